@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/techyoichiro/jobreco-api/infra/database"
@@ -11,35 +12,101 @@ import (
 	"github.com/techyoichiro/jobreco-api/usecase/services"
 )
 
-func initialize() (*gin.Engine, *controller.AuthController, *controller.AttendanceController, *controller.SummaryController) {
+func initialize() (*gin.Engine, *controller.AuthController, *controller.AttendanceController, *controller.SummaryController, *controller.StoreController, *controller.PayrollController, *controller.WageController, *controller.WageRuleController, *controller.ShiftController, *controller.ShiftRequestController, *controller.CorrectionController, *controller.AuditController, *controller.PeriodController, *controller.ExportController, *controller.ImportController, *controller.EmployeeController) {
 	// データベース接続の設定
 	db, err := database.ConnectionDB()
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	// テーブル・制約の作成
+	if err := database.Migrate(db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+
 	// リポジトリの初期化
 	empRepo := repository.NewEmployeeRepository(db)
 	attendanceRepo := repository.NewAttendanceRepository(db)
 	summaryRepo := repository.NewSummaryRepository(db)
+	storeRepo := repository.NewStoreRepository(db)
+	payrollRepo := repository.NewPayrollRepository(db)
+	wageRepo := repository.NewWageRepository(db)
+	wageRuleRepo := repository.NewWageRuleRepository(db)
+	holidayRepo := repository.NewHolidayRepository(db)
+	shiftRepo := repository.NewShiftRepository(db)
+	availabilityRepo := repository.NewAvailabilityRepository(db)
+	staffingTargetRepo := repository.NewStaffingTargetRepository(db)
+	correctionRepo := repository.NewCorrectionRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	shiftSwapRepo := repository.NewShiftSwapRepository(db)
+	periodRepo := repository.NewPeriodRepository(db)
+	minimumWageRepo := repository.NewMinimumWageRepository(db)
 
 	// サービス層の初期化
-	authService := services.NewAuthService(empRepo)
-	attendanceService := services.NewAttendanceService(attendanceRepo)
-	summaryService := services.NewSummaryService(summaryRepo)
+	authService := services.NewAuthService(empRepo, storeRepo, auditRepo, minimumWageRepo)
+	attendanceService := services.NewAttendanceService(attendanceRepo, storeRepo, periodRepo, empRepo)
+	summaryService := services.NewSummaryService(summaryRepo, empRepo, storeRepo, wageRepo)
+	storeService := services.NewStoreService(storeRepo)
+	payrollService := services.NewPayrollService(payrollRepo, summaryRepo, empRepo, storeRepo, wageRepo, wageRuleRepo, holidayRepo, periodRepo, minimumWageRepo)
+	wageService := services.NewWageService(wageRepo, empRepo, periodRepo, storeRepo, minimumWageRepo)
+	wageRuleService := services.NewWageRuleService(wageRuleRepo, holidayRepo, empRepo, storeRepo, minimumWageRepo, periodRepo)
+	shiftService := services.NewShiftService(shiftRepo, empRepo, storeRepo)
+	shiftPlanService := services.NewShiftPlanService(staffingTargetRepo, availabilityRepo, shiftRepo, empRepo, storeRepo, wageRepo, wageRuleRepo, holidayRepo)
+	shiftRequestService := services.NewShiftRequestService(availabilityRepo, shiftSwapRepo, shiftRepo, empRepo)
+	correctionService := services.NewCorrectionService(correctionRepo, summaryRepo, empRepo, storeRepo, periodRepo)
+	auditService := services.NewAuditService(auditRepo, empRepo)
+	reconciliationService := services.NewReconciliationService(summaryRepo, shiftRepo, empRepo, storeRepo)
+	periodService := services.NewPeriodService(periodRepo, storeRepo)
+	importService := services.NewImportService(summaryRepo, empRepo, storeRepo, periodRepo)
+	employeeService := services.NewEmployeeService(empRepo, storeRepo, attendanceRepo, minimumWageRepo)
+
+	// 先の日付で登録した時給の変更を、適用開始日を迎えたら従業員の時給に反映（起動時と日本時間の0時）
+	go applyDueWageChanges(wageService)
 
 	// コントローラの初期化
-	authController := controller.NewAuthController(authService)
+	authController := controller.NewAuthController(authService, attendanceService)
 	attendanceController := controller.NewAttendanceController(attendanceService)
-	summaryController := controller.NewSummaryController(summaryService)
+	summaryController := controller.NewSummaryController(summaryService, reconciliationService)
+	storeController := controller.NewStoreController(storeService)
+	payrollController := controller.NewPayrollController(payrollService)
+	wageController := controller.NewWageController(wageService)
+	wageRuleController := controller.NewWageRuleController(wageRuleService)
+	shiftController := controller.NewShiftController(shiftService, shiftPlanService)
+	shiftRequestController := controller.NewShiftRequestController(shiftRequestService)
+	correctionController := controller.NewCorrectionController(correctionService)
+	auditController := controller.NewAuditController(auditService)
+	periodController := controller.NewPeriodController(periodService)
+	exportController := controller.NewExportController(summaryService, payrollService)
+	importController := controller.NewImportController(importService)
+	employeeController := controller.NewEmployeeController(employeeService)
 
 	// ルータの設定
-	engine := router.SetupRouter(authController, attendanceController, summaryController)
-	return engine, authController, attendanceController, summaryController
+	engine := router.SetupRouter(authController, attendanceController, summaryController, storeController, payrollController, wageController, wageRuleController, shiftController, shiftRequestController, correctionController, auditController, periodController, exportController, importController, employeeController)
+	return engine, authController, attendanceController, summaryController, storeController, payrollController, wageController, wageRuleController, shiftController, shiftRequestController, correctionController, auditController, periodController, exportController, importController, employeeController
+}
+
+// 適用開始日を迎えた時給の変更を日付が変わるたびに反映（失敗した場合は1分後に再実行する）
+func applyDueWageChanges(service *services.WageService) {
+	for {
+		wait := untilNextDay(time.Now())
+		if applied, err := service.ApplyDueWageChanges(); err != nil {
+			log.Printf("Failed to apply wage changes: %v", err)
+			wait = time.Minute
+		} else if applied > 0 {
+			log.Printf("Applied wage changes for %d employees", applied)
+		}
+		time.Sleep(wait)
+	}
+}
+
+// 日本時間の翌日0時までの時間
+func untilNextDay(now time.Time) time.Duration {
+	jst := now.In(time.FixedZone("Asia/Tokyo", 9*60*60))
+	return time.Date(jst.Year(), jst.Month(), jst.Day()+1, 0, 0, 0, 0, jst.Location()).Sub(now)
 }
 
 func main() {
-	engine, _, _, _ := initialize()
+	engine, _, _, _, _, _, _, _, _, _, _, _, _, _, _, _ := initialize()
 
 	// サーバを8080ポートで起動
 	if err := engine.Run(":8080"); err != nil {
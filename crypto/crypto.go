@@ -7,6 +7,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"math/big"
 	"os"
 
 	"golang.org/x/crypto/bcrypt"
@@ -23,6 +24,26 @@ func CompareHashAndPassword(hash, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 }
 
+// 初期パスワードに使う文字（見間違えやすい 0/O・1/l/I を除く）
+const passwordLetters = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// 指定した長さのランダムな初期パスワードを生成
+func GeneratePassword(length int) (string, error) {
+	if length <= 0 {
+		return "", fmt.Errorf("invalid password length: %d", length)
+	}
+	password := make([]byte, length)
+	max := big.NewInt(int64(len(passwordLetters)))
+	for i := range password {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		password[i] = passwordLetters[n.Int64()]
+	}
+	return string(password), nil
+}
+
 // メールアドレスを暗号化する関数
 func EncryptEmail(email string) (string, error) {
 	encryptionKey := os.Getenv("ENCRYPTION_KEY")
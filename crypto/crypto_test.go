@@ -2,6 +2,7 @@ package crypto
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"golang.org/x/crypto/bcrypt"
@@ -216,3 +217,42 @@ func TestDecryptEmail(t *testing.T) {
 		})
 	}
 }
+
+// GeneratePassword のテスト
+func TestGeneratePassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		length  int
+		wantErr bool
+	}{
+		{name: "12 characters", length: 12},
+		{name: "1 character", length: 1},
+		{name: "Zero length", length: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GeneratePassword(tt.length)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GeneratePassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != tt.length {
+				t.Errorf("GeneratePassword() length = %d, want %d", len(got), tt.length)
+			}
+			for _, r := range got {
+				if !strings.ContainsRune(passwordLetters, r) {
+					t.Errorf("GeneratePassword() = %q contains %q", got, r)
+				}
+			}
+		})
+	}
+
+	// 生成するたびに異なるパスワードになる
+	first, _ := GeneratePassword(12)
+	second, _ := GeneratePassword(12)
+	if first == second {
+		t.Errorf("GeneratePassword() returned the same password twice: %q", first)
+	}
+}
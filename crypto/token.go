@@ -0,0 +1,78 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// アクセストークンの有効期限
+const TokenExpiration = 12 * time.Hour
+
+// アクセストークンに格納する情報
+type Claims struct {
+	EmployeeID uint `json:"employee_id"`
+	RoleID     int  `json:"role_id"`
+	StoreID    int  `json:"store_id"`
+	jwt.RegisteredClaims
+}
+
+// 署名鍵を環境変数から取得
+func tokenSecret() ([]byte, error) {
+	secret := os.Getenv("JWT_SECRET_KEY")
+	if secret == "" {
+		return nil, fmt.Errorf("JWT_SECRET_KEY environment variable is not set")
+	}
+	return []byte(secret), nil
+}
+
+// アクセストークンを発行する関数
+func GenerateToken(employeeID uint, roleID int, storeID int) (string, time.Time, error) {
+	secret, err := tokenSecret()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(TokenExpiration)
+	claims := Claims{
+		EmployeeID: employeeID,
+		RoleID:     roleID,
+		StoreID:    storeID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   fmt.Sprint(employeeID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// アクセストークンを検証して格納された情報を返す関数
+func ParseToken(tokenString string) (*Claims, error) {
+	secret, err := tokenSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid || claims.EmployeeID == 0 {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
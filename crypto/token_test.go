@@ -0,0 +1,77 @@
+package crypto
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// GenerateToken と ParseToken のテスト
+func TestGenerateAndParseToken(t *testing.T) {
+	os.Setenv("JWT_SECRET_KEY", "test_secret")
+
+	token, expiresAt, err := GenerateToken(10, 2, 3)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if !expiresAt.After(time.Now()) {
+		t.Errorf("expiresAt should be in the future, got %v", expiresAt)
+	}
+
+	claims, err := ParseToken(token)
+	if err != nil {
+		t.Fatalf("ParseToken() error = %v", err)
+	}
+	if claims.EmployeeID != 10 {
+		t.Errorf("expected EmployeeID 10, got %v", claims.EmployeeID)
+	}
+	if claims.RoleID != 2 {
+		t.Errorf("expected RoleID 2, got %v", claims.RoleID)
+	}
+	if claims.StoreID != 3 {
+		t.Errorf("expected StoreID 3, got %v", claims.StoreID)
+	}
+}
+
+// ParseToken の異常系テスト
+func TestParseTokenInvalid(t *testing.T) {
+	os.Setenv("JWT_SECRET_KEY", "test_secret")
+
+	expired := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		EmployeeID: 1,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+		},
+	})
+	expiredToken, _ := expired.SignedString([]byte("test_secret"))
+
+	otherKey := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		EmployeeID: 1,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+	otherKeyToken, _ := otherKey.SignedString([]byte("other_secret"))
+
+	noExpiry := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{EmployeeID: 1})
+	noExpiryToken, _ := noExpiry.SignedString([]byte("test_secret"))
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "Expired token", token: expiredToken},
+		{name: "Signed with other key", token: otherKeyToken},
+		{name: "No expiry", token: noExpiryToken},
+		{name: "Malformed token", token: "invalid_token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseToken(tt.token); err == nil {
+				t.Errorf("ParseToken() expected error for %s", tt.name)
+			}
+		})
+	}
+}
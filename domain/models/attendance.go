@@ -8,33 +8,58 @@ import (
 
 type Attendance struct {
 	gorm.Model
-	ID         uint       `gorm:"primaryKey"`
-	EmployeeID uint       `gorm:"not null;index;uniqueIndex:unique_attendance"`     // 外部キー：employees テーブル、一意制約に含める
-	WorkDate   time.Time  `gorm:"type:date;not null;uniqueIndex:unique_attendance"` // 勤務日、一意制約に含める
-	StartTime1 *time.Time `gorm:"type:timestamp"`                                   // 勤務開始時間1
-	EndTime1   *time.Time `gorm:"type:timestamp"`                                   // 勤務終了時間1
-	StartTime2 *time.Time `gorm:"type:timestamp"`                                   // 勤務開始時間2（オプション）
-	EndTime2   *time.Time `gorm:"type:timestamp"`                                   // 勤務終了時間2（オプション）
-	BreakStart *time.Time `gorm:"type:timestamp"`                                   // 休憩開始時間
-	BreakEnd   *time.Time `gorm:"type:timestamp"`                                   // 休憩終了時間
-	StoreID1   uint       `gorm:"not null"`                                         // 外部キー：stores テーブル
-	StoreID2   *uint      `gorm:""`                                                 // 外部キー（オプション）：stores テーブル
-	StatusID   int        `gorm:"not null"`                                         // 勤務ステータスID
+	ID         uint             `gorm:"primaryKey"`
+	EmployeeID uint             `gorm:"not null;index;uniqueIndex:unique_attendance"`     // 外部キー：employees テーブル、一意制約に含める
+	WorkDate   time.Time        `gorm:"type:date;not null;uniqueIndex:unique_attendance"` // 勤務日、一意制約に含める
+	StatusID   AttendanceStatus `gorm:"not null"`                                         // 勤務ステータスID
+
+	Segments []WorkSegment     `gorm:"foreignKey:AttendanceID;constraint:OnDelete:CASCADE"` // 勤務区間（開始時間順）
+	Breaks   []AttendanceBreak `gorm:"foreignKey:AttendanceID;constraint:OnDelete:CASCADE"` // 休憩（開始時間順）
+}
+
+// 最後の勤務区間を返す（勤務区間がない場合は nil）
+func (a *Attendance) LastSegment() *WorkSegment {
+	if len(a.Segments) == 0 {
+		return nil
+	}
+	return &a.Segments[len(a.Segments)-1]
+}
+
+// 終了していない休憩を返す（休憩中でない場合は nil）
+func (a *Attendance) OpenBreak() *AttendanceBreak {
+	for i := len(a.Breaks) - 1; i >= 0; i-- {
+		if a.Breaks[i].EndTime == nil {
+			return &a.Breaks[i]
+		}
+	}
+	return nil
 }
 
 type AttendanceResponse struct {
-	ID            uint    `json:"ID"`
-	WorkDate      string  `json:"WorkDate"`
-	StartTime1    string  `json:"StartTime1"`
-	EndTime1      string  `json:"EndTime1,omitempty"`
-	StartTime2    string  `json:"StartTime2"`
-	EndTime2      string  `json:"EndTime2"`
-	BreakStart    string  `json:"BreakStart"`
-	BreakEnd      string  `json:"BreakEnd"`
-	TotalWorkTime string  `json:"TotalWorkTime"`
-	Overtime      float64 `json:"Overtime"`
-	StoreID1      uint    `json:"StoreID1"`
-	StoreID2      *uint   `json:"StoreID2"`
-	Remarks       string  `json:"Remarks"`
-	HourlyPay     int     `json:"HourlyPay"`
+	ID             uint                      `json:"ID"`
+	WorkDate       string                    `json:"WorkDate"`
+	Segments       []WorkSegmentResponse     `json:"Segments"`
+	Breaks         []AttendanceBreakResponse `json:"Breaks"`
+	TotalBreakTime string                    `json:"TotalBreakTime"`
+	TotalWorkTime  string                    `json:"TotalWorkTime"`
+	LateNight      float64                   `json:"LateNight"`      // 深夜時間（22:00〜翌5:00）
+	DailyOvertime  float64                   `json:"DailyOvertime"`  // 1日8時間を超えた時間
+	WeeklyOvertime float64                   `json:"WeeklyOvertime"` // 週40時間を超えた時間
+	Overtime       float64                   `json:"Overtime"`       // 法定時間外労働の合計
+	Holiday        bool                      `json:"Holiday"`        // 法定休日の勤務
+	Remarks        string                    `json:"Remarks"`
+	HourlyPay      int                       `json:"HourlyPay"`
+}
+
+// 月次の勤怠サマリ
+type MonthlySummary struct {
+	Attendances     []AttendanceResponse `json:"Attendances"`
+	TotalWorkTime   float64              `json:"TotalWorkTime"`   // 実労働時間の合計
+	LateNight       float64              `json:"LateNight"`       // 深夜時間の合計
+	DailyOvertime   float64              `json:"DailyOvertime"`   // 1日8時間を超えた時間の合計
+	WeeklyOvertime  float64              `json:"WeeklyOvertime"`  // 週40時間を超えた時間の合計
+	Overtime        float64              `json:"Overtime"`        // 法定時間外労働の合計
+	OvertimeUpTo60  float64              `json:"OvertimeUpTo60"`  // 時間外労働のうち月60時間以内の部分
+	OvertimeOver60  float64              `json:"OvertimeOver60"`  // 時間外労働のうち月60時間を超えた部分
+	HolidayWorkTime float64              `json:"HolidayWorkTime"` // 法定休日の労働時間の合計
 }
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 休憩の種類
+type BreakType string
+
+const (
+	BreakTypeMeal   BreakType = "meal"   // 食事休憩
+	BreakTypeRest   BreakType = "rest"   // 小休憩
+	BreakTypeErrand BreakType = "errand" // 外出
+)
+
+// 休憩の種類として有効な値か
+func (t BreakType) IsValid() bool {
+	switch t {
+	case BreakTypeMeal, BreakTypeRest, BreakTypeErrand:
+		return true
+	default:
+		return false
+	}
+}
+
+// 休憩の種類の表示名
+func (t BreakType) Label() string {
+	switch t {
+	case BreakTypeMeal:
+		return "食事"
+	case BreakTypeRest:
+		return "休憩"
+	case BreakTypeErrand:
+		return "外出"
+	default:
+		return string(t)
+	}
+}
+
+// 休憩（1日に複数回記録できる）
+type AttendanceBreak struct {
+	gorm.Model
+	AttendanceID uint       `gorm:"not null;index"`          // 外部キー：attendances テーブル
+	BreakType    BreakType  `gorm:"size:20;not null"`        // 休憩の種類
+	StartTime    time.Time  `gorm:"type:timestamp;not null"` // 休憩開始時間
+	EndTime      *time.Time `gorm:"type:timestamp"`          // 休憩終了時間（休憩中は nil）
+}
+
+type AttendanceBreakResponse struct {
+	ID        uint      `json:"ID"`
+	BreakType BreakType `json:"BreakType"`
+	StartTime string    `json:"StartTime"`
+	EndTime   string    `json:"EndTime"`
+}
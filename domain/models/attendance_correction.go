@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 勤怠の修正申請の状態
+type CorrectionStatus string
+
+const (
+	CorrectionPending   CorrectionStatus = "pending"   // 承認待ち
+	CorrectionApproved  CorrectionStatus = "approved"  // 承認済み（勤怠に反映済み）
+	CorrectionRejected  CorrectionStatus = "rejected"  // 却下
+	CorrectionCancelled CorrectionStatus = "cancelled" // 取り下げ
+)
+
+// 勤怠の修正申請（承認されたものだけ勤怠に反映し、申請の履歴は残す）
+type AttendanceCorrection struct {
+	gorm.Model
+	AttendanceID uint             `gorm:"not null;index"`                   // 外部キー：attendances テーブル
+	EmployeeID   uint             `gorm:"not null;index"`                   // 勤怠の従業員
+	RequesterID  uint             `gorm:"not null;index"`                   // 申請者
+	Reason       string           `gorm:"size:255;not null"`                // 申請理由
+	Status       CorrectionStatus `gorm:"size:20;not null;default:pending"` // 状態
+	ReviewedByID *uint            // 承認・却下した従業員
+	ReviewedAt   *time.Time       `gorm:"type:timestamp"` // 承認・却下日時
+	ReviewNote   string           `gorm:"size:255"`       // 承認・却下時のコメント
+
+	Items []AttendanceCorrectionItem `gorm:"foreignKey:CorrectionID;constraint:OnDelete:CASCADE"` // 修正する項目
+
+	Attendance *Attendance `gorm:"foreignKey:AttendanceID" json:"-"`
+	Employee   *Employee   `gorm:"foreignKey:EmployeeID" json:"-"`
+	Requester  *Employee   `gorm:"foreignKey:RequesterID" json:"-"`
+}
+
+// 承認待ちかどうか
+func (c *AttendanceCorrection) IsPending() bool {
+	return c.Status == CorrectionPending
+}
+
+// 修正する項目
+// Field は「segments.0.start_time」のように勤務区間・休憩の番号（0始まり）と項目名を指定する
+type AttendanceCorrectionItem struct {
+	gorm.Model
+	CorrectionID uint   `gorm:"not null;index"`   // 外部キー：attendance_corrections テーブル
+	Field        string `gorm:"size:50;not null"` // 項目
+	OldValue     string `gorm:"size:50"`          // 申請時の値
+	NewValue     string `gorm:"size:50"`          // 修正後の値（開始時刻を空にすると勤務区間・休憩を削除する）
+}
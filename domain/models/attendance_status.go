@@ -0,0 +1,30 @@
+package model
+
+// 勤務ステータス
+type AttendanceStatus int
+
+const (
+	StatusNotStarted AttendanceStatus = 0 // 未出勤
+	StatusWorking    AttendanceStatus = 1 // 出勤
+	StatusOut        AttendanceStatus = 2 // 外出
+	StatusClockedOut AttendanceStatus = 3 // 退勤
+	StatusReturned   AttendanceStatus = 4 // 休憩戻り
+)
+
+// ステータスの表示名
+func (s AttendanceStatus) Label() string {
+	switch s {
+	case StatusNotStarted:
+		return "未出勤"
+	case StatusWorking:
+		return "出勤"
+	case StatusOut:
+		return "外出"
+	case StatusClockedOut:
+		return "退勤"
+	case StatusReturned:
+		return "休憩戻り"
+	default:
+		return "不明"
+	}
+}
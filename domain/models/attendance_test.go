@@ -16,7 +16,7 @@ func TestAttendanceCRUD(t *testing.T) {
 	}
 
 	// テーブルを作成
-	err = db.AutoMigrate(&Attendance{})
+	err = db.AutoMigrate(&Attendance{}, &WorkSegment{}, &AttendanceBreak{})
 	if err != nil {
 		t.Fatalf("failed to migrate database: %v", err)
 	}
@@ -27,10 +27,13 @@ func TestAttendanceCRUD(t *testing.T) {
 	attendance := Attendance{
 		EmployeeID: 1,
 		WorkDate:   time.Now(),
-		StartTime1: &startTime1,
-		EndTime1:   &endTime1,
-		StoreID1:   1,
 		StatusID:   1,
+		Segments: []WorkSegment{
+			{StoreID: 1, StartTime: startTime1, EndTime: &endTime1},
+		},
+		Breaks: []AttendanceBreak{
+			{BreakType: BreakTypeMeal, StartTime: startTime1.Add(3 * time.Hour)},
+		},
 	}
 
 	// Create: データを保存
@@ -40,7 +43,7 @@ func TestAttendanceCRUD(t *testing.T) {
 
 	// Read: 保存したデータを取得
 	var result Attendance
-	if err := db.First(&result, attendance.ID).Error; err != nil {
+	if err := db.Preload("Segments").Preload("Breaks").First(&result, attendance.ID).Error; err != nil {
 		t.Fatalf("failed to read attendance: %v", err)
 	}
 
@@ -48,20 +51,26 @@ func TestAttendanceCRUD(t *testing.T) {
 	if result.EmployeeID != attendance.EmployeeID {
 		t.Errorf("expected EmployeeID %v, got %v", attendance.EmployeeID, result.EmployeeID)
 	}
+	if len(result.Segments) != 1 {
+		t.Fatalf("expected 1 segment, got %v", len(result.Segments))
+	}
+	if result.OpenBreak() == nil || result.OpenBreak().BreakType != BreakTypeMeal {
+		t.Errorf("expected open meal break, got %+v", result.Breaks)
+	}
 
 	// Update: データを更新
 	newStoreID := uint(2)
-	if err := db.Model(&result).Update("StoreID1", newStoreID).Error; err != nil {
+	if err := db.Model(&result.Segments[0]).Update("StoreID", newStoreID).Error; err != nil {
 		t.Fatalf("failed to update attendance: %v", err)
 	}
 
 	// 更新が反映されているか確認
 	var updatedResult Attendance
-	if err := db.First(&updatedResult, attendance.ID).Error; err != nil {
+	if err := db.Preload("Segments").First(&updatedResult, attendance.ID).Error; err != nil {
 		t.Fatalf("failed to read updated attendance: %v", err)
 	}
-	if updatedResult.StoreID1 != newStoreID {
-		t.Errorf("expected StoreID1 %v, got %v", newStoreID, updatedResult.StoreID1)
+	if updatedResult.LastSegment().StoreID != newStoreID {
+		t.Errorf("expected StoreID %v, got %v", newStoreID, updatedResult.LastSegment().StoreID)
 	}
 
 	// Delete: データを削除
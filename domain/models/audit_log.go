@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+)
+
+// 監査ログの対象
+type AuditEntity string
+
+const (
+	AuditEntityAttendance AuditEntity = "attendance" // 勤怠
+	AuditEntityEmployee   AuditEntity = "employee"   // 従業員
+)
+
+// 変更の種類
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create" // 作成
+	AuditActionUpdate AuditAction = "update" // 更新
+)
+
+// 変更の経路
+type AuditSource string
+
+const (
+	AuditSourcePunch      AuditSource = "punch"       // 打刻
+	AuditSourceManualEdit AuditSource = "manual_edit" // 画面からの登録・修正
+	AuditSourceImport     AuditSource = "import"      // 取り込み
+)
+
+// JSON文字列（レスポンスではそのままJSONとして出力する）
+type RawJSON string
+
+func (j RawJSON) MarshalJSON() ([]byte, error) {
+	if j == "" {
+		return []byte("null"), nil
+	}
+	return []byte(j), nil
+}
+
+// 監査ログ（追記のみで、更新・削除はしない）
+type AuditLog struct {
+	ID         uint        `gorm:"primaryKey"`
+	CreatedAt  time.Time   `gorm:"not null;index"`         // 記録日時
+	ActorID    *uint       `gorm:"index"`                  // 操作者（サインアップなど操作者がいない場合は nil）
+	EmployeeID uint        `gorm:"not null;index"`         // 対象の従業員
+	Entity     AuditEntity `gorm:"size:30;not null;index"` // 対象
+	EntityID   uint        `gorm:"not null"`               // 対象のID
+	Action     AuditAction `gorm:"size:20;not null"`       // 変更の種類
+	Source     AuditSource `gorm:"size:20;not null"`       // 変更の経路
+	Before     RawJSON     `gorm:"type:text"`              // 変更前の内容（作成の場合は空）
+	After      RawJSON     `gorm:"type:text"`              // 変更後の内容
+	Changes    RawJSON     `gorm:"type:text"`              // 変更された項目ごとの変更前・変更後
+	Note       string      `gorm:"size:255"`               // 補足（修正申請の番号など）
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 勤務希望の区分
+type AvailabilityStatus string
+
+const (
+	AvailabilityAvailable   AvailabilityStatus = "available"   // 勤務可能
+	AvailabilityUnavailable AvailabilityStatus = "unavailable" // 勤務不可
+	AvailabilityPreferred   AvailabilityStatus = "preferred"   // 勤務を希望
+)
+
+// 定義済みの区分かどうか
+func (s AvailabilityStatus) IsValid() bool {
+	switch s {
+	case AvailabilityAvailable, AvailabilityUnavailable, AvailabilityPreferred:
+		return true
+	default:
+		return false
+	}
+}
+
+// 勤務希望（日付・時間帯ごと、1日に複数登録できる）
+type Availability struct {
+	gorm.Model
+	EmployeeID uint               `gorm:"not null;index"`           // 外部キー：employees テーブル
+	Date       time.Time          `gorm:"type:date;not null;index"` // 対象日
+	Status     AvailabilityStatus `gorm:"size:20;not null"`         // 区分
+	StartTime  string             `gorm:"size:5"`                   // 時間帯の開始（HH:MM、空の場合は終日）
+	EndTime    string             `gorm:"size:5"`                   // 時間帯の終了（HH:MM、開始より前の場合は翌日まで）
+	Note       string             `gorm:"size:255"`                 // メモ
+
+	Employee *Employee `gorm:"foreignKey:EmployeeID" json:"-"`
+}
@@ -1,15 +1,36 @@
 package model
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type Employee struct {
 	gorm.Model
-	Name             string `gorm:"size:100;not null"`
-	LoginID          string `gorm:"size:50;unique;not null"`
-	Password         string `gorm:"size:255;not null"`
-	RoleID           int    `gorm:"not null"`
-	HourlyPay        int    `gorm:"not null"`
-	CompetentStoreID int
+	Name               string     `gorm:"size:100;not null"`
+	LoginID            string     `gorm:"size:50;unique;not null"`
+	Password           string     `gorm:"size:255;not null"`
+	RoleID             int        `gorm:"not null"`
+	HourlyPay          int        `gorm:"not null"`
+	CompetentStoreID   *int       `gorm:"index"` // 担当店舗（未設定の場合は nil）
+	DeactivatedAt      *time.Time // 退職処理の日時（nil の場合は在籍中）
+	DeactivatedByID    *uint      // 退職処理を行った従業員
+	DeactivationReason string     `gorm:"size:255"`
+	RetentionUntil     *time.Time `gorm:"type:date"` // 勤怠記録の保存期限（退職処理の日から法定の保存期間）
+
+	CompetentStore *Store `gorm:"foreignKey:CompetentStoreID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+}
+
+// 担当店舗のID（未設定の場合は0）
+func (e *Employee) StoreID() uint {
+	if e.CompetentStoreID == nil {
+		return 0
+	}
+	return uint(*e.CompetentStoreID)
+}
+
+// 在籍中か（退職処理をした従業員はログイン・打刻できない）
+func (e *Employee) IsActive() bool {
+	return e.DeactivatedAt == nil
 }
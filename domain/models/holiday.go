@@ -0,0 +1,14 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 祝日（賃金ルールの曜日区分の判定に使う）
+type Holiday struct {
+	gorm.Model
+	Date time.Time `gorm:"type:date;not null;uniqueIndex"` // 日付
+	Name string    `gorm:"size:100;not null"`              // 祝日名
+}
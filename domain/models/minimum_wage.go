@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 都道府県別の地域別最低賃金（改定ごとに適用開始日を指定して登録する）
+type MinimumWage struct {
+	gorm.Model
+	Prefecture    string    `gorm:"size:10;not null;uniqueIndex:idx_minimum_wage"`   // 都道府県
+	EffectiveFrom time.Time `gorm:"type:date;not null;uniqueIndex:idx_minimum_wage"` // 適用開始日
+	HourlyWage    int       `gorm:"not null"`                                        // 時間額
+}
+
+// 都道府県（全国地方公共団体コード順）
+var Prefectures = []string{
+	"北海道", "青森県", "岩手県", "宮城県", "秋田県", "山形県", "福島県",
+	"茨城県", "栃木県", "群馬県", "埼玉県", "千葉県", "東京都", "神奈川県",
+	"新潟県", "富山県", "石川県", "福井県", "山梨県", "長野県", "岐阜県",
+	"静岡県", "愛知県", "三重県", "滋賀県", "京都府", "大阪府", "兵庫県",
+	"奈良県", "和歌山県", "鳥取県", "島根県", "岡山県", "広島県", "山口県",
+	"徳島県", "香川県", "愛媛県", "高知県", "福岡県", "佐賀県", "長崎県",
+	"熊本県", "大分県", "宮崎県", "鹿児島県", "沖縄県",
+}
+
+// 都道府県名として正しいか
+func IsValidPrefecture(name string) bool {
+	for _, prefecture := range Prefectures {
+		if prefecture == name {
+			return true
+		}
+	}
+	return false
+}
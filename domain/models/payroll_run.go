@@ -0,0 +1,40 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// 給与計算の結果（作成後は変更せず、再計算する場合は新しく作成する）
+type PayrollRun struct {
+	gorm.Model
+	EmployeeID           uint    `gorm:"not null;index:idx_payroll_period"` // 外部キー：employees テーブル
+	Year                 int     `gorm:"not null;index:idx_payroll_period"` // 対象年
+	Month                int     `gorm:"not null;index:idx_payroll_period"` // 対象月
+	HourlyPay            int     `gorm:"not null"`                          // 月末時点の時給
+	WorkHours            float64 `gorm:"not null"`                          // 実労働時間
+	LateNightHours       float64 `gorm:"not null"`                          // 深夜時間
+	OvertimeHours        float64 `gorm:"not null"`                          // 法定時間外労働（月60時間以内）
+	OvertimeOver60Hours  float64 `gorm:"not null"`                          // 法定時間外労働（月60時間超）
+	HolidayHours         float64 `gorm:"not null"`                          // 法定休日の労働時間
+	BasePay              int     `gorm:"not null"`                          // 基本給（実労働時間 × 時給）
+	LateNightPremium     int     `gorm:"not null"`                          // 深夜割増
+	OvertimePremium      int     `gorm:"not null"`                          // 時間外割増
+	HolidayPremium       int     `gorm:"not null"`                          // 休日割増
+	Allowances           int     `gorm:"not null"`                          // 手当
+	GrossPay             int     `gorm:"not null"`                          // 総支給額
+	TotalDeductions      int     `gorm:"not null;default:0"`                // 控除額の合計
+	NetPay               int     `gorm:"not null;default:0"`                // 差引支給額（総支給額 − 控除額の合計）
+	MinimumWageShortfall int     `gorm:"not null;default:0"`                // 最低賃金に満たない額（0 より大きい場合は時給の見直しが必要）
+
+	Deductions []PayrollDeduction `gorm:"foreignKey:PayrollRunID;constraint:OnDelete:CASCADE"` // 控除の内訳
+
+	Employee *Employee `gorm:"foreignKey:EmployeeID" json:"-"`
+}
+
+// 給与から控除する項目（源泉所得税・社会保険料など、給与計算の実行時に入力する）
+type PayrollDeduction struct {
+	gorm.Model
+	PayrollRunID uint   `gorm:"not null;index"`   // 外部キー：payroll_runs テーブル
+	Name         string `gorm:"size:50;not null"` // 項目名
+	Amount       int    `gorm:"not null"`         // 金額
+}
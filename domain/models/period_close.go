@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 店舗・月ごとの締め（締めた月の勤怠・給与は変更できない）
+// 再開した締めも履歴として残し、再度締める場合は新しく作成する
+type PeriodClose struct {
+	gorm.Model
+	StoreID      uint       `gorm:"not null;index:idx_period_close"` // 外部キー：stores テーブル
+	Year         int        `gorm:"not null;index:idx_period_close"` // 対象年
+	Month        int        `gorm:"not null;index:idx_period_close"` // 対象月
+	ClosedByID   uint       `gorm:"not null"`                        // 締めた従業員
+	ClosedAt     time.Time  `gorm:"type:timestamp;not null"`         // 締めた日時
+	ReopenedByID *uint      // 再開した従業員
+	ReopenedAt   *time.Time `gorm:"type:timestamp"` // 再開日時
+	ReopenReason string     `gorm:"size:255"`       // 再開の理由
+
+	Store *Store `gorm:"foreignKey:StoreID" json:"-"`
+}
+
+// 締めが有効か（再開されていないか）
+func (p *PeriodClose) IsClosed() bool {
+	return p.ReopenedAt == nil
+}
@@ -0,0 +1,27 @@
+package model
+
+// シフトと実績の差異の種類
+type DeviationType string
+
+const (
+	DeviationLate        DeviationType = "late"        // 遅刻
+	DeviationEarlyLeave  DeviationType = "early_leave" // 早退
+	DeviationNoShow      DeviationType = "no_show"     // 欠勤（シフトがあるのに出勤していない）
+	DeviationUnscheduled DeviationType = "unscheduled" // シフトのない日の勤務
+)
+
+// シフトと実績の差異
+type Deviation struct {
+	Type    DeviationType `json:"Type"`
+	Minutes int           `json:"Minutes"` // 差異の時間（分）
+}
+
+// 1日分のシフトと実績の突き合わせ結果
+type ReconciliationDay struct {
+	WorkDate     string      `json:"WorkDate"`
+	PlannedStart string      `json:"PlannedStart"` // シフトの開始時刻
+	PlannedEnd   string      `json:"PlannedEnd"`   // シフトの終了時刻
+	ActualStart  string      `json:"ActualStart"`  // 出勤時刻
+	ActualEnd    string      `json:"ActualEnd"`    // 退勤時刻
+	Deviations   []Deviation `json:"Deviations"`
+}
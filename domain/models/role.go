@@ -0,0 +1,83 @@
+package model
+
+// 従業員の権限
+type Role int
+
+const (
+	RoleStaff        Role = 1 // 従業員
+	RoleStoreManager Role = 2 // 店長
+	RoleOwner        Role = 3 // オーナー
+)
+
+// 権限を適用できる範囲
+type Scope int
+
+const (
+	ScopeNone  Scope = iota // 操作不可
+	ScopeOwn                // 自分自身のみ
+	ScopeStore              // 担当店舗の従業員
+	ScopeAll                // 全従業員
+)
+
+// 操作の種類
+type Permission string
+
+const (
+	PermPunch             Permission = "attendance:punch"   // 打刻
+	PermViewSummary       Permission = "summary:view"       // 勤怠サマリの閲覧
+	PermEditAttendance    Permission = "attendance:edit"    // 勤怠の修正の申請
+	PermListEmployees     Permission = "employee:list"      // 従業員一覧の閲覧
+	PermUpdateAccount     Permission = "employee:update"    // アカウント情報の更新
+	PermAssignStore       Permission = "employee:store"     // 担当店舗の変更
+	PermUpdateHourlyPay   Permission = "employee:pay"       // 時給の変更
+	PermManageStores      Permission = "store:manage"       // 店舗マスタの管理
+	PermViewPayroll       Permission = "payroll:view"       // 給与計算の結果の閲覧
+	PermRunPayroll        Permission = "payroll:run"        // 給与計算の実行
+	PermViewShifts        Permission = "shift:view"         // 自分のシフトの閲覧
+	PermManageShifts      Permission = "shift:manage"       // シフトの作成・編集・公開
+	PermRequestShifts     Permission = "shift:request"      // 勤務希望の提出・シフト交代の申請
+	PermApproveCorrection Permission = "attendance:approve" // 勤怠の修正申請の承認
+	PermViewAuditLog      Permission = "audit:view"         // 監査ログの閲覧
+	PermClosePeriod       Permission = "period:close"       // 月次の締め
+	PermReopenPeriod      Permission = "period:reopen"      // 締めた月の再開
+	PermImportAttendance  Permission = "attendance:import"  // 過去の勤怠の一括取り込み
+	PermManageEmployees   Permission = "employee:manage"    // 従業員の一括登録・退職処理
+)
+
+// 権限ごとの操作範囲
+var permissionMatrix = map[Permission]map[Role]Scope{
+	PermPunch:             {RoleStaff: ScopeOwn, RoleStoreManager: ScopeOwn, RoleOwner: ScopeOwn},
+	PermViewSummary:       {RoleStaff: ScopeOwn, RoleStoreManager: ScopeStore, RoleOwner: ScopeAll},
+	PermEditAttendance:    {RoleStaff: ScopeOwn, RoleStoreManager: ScopeStore, RoleOwner: ScopeAll},
+	PermListEmployees:     {RoleStoreManager: ScopeStore, RoleOwner: ScopeAll},
+	PermUpdateAccount:     {RoleStaff: ScopeOwn, RoleStoreManager: ScopeOwn, RoleOwner: ScopeAll},
+	PermAssignStore:       {RoleStoreManager: ScopeStore, RoleOwner: ScopeAll},
+	PermUpdateHourlyPay:   {RoleOwner: ScopeAll},
+	PermManageStores:      {RoleOwner: ScopeAll},
+	PermViewPayroll:       {RoleStaff: ScopeOwn, RoleStoreManager: ScopeStore, RoleOwner: ScopeAll},
+	PermRunPayroll:        {RoleOwner: ScopeAll},
+	PermViewShifts:        {RoleStaff: ScopeOwn, RoleStoreManager: ScopeOwn, RoleOwner: ScopeOwn},
+	PermManageShifts:      {RoleStoreManager: ScopeStore, RoleOwner: ScopeAll},
+	PermRequestShifts:     {RoleStaff: ScopeOwn, RoleStoreManager: ScopeOwn, RoleOwner: ScopeOwn},
+	PermApproveCorrection: {RoleStoreManager: ScopeStore, RoleOwner: ScopeAll},
+	PermViewAuditLog:      {RoleStoreManager: ScopeStore, RoleOwner: ScopeAll},
+	PermClosePeriod:       {RoleStoreManager: ScopeStore, RoleOwner: ScopeAll},
+	PermReopenPeriod:      {RoleOwner: ScopeAll},
+	PermImportAttendance:  {RoleStoreManager: ScopeStore, RoleOwner: ScopeAll},
+	PermManageEmployees:   {RoleStoreManager: ScopeStore, RoleOwner: ScopeAll},
+}
+
+// 定義されている権限か
+func (r Role) IsValid() bool {
+	return r >= RoleStaff && r <= RoleOwner
+}
+
+// 指定した操作を行える範囲を返す
+func (r Role) Scope(p Permission) Scope {
+	return permissionMatrix[p][r]
+}
+
+// 指定した操作を行えるか
+func (r Role) HasPermission(p Permission) bool {
+	return r.Scope(p) != ScopeNone
+}
@@ -0,0 +1,36 @@
+package model
+
+import "testing"
+
+func TestRoleScope(t *testing.T) {
+	tests := []struct {
+		name string
+		role Role
+		perm Permission
+		want Scope
+	}{
+		{name: "Staff views own summary", role: RoleStaff, perm: PermViewSummary, want: ScopeOwn},
+		{name: "Manager views store summary", role: RoleStoreManager, perm: PermViewSummary, want: ScopeStore},
+		{name: "Owner views all summary", role: RoleOwner, perm: PermViewSummary, want: ScopeAll},
+		{name: "Staff cannot list employees", role: RoleStaff, perm: PermListEmployees, want: ScopeNone},
+		{name: "Manager cannot change hourly pay", role: RoleStoreManager, perm: PermUpdateHourlyPay, want: ScopeNone},
+		{name: "Owner changes hourly pay", role: RoleOwner, perm: PermUpdateHourlyPay, want: ScopeAll},
+		{name: "Staff views own payroll", role: RoleStaff, perm: PermViewPayroll, want: ScopeOwn},
+		{name: "Manager cannot run payroll", role: RoleStoreManager, perm: PermRunPayroll, want: ScopeNone},
+		{name: "Staff cannot manage shifts", role: RoleStaff, perm: PermManageShifts, want: ScopeNone},
+		{name: "Manager manages store shifts", role: RoleStoreManager, perm: PermManageShifts, want: ScopeStore},
+		{name: "Staff requests own shift swaps", role: RoleStaff, perm: PermRequestShifts, want: ScopeOwn},
+		{name: "Staff cannot approve corrections", role: RoleStaff, perm: PermApproveCorrection, want: ScopeNone},
+		{name: "Manager approves store corrections", role: RoleStoreManager, perm: PermApproveCorrection, want: ScopeStore},
+		{name: "Staff cannot manage employees", role: RoleStaff, perm: PermManageEmployees, want: ScopeNone},
+		{name: "Manager manages store employees", role: RoleStoreManager, perm: PermManageEmployees, want: ScopeStore},
+		{name: "Unknown role", role: Role(0), perm: PermPunch, want: ScopeNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.role.Scope(tt.perm); got != tt.want {
+				t.Errorf("Scope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
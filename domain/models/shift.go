@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// シフト（勤務予定）
+type Shift struct {
+	gorm.Model
+	EmployeeID  uint       `gorm:"not null;index"` // 外部キー：employees テーブル
+	StoreID     uint       `gorm:"not null;index"` // 外部キー：stores テーブル
+	StartTime   time.Time  `gorm:"not null;index"` // 予定開始時刻
+	EndTime     time.Time  `gorm:"not null"`       // 予定終了時刻
+	Position    string     `gorm:"size:50"`        // 担当（ホール・キッチンなど）
+	Note        string     `gorm:"size:255"`       // メモ
+	PublishedAt *time.Time `gorm:"type:timestamp"` // 公開日時（nil は下書き）
+
+	Employee *Employee `gorm:"foreignKey:EmployeeID" json:"-"`
+	Store    *Store    `gorm:"foreignKey:StoreID" json:"-"`
+}
+
+// 公開済みかどうか
+func (s *Shift) IsPublished() bool {
+	return s.PublishedAt != nil
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// シフト交代の種類
+type SwapType string
+
+const (
+	SwapTypeSwap     SwapType = "swap"     // 相手のシフトと交換する
+	SwapTypeGiveaway SwapType = "giveaway" // 相手に譲る
+)
+
+// 定義済みの種類かどうか
+func (t SwapType) IsValid() bool {
+	return t == SwapTypeSwap || t == SwapTypeGiveaway
+}
+
+// シフト交代の申請の状態
+type SwapStatus string
+
+const (
+	SwapStatusPending   SwapStatus = "pending"   // 承認待ち
+	SwapStatusApproved  SwapStatus = "approved"  // 承認済み
+	SwapStatusRejected  SwapStatus = "rejected"  // 却下
+	SwapStatusCancelled SwapStatus = "cancelled" // 取り下げ
+)
+
+// シフト交代の申請（店長・オーナーの承認でシフトの担当者を入れ替える）
+type ShiftSwapRequest struct {
+	gorm.Model
+	Type             SwapType   `gorm:"size:20;not null"`                 // 種類
+	ShiftID          uint       `gorm:"not null;index"`                   // 申請者のシフト
+	RequesterID      uint       `gorm:"not null;index"`                   // 申請者
+	TargetEmployeeID uint       `gorm:"not null;index"`                   // 交代相手
+	CounterShiftID   *uint      `gorm:"index"`                            // 交換する相手のシフト（交換の場合）
+	StoreID          uint       `gorm:"not null;index"`                   // 承認する店舗
+	Status           SwapStatus `gorm:"size:20;not null;default:pending"` // 状態
+	Note             string     `gorm:"size:255"`                         // 申請理由
+	ReviewedByID     *uint      // 承認・却下した従業員
+	ReviewedAt       *time.Time `gorm:"type:timestamp"` // 承認・却下日時
+	ReviewNote       string     `gorm:"size:255"`       // 承認・却下時のコメント
+
+	Shift          *Shift    `gorm:"foreignKey:ShiftID" json:"-"`
+	CounterShift   *Shift    `gorm:"foreignKey:CounterShiftID" json:"-"`
+	Requester      *Employee `gorm:"foreignKey:RequesterID" json:"-"`
+	TargetEmployee *Employee `gorm:"foreignKey:TargetEmployeeID" json:"-"`
+	Store          *Store    `gorm:"foreignKey:StoreID" json:"-"`
+}
+
+// 承認待ちかどうか
+func (r *ShiftSwapRequest) IsPending() bool {
+	return r.Status == SwapStatusPending
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// 必要人数（店舗・曜日・時間帯ごと）
+type StaffingTarget struct {
+	gorm.Model
+	StoreID   uint `gorm:"not null;index"` // 外部キー：stores テーブル
+	Weekday   int  `gorm:"not null"`       // 曜日（0:日曜〜6:土曜）
+	StartHour int  `gorm:"not null"`       // 時間帯の開始（時）
+	EndHour   int  `gorm:"not null"`       // 時間帯の終了（時、24 まで）
+	Headcount int  `gorm:"not null"`       // 必要人数
+
+	Store *Store `gorm:"foreignKey:StoreID" json:"-"`
+}
+
+// 必要人数に足りない時間帯
+type StaffingShortage struct {
+	Date      string `json:"Date"`
+	StartHour int    `json:"StartHour"`
+	EndHour   int    `json:"EndHour"`
+	Missing   int    `json:"Missing"` // 不足している人数
+}
+
+// シフトの自動作成の結果
+type ShiftPlan struct {
+	Shifts        []Shift            `json:"Shifts"`        // 作成した下書きのシフト
+	Shortages     []StaffingShortage `json:"Shortages"`     // 埋められなかった時間帯
+	EstimatedCost int                `json:"EstimatedCost"` // 作成したシフトの人件費の見込み（割増を除く）
+}
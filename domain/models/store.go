@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Store struct {
+	gorm.Model
+	Name                string     `gorm:"size:100;not null"`                     // 店舗名
+	Address             string     `gorm:"size:255"`                              // 住所
+	Prefecture          string     `gorm:"size:10"`                               // 都道府県（最低賃金の判定に使う）
+	Timezone            string     `gorm:"size:64;not null;default:'Asia/Tokyo'"` // タイムゾーン
+	OpenTime            string     `gorm:"size:5"`                                // 営業開始時刻（HH:MM）
+	CloseTime           string     `gorm:"size:5"`                                // 営業終了時刻（HH:MM）
+	DayCutoff           string     `gorm:"size:5;not null;default:'05:00'"`       // 営業日の切り替え時刻（HH:MM、これより前の打刻は前日扱い）
+	LegalHoliday        *int       `gorm:"type:smallint"`                         // 就業規則で定めた法定休日の曜日（0: 日曜日〜6: 土曜日、未設定の場合は勤務から判定）
+	LateTolerance       *int       `gorm:"type:smallint"`                         // シフトとの突き合わせで遅刻として扱わない分数（未設定の場合は既定値）
+	EarlyLeaveTolerance *int       `gorm:"type:smallint"`                         // シフトとの突き合わせで早退として扱わない分数（未設定の場合は既定値）
+	ArchivedAt          *time.Time `gorm:"type:timestamp"`                        // アーカイブ日時（閉店など）
+}
+
+// アーカイブ済みかどうか
+func (s *Store) IsArchived() bool {
+	return s.ArchivedAt != nil
+}
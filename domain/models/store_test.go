@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestStoreCRUD(t *testing.T) {
+	// テスト用のSQLiteデータベースを作成
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+
+	// テーブルを作成
+	err = db.AutoMigrate(&Store{})
+	if err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	// Create: 新しいStoreを作成
+	store := Store{
+		Name:      "Test Store",
+		Address:   "Tokyo",
+		OpenTime:  "10:00",
+		CloseTime: "22:00",
+	}
+	if err := db.Create(&store).Error; err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	// Read: 保存したデータを取得
+	var result Store
+	if err := db.First(&result, store.ID).Error; err != nil {
+		t.Fatalf("failed to read store: %v", err)
+	}
+	if result.Name != store.Name {
+		t.Errorf("expected Name %v, got %v", store.Name, result.Name)
+	}
+	if result.Timezone != "Asia/Tokyo" {
+		t.Errorf("expected default Timezone Asia/Tokyo, got %v", result.Timezone)
+	}
+	if result.IsArchived() {
+		t.Errorf("expected store not to be archived")
+	}
+
+	// Update: アーカイブ
+	now := time.Now()
+	if err := db.Model(&result).Update("ArchivedAt", &now).Error; err != nil {
+		t.Fatalf("failed to archive store: %v", err)
+	}
+
+	var archived Store
+	if err := db.First(&archived, store.ID).Error; err != nil {
+		t.Fatalf("failed to read archived store: %v", err)
+	}
+	if !archived.IsArchived() {
+		t.Errorf("expected store to be archived")
+	}
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 時給の履歴
+type WageHistory struct {
+	gorm.Model
+	EmployeeID    uint      `gorm:"not null;index"`     // 外部キー：employees テーブル
+	HourlyPay     int       `gorm:"not null"`           // 時給
+	EffectiveFrom time.Time `gorm:"type:date;not null"` // 適用開始日
+	Reason        string    `gorm:"size:255"`           // 変更理由
+	ChangedByID   *uint     // 変更した従業員（移行・登録時は nil）
+
+	Employee  *Employee `gorm:"foreignKey:EmployeeID" json:"-"`
+	ChangedBy *Employee `gorm:"foreignKey:ChangedByID" json:"-"`
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 賃金ルールを適用する日の区分
+type DayType string
+
+const (
+	DayTypeAny     DayType = ""        // 毎日
+	DayTypeWeekday DayType = "weekday" // 平日
+	DayTypeHoliday DayType = "holiday" // 土日祝
+)
+
+// 定義済みの区分かどうか
+func (d DayType) IsValid() bool {
+	switch d {
+	case DayTypeAny, DayTypeWeekday, DayTypeHoliday:
+		return true
+	default:
+		return false
+	}
+}
+
+// 賃金ルール（従業員・店舗・曜日区分・時間帯ごとの時給）
+// HourlyPay は基本の時給を置き換え、Addition は条件に合うルールの分だけ加算する
+// 過去の勤務の賃金が変わらないよう、変更・削除は適用終了日を設定して新しいルールに切り替える
+type WageRule struct {
+	gorm.Model
+	EmployeeID    *uint      `gorm:"index"`              // 対象の従業員（nil は全従業員）
+	StoreID       *uint      `gorm:"index"`              // 対象の店舗（nil は全店舗）
+	DayType       DayType    `gorm:"size:20;not null"`   // 曜日区分
+	StartTime     string     `gorm:"size:5"`             // 時間帯の開始（HH:MM、空の場合は終日）
+	EndTime       string     `gorm:"size:5"`             // 時間帯の終了（HH:MM、開始より前の場合は翌日まで）
+	HourlyPay     int        `gorm:"not null;default:0"` // 時給（0 は基本の時給を使う）
+	Addition      int        `gorm:"not null;default:0"` // 時給への加算額
+	Note          string     `gorm:"size:255"`           // メモ
+	EffectiveFrom time.Time  `gorm:"type:date"`          // 適用開始日
+	EffectiveTo   *time.Time `gorm:"type:date"`          // 適用終了日（この日まで適用、nil は終了日なし）
+
+	Employee *Employee `gorm:"foreignKey:EmployeeID" json:"-"`
+	Store    *Store    `gorm:"foreignKey:StoreID" json:"-"`
+}
+
+// 勤務日に適用されるルールか
+func (r *WageRule) EffectiveOn(date time.Time) bool {
+	day := date.Format("2006-01-02")
+	if r.EffectiveFrom.Format("2006-01-02") > day {
+		return false
+	}
+	return r.EffectiveTo == nil || r.EffectiveTo.Format("2006-01-02") >= day
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 勤務区間（1日の勤務を店舗ごとの区間に分けて記録する）
+type WorkSegment struct {
+	gorm.Model
+	AttendanceID uint       `gorm:"not null;index"`          // 外部キー：attendances テーブル
+	StoreID      uint       `gorm:"not null"`                // 外部キー：stores テーブル
+	StartTime    time.Time  `gorm:"type:timestamp;not null"` // 勤務開始時間
+	EndTime      *time.Time `gorm:"type:timestamp"`          // 勤務終了時間（勤務中は nil）
+
+	Store *Store `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+}
+
+type WorkSegmentResponse struct {
+	ID        uint   `json:"ID"`
+	StoreID   uint   `json:"StoreID"`
+	StoreName string `json:"StoreName"`
+	StartTime string `json:"StartTime"`
+	EndTime   string `json:"EndTime"`
+}
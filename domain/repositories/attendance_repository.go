@@ -6,7 +6,8 @@ import (
 
 // AttendanceRepository
 type AttendanceRepository interface {
-	CreateAttendance(summary *model.Attendance) error
+	CreateAttendance(summary *model.Attendance, auditLog *model.AuditLog) error
 	FindAttendance(employeeID uint, workDate string) (*model.Attendance, error)
-	UpdateAttendance(summary *model.Attendance) error
+	FindLatestAttendance(employeeID uint) (*model.Attendance, error)
+	UpdateAttendance(summary *model.Attendance, auditLog *model.AuditLog) error
 }
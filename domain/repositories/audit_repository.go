@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// 監査ログの検索条件（ゼロ値の項目は条件にしない）
+type AuditLogFilter struct {
+	EmployeeID uint              // 対象の従業員
+	StoreID    uint              // 対象の従業員の担当店舗
+	Entity     model.AuditEntity // 対象
+	From       time.Time         // 記録日時（以上）
+	To         time.Time         // 記録日時（未満）
+}
+
+// AuditRepository 監査ログは追記のみ
+type AuditRepository interface {
+	CreateAuditLog(log *model.AuditLog) error
+	FindAuditLogs(filter AuditLogFilter) ([]model.AuditLog, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// CorrectionRepository
+type CorrectionRepository interface {
+	CreateCorrection(correction *model.AttendanceCorrection) error
+	FindCorrectionByID(correctionID uint) (*model.AttendanceCorrection, error)
+	GetEmployeeCorrections(employeeID uint) ([]model.AttendanceCorrection, error)
+	GetCorrections(storeID uint, status model.CorrectionStatus) ([]model.AttendanceCorrection, error)
+	HasPendingCorrection(attendanceID uint) (bool, error)
+	UpdateCorrection(correction *model.AttendanceCorrection) error
+	ApproveCorrection(correction *model.AttendanceCorrection, attendance *model.Attendance, auditLog *model.AuditLog) error
+}
@@ -7,10 +7,29 @@ import (
 // EmployeeRepository
 type EmployeeRepository interface {
 	FindEmpByLoginID(loginID string) (*model.Employee, error)
+	GetLoginIDs() ([]string, error)
 	FindEmpByEmpID(employeeID int) (*model.Employee, error)
 	CreateEmp(employee *model.Employee) error
-	GetStatusByEmpID(employeeID uint) (int, error)
 	GetLoginIDByEmpID(employeeID string) (string, error)
 	UpdateEmpPassword(employee *model.Employee) error
 	UpdateEmployee(employee *model.Employee) error
+	GetEmployeesByStore(storeID uint) ([]model.Employee, error)
+	ImportEmployees(imports []EmployeeImport) error
+	UpdateEmploymentStatus(employee *model.Employee, auditLog *model.AuditLog) error
+	SaveEmployeeChange(change EmployeeChange) error
+	ApplyHourlyPay(employeeID uint, hourlyPay int, auditLog *model.AuditLog) (bool, error)
+}
+
+// 一括登録する従業員と、登録時の時給の履歴・監査ログ
+type EmployeeImport struct {
+	Employee    *model.Employee
+	WageHistory *model.WageHistory // 従業員の登録後に従業員IDを設定して記録する
+	AuditLog    *model.AuditLog    // 従業員の登録後に対象のIDを設定して記録する（nil の場合は記録しない）
+}
+
+// 更新する従業員と、時給の履歴・監査ログ
+type EmployeeChange struct {
+	Employee    *model.Employee
+	WageHistory *model.WageHistory // nil の場合は記録しない
+	AuditLog    *model.AuditLog    // nil の場合は記録しない
 }
@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// PayrollRepository
+type PayrollRepository interface {
+	CreatePayrollRun(run *model.PayrollRun) error
+	FindLatestPayrollRun(employeeID uint, year int, month int) (*model.PayrollRun, error)
+}
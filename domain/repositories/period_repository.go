@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// PeriodRepository
+type PeriodRepository interface {
+	CreatePeriodClose(period *model.PeriodClose) error
+	FindActivePeriodClose(storeID uint, year int, month int) (*model.PeriodClose, error)
+	GetPeriodCloses(storeID uint, year int) ([]model.PeriodClose, error)
+	UpdatePeriodClose(period *model.PeriodClose) error
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// ShiftRepository
+type ShiftRepository interface {
+	CreateShift(shift *model.Shift) error
+	FindShiftByID(shiftID uint) (*model.Shift, error)
+	GetStoreShifts(storeID uint, from time.Time, to time.Time) ([]model.Shift, error)
+	GetEmployeeShifts(employeeID uint, from time.Time, to time.Time, publishedOnly bool) ([]model.Shift, error)
+	HasOverlappingShift(employeeID uint, start time.Time, end time.Time, excludeID uint) (bool, error)
+	UpdateShift(shift *model.Shift) error
+	DeleteShift(shift *model.Shift) error
+	PublishShifts(storeID uint, from time.Time, to time.Time, publishedAt time.Time) (int64, error)
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// AvailabilityRepository
+type AvailabilityRepository interface {
+	GetEmployeeAvailabilities(employeeID uint, from time.Time, to time.Time) ([]model.Availability, error)
+	GetStoreAvailabilities(storeID uint, from time.Time, to time.Time) ([]model.Availability, error)
+	ReplaceAvailabilities(employeeID uint, from time.Time, to time.Time, availabilities []model.Availability) error
+}
+
+// ShiftSwapRepository
+type ShiftSwapRepository interface {
+	CreateShiftSwapRequest(request *model.ShiftSwapRequest) error
+	FindShiftSwapRequestByID(requestID uint) (*model.ShiftSwapRequest, error)
+	GetEmployeeShiftSwapRequests(employeeID uint) ([]model.ShiftSwapRequest, error)
+	GetStoreShiftSwapRequests(storeID uint, status model.SwapStatus) ([]model.ShiftSwapRequest, error)
+	HasPendingShiftSwapRequest(shiftID uint) (bool, error)
+	UpdateShiftSwapRequest(request *model.ShiftSwapRequest) error
+	ApproveShiftSwapRequest(request *model.ShiftSwapRequest, shifts []*model.Shift) error
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// StaffingTargetRepository
+type StaffingTargetRepository interface {
+	GetStaffingTargets(storeID uint) ([]model.StaffingTarget, error)
+	ReplaceStaffingTargets(storeID uint, targets []model.StaffingTarget) error
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// StoreRepository
+type StoreRepository interface {
+	CreateStore(store *model.Store) error
+	FindStoreByID(storeID uint) (*model.Store, error)
+	GetAllStores(includeArchived bool) ([]model.Store, error)
+	UpdateStore(store *model.Store) error
+}
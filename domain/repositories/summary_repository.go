@@ -8,9 +8,14 @@ import (
 
 type SummaryRepository interface {
 	GetAllEmployee() ([]model.Employee, error)
-	GetAttendance(uint, int, int) ([]model.Attendance, error)
-	GetHourlyPay(uint) (int, error)
+	GetAttendanceBetween(uint, time.Time, time.Time) ([]model.Attendance, error)
 	GetAttendanceByID(uint) (*model.Attendance, error)
 	UpdateAttendance(*model.Attendance) error
-	GetWorkDateByID(uint) (time.Time, error)
+	ImportAttendances([]AttendanceImport) error
+}
+
+// 取り込む勤怠と監査ログ
+type AttendanceImport struct {
+	Attendance *model.Attendance // ID が0の場合は作成し、それ以外は勤務区間・休憩を置き換える
+	AuditLog   *model.AuditLog   // 勤怠の保存後に対象のIDを設定して記録する（nil の場合は記録しない）
 }
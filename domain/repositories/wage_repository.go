@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// WageRepository
+type WageRepository interface {
+	CreateWageHistory(history *model.WageHistory) error
+	GetWageHistories(employeeID uint) ([]model.WageHistory, error)
+	GetEffectiveWageHistories(date time.Time) ([]model.WageHistory, error)
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// WageRuleRepository
+type WageRuleRepository interface {
+	CreateWageRule(rule *model.WageRule) error
+	FindWageRuleByID(ruleID uint) (*model.WageRule, error)
+	GetWageRules() ([]model.WageRule, error)
+	GetWageRulesForEmployee(employeeID uint) ([]model.WageRule, error)
+	UpdateWageRule(rule *model.WageRule) error
+	ReplaceWageRule(current *model.WageRule, next *model.WageRule) error
+	DeleteWageRule(rule *model.WageRule) error
+}
+
+// HolidayRepository
+type HolidayRepository interface {
+	CreateHoliday(holiday *model.Holiday) error
+	FindHolidayByID(holidayID uint) (*model.Holiday, error)
+	GetHolidaysBetween(from time.Time, to time.Time) ([]model.Holiday, error)
+	DeleteHoliday(holiday *model.Holiday) error
+}
+
+// MinimumWageRepository
+type MinimumWageRepository interface {
+	CreateMinimumWage(wage *model.MinimumWage) error
+	FindMinimumWageByID(wageID uint) (*model.MinimumWage, error)
+	GetMinimumWages(prefecture string) ([]model.MinimumWage, error)
+	DeleteMinimumWage(wage *model.MinimumWage) error
+}
@@ -0,0 +1,320 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"gorm.io/gorm"
+)
+
+// Migrate アプリケーションが必要とするテーブル・制約を作成する
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&model.Store{}); err != nil {
+		return err
+	}
+
+	// 既存の勤怠・従業員が参照している店舗IDを先に登録してから外部キーを作成する
+	if err := seedReferencedStores(db); err != nil {
+		return err
+	}
+	if err := clearUnassignedStores(db); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&model.Employee{}, &model.Attendance{}, &model.WorkSegment{}, &model.AttendanceBreak{}, &model.PayrollRun{}, &model.PayrollDeduction{}, &model.WageHistory{}, &model.WageRule{}, &model.Holiday{}, &model.Shift{}, &model.Availability{}, &model.ShiftSwapRequest{}, &model.StaffingTarget{}, &model.AttendanceCorrection{}, &model.AttendanceCorrectionItem{}, &model.AuditLog{}, &model.PeriodClose{}, &model.MinimumWage{}); err != nil {
+		return err
+	}
+
+	if err := migrateLegacySegments(db); err != nil {
+		return err
+	}
+	if err := migrateLegacyBreaks(db); err != nil {
+		return err
+	}
+	if err := protectAuditLogs(db); err != nil {
+		return err
+	}
+	if err := backfillNetPay(db); err != nil {
+		return err
+	}
+	if err := backfillWageRuleEffectiveFrom(db); err != nil {
+		return err
+	}
+	if err := seedMinimumWages(db); err != nil {
+		return err
+	}
+	return seedWageHistories(db)
+}
+
+// 適用開始日のない賃金ルールは、登録日から適用する
+func backfillWageRuleEffectiveFrom(db *gorm.DB) error {
+	var rules []struct {
+		ID        uint
+		CreatedAt time.Time
+	}
+	if err := db.Model(&model.WageRule{}).Unscoped().Where("effective_from IS NULL").
+		Select("id, created_at").Find(&rules).Error; err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		created := rule.CreatedAt
+		effectiveFrom := time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, time.UTC)
+		if err := db.Model(&model.WageRule{}).Unscoped().Where("id = ?", rule.ID).
+			Update("effective_from", effectiveFrom).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 最低賃金が未登録の場合は、これまで登録時の時給の初期値としていた神奈川県の最低賃金を登録する
+func seedMinimumWages(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&model.MinimumWage{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	return db.Create(&model.MinimumWage{
+		Prefecture:    "神奈川県",
+		EffectiveFrom: time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC),
+		HourlyWage:    1162,
+	}).Error
+}
+
+// 監査ログの更新・削除をデータベースのトリガーで禁止する
+func protectAuditLogs(db *gorm.DB) error {
+	var statements []string
+	switch db.Dialector.Name() {
+	case "postgres":
+		statements = []string{
+			`CREATE OR REPLACE FUNCTION reject_audit_log_change() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql`,
+			`DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs`,
+			`CREATE TRIGGER audit_logs_append_only BEFORE UPDATE OR DELETE ON audit_logs
+	FOR EACH ROW EXECUTE FUNCTION reject_audit_log_change()`,
+		}
+	case "sqlite":
+		statements = []string{
+			`CREATE TRIGGER IF NOT EXISTS audit_logs_no_update BEFORE UPDATE ON audit_logs
+	BEGIN SELECT RAISE(ABORT, 'audit_logs is append-only'); END`,
+			`CREATE TRIGGER IF NOT EXISTS audit_logs_no_delete BEFORE DELETE ON audit_logs
+	BEGIN SELECT RAISE(ABORT, 'audit_logs is append-only'); END`,
+		}
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 控除を記録する前の給与計算の結果は、総支給額をそのまま差引支給額とする
+func backfillNetPay(db *gorm.DB) error {
+	return db.Model(&model.PayrollRun{}).
+		Where("net_pay = 0 AND total_deductions = 0 AND gross_pay <> 0").
+		Update("net_pay", gorm.Expr("gross_pay")).Error
+}
+
+// 時給の履歴がない従業員は、現在の時給を登録日から適用する履歴を作成する
+func seedWageHistories(db *gorm.DB) error {
+	var employees []model.Employee
+	if err := db.Where("id NOT IN (?)", db.Model(&model.WageHistory{}).Select("employee_id")).
+		Find(&employees).Error; err != nil {
+		return err
+	}
+
+	for _, employee := range employees {
+		created := employee.CreatedAt
+		history := model.WageHistory{
+			EmployeeID:    employee.ID,
+			HourlyPay:     employee.HourlyPay,
+			EffectiveFrom: time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, time.UTC),
+			Reason:        "移行時の時給",
+		}
+		if err := db.Create(&history).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 旧形式（StartTime1/EndTime1/StartTime2/EndTime2）の勤怠
+type legacyAttendance struct {
+	ID         uint
+	StartTime1 *time.Time
+	EndTime1   *time.Time
+	StartTime2 *time.Time
+	EndTime2   *time.Time
+	StoreID1   uint
+	StoreID2   *uint
+}
+
+// 旧形式の勤務時間カラムを勤務区間テーブルへ移行し、旧カラムを削除する
+func migrateLegacySegments(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&model.Attendance{}, "start_time1") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var rows []legacyAttendance
+		if err := tx.Table("attendances").
+			Select("id, start_time1, end_time1, start_time2, end_time2, store_id1, store_id2").
+			Find(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			var count int64
+			if err := tx.Model(&model.WorkSegment{}).Where("attendance_id = ?", row.ID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				continue
+			}
+
+			var segments []model.WorkSegment
+			if row.StartTime1 != nil {
+				segments = append(segments, model.WorkSegment{
+					AttendanceID: row.ID,
+					StoreID:      row.StoreID1,
+					StartTime:    *row.StartTime1,
+					EndTime:      row.EndTime1,
+				})
+			}
+			if row.StartTime2 != nil && row.StoreID2 != nil {
+				segments = append(segments, model.WorkSegment{
+					AttendanceID: row.ID,
+					StoreID:      *row.StoreID2,
+					StartTime:    *row.StartTime2,
+					EndTime:      row.EndTime2,
+				})
+			}
+			if len(segments) == 0 {
+				continue
+			}
+			if err := tx.Create(&segments).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, column := range []string{"start_time1", "end_time1", "start_time2", "end_time2", "store_id1", "store_id2"} {
+			if err := tx.Migrator().DropColumn(&model.Attendance{}, column); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// 勤怠・従業員が参照している店舗が stores テーブルになければ仮の名前で登録する
+func seedReferencedStores(db *gorm.DB) error {
+	var ids []uint
+	if db.Migrator().HasColumn(&model.Attendance{}, "store_id1") {
+		var attendanceStoreIDs []uint
+		if err := db.Raw("SELECT store_id1 FROM attendances UNION SELECT store_id2 FROM attendances WHERE store_id2 IS NOT NULL").
+			Scan(&attendanceStoreIDs).Error; err != nil {
+			return err
+		}
+		ids = append(ids, attendanceStoreIDs...)
+	}
+	if db.Migrator().HasTable(&model.Employee{}) {
+		var employeeStoreIDs []uint
+		if err := db.Model(&model.Employee{}).Where("competent_store_id > 0").
+			Distinct().Pluck("competent_store_id", &employeeStoreIDs).Error; err != nil {
+			return err
+		}
+		ids = append(ids, employeeStoreIDs...)
+	}
+
+	seeded := false
+	for _, id := range ids {
+		var count int64
+		if err := db.Unscoped().Model(&model.Store{}).Where("id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		store := model.Store{Name: fmt.Sprintf("店舗%d", id), Timezone: "Asia/Tokyo"}
+		store.ID = id
+		if err := db.Create(&store).Error; err != nil {
+			return err
+		}
+		seeded = true
+	}
+
+	// IDを指定して登録したため、PostgreSQLのシーケンスを進めておく
+	if seeded && db.Dialector.Name() == "postgres" {
+		return db.Exec("SELECT setval(pg_get_serial_sequence('stores', 'id'), (SELECT MAX(id) FROM stores))").Error
+	}
+	return nil
+}
+
+// 担当店舗が未設定（0）の従業員は、外部キーを作成できるよう NULL にする
+func clearUnassignedStores(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&model.Employee{}) {
+		return nil
+	}
+	return db.Model(&model.Employee{}).Unscoped().Where("competent_store_id = 0").
+		Update("competent_store_id", nil).Error
+}
+
+// 旧形式（BreakStart/BreakEnd）の休憩
+type legacyBreak struct {
+	ID         uint
+	BreakStart *time.Time
+	BreakEnd   *time.Time
+}
+
+// 旧形式の休憩カラムを休憩テーブルへ移行し、旧カラムを削除する
+func migrateLegacyBreaks(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&model.Attendance{}, "break_start") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var rows []legacyBreak
+		if err := tx.Table("attendances").
+			Select("id, break_start, break_end").
+			Where("break_start IS NOT NULL").
+			Find(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			var count int64
+			if err := tx.Model(&model.AttendanceBreak{}).Where("attendance_id = ?", row.ID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				continue
+			}
+
+			// 旧形式の休憩は「外出」打刻で記録されていたため外出として移行する
+			breakRecord := model.AttendanceBreak{
+				AttendanceID: row.ID,
+				BreakType:    model.BreakTypeErrand,
+				StartTime:    *row.BreakStart,
+				EndTime:      row.BreakEnd,
+			}
+			if err := tx.Create(&breakRecord).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, column := range []string{"break_start", "break_end"} {
+			if err := tx.Migrator().DropColumn(&model.Attendance{}, column); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
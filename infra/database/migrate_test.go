@@ -0,0 +1,235 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// 旧形式の attendances テーブル
+type oldAttendance struct {
+	gorm.Model
+	EmployeeID uint
+	WorkDate   time.Time `gorm:"type:date"`
+	StartTime1 *time.Time
+	EndTime1   *time.Time
+	StartTime2 *time.Time
+	EndTime2   *time.Time
+	BreakStart *time.Time
+	BreakEnd   *time.Time
+	StoreID1   uint
+	StoreID2   *uint
+	StatusID   int
+}
+
+func TestMigrateLegacyAttendance(t *testing.T) {
+	// テスト用のSQLiteデータベースを作成
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+
+	// 旧形式のテーブルとデータを作成
+	if err := db.Table("attendances").AutoMigrate(&oldAttendance{}); err != nil {
+		t.Fatalf("failed to create legacy table: %v", err)
+	}
+	start1 := time.Date(2024, 10, 1, 9, 0, 0, 0, time.UTC)
+	end1 := time.Date(2024, 10, 1, 13, 0, 0, 0, time.UTC)
+	start2 := time.Date(2024, 10, 1, 14, 0, 0, 0, time.UTC)
+	end2 := time.Date(2024, 10, 1, 18, 0, 0, 0, time.UTC)
+	storeID2 := uint(2)
+	legacy := []oldAttendance{
+		{EmployeeID: 1, WorkDate: start1, StartTime1: &start1, EndTime1: &end1, StartTime2: &start2, EndTime2: &end2, BreakStart: &end1, BreakEnd: &start2, StoreID1: 1, StoreID2: &storeID2, StatusID: 3},
+		{EmployeeID: 2, WorkDate: start1, StartTime1: &start1, StoreID1: 1, StatusID: 1},
+	}
+	if err := db.Table("attendances").Create(&legacy).Error; err != nil {
+		t.Fatalf("failed to create legacy attendance: %v", err)
+	}
+
+	// マイグレーションを実行（2回実行しても問題ないこと）
+	for i := 0; i < 2; i++ {
+		if err := Migrate(db); err != nil {
+			t.Fatalf("failed to migrate database: %v", err)
+		}
+	}
+
+	// 参照されていた店舗が登録されていること
+	var storeCount int64
+	db.Model(&model.Store{}).Count(&storeCount)
+	if storeCount != 2 {
+		t.Errorf("expected 2 stores, got %v", storeCount)
+	}
+
+	// 勤務区間に移行されていること
+	var attendance model.Attendance
+	if err := db.Preload("Segments", func(db *gorm.DB) *gorm.DB { return db.Order("start_time") }).Preload("Breaks").
+		First(&attendance, legacy[0].ID).Error; err != nil {
+		t.Fatalf("failed to read attendance: %v", err)
+	}
+	if len(attendance.Segments) != 2 {
+		t.Fatalf("expected 2 segments, got %v", len(attendance.Segments))
+	}
+	if attendance.Segments[1].StoreID != 2 || !attendance.Segments[1].EndTime.Equal(end2) {
+		t.Errorf("unexpected second segment: %+v", attendance.Segments[1])
+	}
+
+	// 休憩に移行されていること
+	if len(attendance.Breaks) != 1 {
+		t.Fatalf("expected 1 break, got %v", len(attendance.Breaks))
+	}
+	if attendance.Breaks[0].BreakType != model.BreakTypeErrand || !attendance.Breaks[0].EndTime.Equal(start2) {
+		t.Errorf("unexpected break: %+v", attendance.Breaks[0])
+	}
+
+	var openSegments []model.WorkSegment
+	db.Where("attendance_id = ?", legacy[1].ID).Find(&openSegments)
+	if len(openSegments) != 1 || openSegments[0].EndTime != nil {
+		t.Errorf("expected 1 open segment, got %+v", openSegments)
+	}
+
+	// 旧カラムが削除されていること
+	for _, column := range []string{"start_time1", "store_id2", "break_start"} {
+		if db.Migrator().HasColumn(&model.Attendance{}, column) {
+			t.Errorf("expected legacy column %s to be dropped", column)
+		}
+	}
+}
+
+func TestSeedWageHistories(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	employee := model.Employee{Name: "テスト", LoginID: "test", Password: "x", RoleID: 1, HourlyPay: 1200}
+	if err := db.Create(&employee).Error; err != nil {
+		t.Fatalf("failed to create employee: %v", err)
+	}
+
+	// 履歴のない従業員にだけ作成されること（2回実行しても増えないこと）
+	for i := 0; i < 2; i++ {
+		if err := Migrate(db); err != nil {
+			t.Fatalf("failed to migrate database: %v", err)
+		}
+	}
+
+	var histories []model.WageHistory
+	db.Where("employee_id = ?", employee.ID).Find(&histories)
+	if len(histories) != 1 || histories[0].HourlyPay != 1200 {
+		t.Errorf("expected 1 wage history with 1200, got %+v", histories)
+	}
+}
+
+func TestAuditLogsAppendOnly(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	log := model.AuditLog{EmployeeID: 1, Entity: model.AuditEntityAttendance, EntityID: 1, Action: model.AuditActionCreate, Source: model.AuditSourcePunch}
+	if err := db.Create(&log).Error; err != nil {
+		t.Fatalf("failed to create audit log: %v", err)
+	}
+
+	// 更新・削除はできないこと
+	if err := db.Model(&log).Update("source", model.AuditSourceManualEdit).Error; err == nil {
+		t.Errorf("audit log was updated")
+	}
+	if err := db.Delete(&log).Error; err == nil {
+		t.Errorf("audit log was deleted")
+	}
+
+	var count int64
+	db.Model(&model.AuditLog{}).Where("source = ?", model.AuditSourcePunch).Count(&count)
+	if count != 1 {
+		t.Errorf("audit logs = %d, want 1", count)
+	}
+}
+
+func TestBackfillWageRuleEffectiveFrom(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	// 適用開始日のない既存のルール
+	rule := model.WageRule{HourlyPay: 1100}
+	if err := db.Create(&rule).Error; err != nil {
+		t.Fatalf("failed to create wage rule: %v", err)
+	}
+	created := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	if err := db.Exec("UPDATE wage_rules SET effective_from = NULL, created_at = ? WHERE id = ?", created, rule.ID).Error; err != nil {
+		t.Fatalf("failed to clear effective_from: %v", err)
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	// 登録日から適用されること
+	var migrated model.WageRule
+	db.First(&migrated, rule.ID)
+	if got := migrated.EffectiveFrom.Format("2006-01-02"); got != "2024-03-15" {
+		t.Errorf("effective_from = %s, want 2024-03-15", got)
+	}
+}
+
+// 担当店舗の外部キーを作成する前の employees テーブル
+type oldEmployee struct {
+	gorm.Model
+	Name             string
+	LoginID          string
+	Password         string
+	RoleID           int
+	HourlyPay        int
+	CompetentStoreID int
+}
+
+func TestMigrateEmployeeStoreForeignKey(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+
+	// 担当店舗が未設定（0）の従業員と、stores にない店舗を担当する従業員
+	if err := db.Table("employees").AutoMigrate(&oldEmployee{}); err != nil {
+		t.Fatalf("failed to create legacy table: %v", err)
+	}
+	legacy := []oldEmployee{
+		{Name: "未設定", LoginID: "a", Password: "x", RoleID: 1, HourlyPay: 1200},
+		{Name: "店舗5", LoginID: "b", Password: "x", RoleID: 1, HourlyPay: 1200, CompetentStoreID: 5},
+	}
+	if err := db.Table("employees").Create(&legacy).Error; err != nil {
+		t.Fatalf("failed to create legacy employees: %v", err)
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	if !db.Migrator().HasConstraint(&model.Employee{}, "CompetentStore") {
+		t.Errorf("expected foreign key from employees to stores")
+	}
+	var employees []model.Employee
+	db.Order("id").Find(&employees)
+	if len(employees) != 2 || employees[0].CompetentStoreID != nil || employees[1].StoreID() != 5 {
+		t.Errorf("unexpected employees after migration: %+v", employees)
+	}
+	var count int64
+	db.Model(&model.Store{}).Where("id = ?", 5).Count(&count)
+	if count != 1 {
+		t.Errorf("expected referenced store 5 to be seeded")
+	}
+}
@@ -13,16 +13,57 @@ func NewAttendanceRepository(db *gorm.DB) *AttendanceRepositoryImpl {
 	return &AttendanceRepositoryImpl{DB: db}
 }
 
-func (r *AttendanceRepositoryImpl) CreateAttendance(attendance *model.Attendance) error {
-	return r.DB.Create(attendance).Error
+// 勤怠を作成し、監査ログを記録する（auditLog が nil の場合は記録しない）
+func (r *AttendanceRepositoryImpl) CreateAttendance(attendance *model.Attendance, auditLog *model.AuditLog) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attendance).Error; err != nil {
+			return err
+		}
+		return createAttendanceAudit(tx, attendance, auditLog)
+	})
 }
 
 func (r *AttendanceRepositoryImpl) FindAttendance(employeeID uint, workDate string) (*model.Attendance, error) {
 	var attendance model.Attendance
-	err := r.DB.Where("employee_id = ? AND work_date = ?", employeeID, workDate).First(&attendance).Error
+	err := r.DB.Preload("Segments", orderByStartTime).Preload("Breaks", orderByStartTime).
+		Where("employee_id = ? AND work_date = ?", employeeID, workDate).First(&attendance).Error
 	return &attendance, err
 }
 
-func (r *AttendanceRepositoryImpl) UpdateAttendance(attendance *model.Attendance) error {
-	return r.DB.Save(attendance).Error
+// 最新の勤怠を取得（勤怠がない場合は nil）
+func (r *AttendanceRepositoryImpl) FindLatestAttendance(employeeID uint) (*model.Attendance, error) {
+	var attendance model.Attendance
+	err := r.DB.Preload("Segments", orderByStartTime).Preload("Breaks", orderByStartTime).
+		Where("employee_id = ?", employeeID).Order("work_date DESC, id DESC").First(&attendance).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attendance, nil
+}
+
+// 勤怠と勤務区間・休憩をまとめて保存し、監査ログを記録する（auditLog が nil の場合は記録しない）
+func (r *AttendanceRepositoryImpl) UpdateAttendance(attendance *model.Attendance, auditLog *model.AuditLog) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(attendance).Error; err != nil {
+			return err
+		}
+		return createAttendanceAudit(tx, attendance, auditLog)
+	})
+}
+
+// 保存した勤怠のIDを設定して監査ログを記録する
+func createAttendanceAudit(tx *gorm.DB, attendance *model.Attendance, auditLog *model.AuditLog) error {
+	if auditLog == nil {
+		return nil
+	}
+	auditLog.EntityID = attendance.ID
+	return tx.Create(auditLog).Error
+}
+
+// 勤務区間・休憩を開始時間順に取得する
+func orderByStartTime(db *gorm.DB) *gorm.DB {
+	return db.Order("start_time")
 }
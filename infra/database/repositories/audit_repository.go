@@ -0,0 +1,46 @@
+package repository
+
+import (
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type AuditRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) *AuditRepositoryImpl {
+	return &AuditRepositoryImpl{DB: db}
+}
+
+// 監査ログの記録
+func (r *AuditRepositoryImpl) CreateAuditLog(log *model.AuditLog) error {
+	return r.DB.Create(log).Error
+}
+
+// 監査ログの検索（記録日時順）
+func (r *AuditRepositoryImpl) FindAuditLogs(filter repositories.AuditLogFilter) ([]model.AuditLog, error) {
+	query := r.DB.Model(&model.AuditLog{})
+	if filter.EmployeeID != 0 {
+		query = query.Where("employee_id = ?", filter.EmployeeID)
+	}
+	if filter.StoreID != 0 {
+		query = query.Where("employee_id IN (?)", r.DB.Model(&model.Employee{}).Select("id").Where("competent_store_id = ?", filter.StoreID))
+	}
+	if filter.Entity != "" {
+		query = query.Where("entity = ?", filter.Entity)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	var logs []model.AuditLog
+	if err := query.Order("created_at, id").Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CorrectionRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewCorrectionRepository(db *gorm.DB) *CorrectionRepositoryImpl {
+	return &CorrectionRepositoryImpl{DB: db}
+}
+
+// 修正する項目を登録順に取得する
+func orderItems(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
+
+// 修正申請の作成（修正する項目も合わせて作成する）
+func (r *CorrectionRepositoryImpl) CreateCorrection(correction *model.AttendanceCorrection) error {
+	return r.DB.Create(correction).Error
+}
+
+// 修正申請の取得
+func (r *CorrectionRepositoryImpl) FindCorrectionByID(correctionID uint) (*model.AttendanceCorrection, error) {
+	var correction model.AttendanceCorrection
+	if err := r.DB.Preload("Items", orderItems).Where("id = ?", correctionID).First(&correction).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &correction, nil
+}
+
+// 従業員が申請した、または従業員の勤怠に対する修正申請を取得（新しい順）
+func (r *CorrectionRepositoryImpl) GetEmployeeCorrections(employeeID uint) ([]model.AttendanceCorrection, error) {
+	var corrections []model.AttendanceCorrection
+	if err := r.DB.Preload("Items", orderItems).
+		Where("employee_id = ? OR requester_id = ?", employeeID, employeeID).
+		Order("created_at DESC").
+		Find(&corrections).Error; err != nil {
+		return nil, err
+	}
+	return corrections, nil
+}
+
+// 修正申請を取得（storeID が0の場合は全店舗、status が空の場合はすべての状態、古い順）
+func (r *CorrectionRepositoryImpl) GetCorrections(storeID uint, status model.CorrectionStatus) ([]model.AttendanceCorrection, error) {
+	var corrections []model.AttendanceCorrection
+	query := r.DB.Preload("Items", orderItems)
+	if storeID != 0 {
+		query = query.Where("employee_id IN (?)", r.DB.Model(&model.Employee{}).Select("id").Where("competent_store_id = ?", storeID))
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("created_at").Find(&corrections).Error; err != nil {
+		return nil, err
+	}
+	return corrections, nil
+}
+
+// 勤怠に承認待ちの修正申請があるか
+func (r *CorrectionRepositoryImpl) HasPendingCorrection(attendanceID uint) (bool, error) {
+	var count int64
+	if err := r.DB.Model(&model.AttendanceCorrection{}).
+		Where("attendance_id = ? AND status = ?", attendanceID, model.CorrectionPending).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// 修正申請の状態を更新（修正する項目は変更しない）
+func (r *CorrectionRepositoryImpl) UpdateCorrection(correction *model.AttendanceCorrection) error {
+	return r.DB.Omit(clause.Associations).Save(correction).Error
+}
+
+// 修正申請の承認（勤怠の置き換え・監査ログの記録・申請の状態の更新をまとめて行い、いずれかが失敗した場合はすべて取り消す）
+func (r *CorrectionRepositoryImpl) ApproveCorrection(correction *model.AttendanceCorrection, attendance *model.Attendance, auditLog *model.AuditLog) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := replaceAttendance(tx, attendance); err != nil {
+			return err
+		}
+		if auditLog != nil {
+			if err := tx.Create(auditLog).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit(clause.Associations).Save(correction).Error
+	})
+}
@@ -1,9 +1,8 @@
 package repository
 
 import (
-	"time"
-
 	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
 	"gorm.io/gorm"
 )
 
@@ -28,31 +27,20 @@ func (r *EmployeeRepositoryImpl) FindEmpByLoginID(loginID string) (*model.Employ
 	return &employee, nil
 }
 
+// 登録済みのすべてのログインID（暗号化されたまま、削除済みを含む）
+func (r *EmployeeRepositoryImpl) GetLoginIDs() ([]string, error) {
+	var loginIDs []string
+	if err := r.DB.Unscoped().Model(&model.Employee{}).Pluck("login_id", &loginIDs).Error; err != nil {
+		return nil, err
+	}
+	return loginIDs, nil
+}
+
 // ユーザー作成
 func (r *EmployeeRepositoryImpl) CreateEmp(employee *model.Employee) error {
 	return r.DB.Create(employee).Error
 }
 
-// ステータス取得
-func (r *EmployeeRepositoryImpl) GetStatusByEmpID(employeeID uint) (int, error) {
-	var attendance model.Attendance
-
-	// 現在の日本時間を取得
-	jst, _ := time.LoadLocation("Asia/Tokyo")
-	today := time.Now().In(jst).Format("2006-01-02")
-
-	// 従業員IDに紐づいた今日のステータスを取得
-	if err := r.DB.Where("employee_id = ? AND DATE(created_at) = ?", employeeID, today).
-		Order("created_at DESC").
-		First(&attendance).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return 0, nil // レコードが見つからない場合は 0 を返す
-		}
-		return 0, err
-	}
-	return attendance.StatusID, nil
-}
-
 // ログインID取得
 func (r *EmployeeRepositoryImpl) GetLoginIDByEmpID(employeeID string) (string, error) {
 	var employee model.Employee
@@ -88,3 +76,88 @@ func (r *EmployeeRepositoryImpl) FindEmpByEmpID(employeeID int) (*model.Employee
 func (r *EmployeeRepositoryImpl) UpdateEmployee(employee *model.Employee) error {
 	return r.DB.Model(employee).Updates(employee).Error
 }
+
+// 店舗を担当する従業員を取得（ID順）
+func (r *EmployeeRepositoryImpl) GetEmployeesByStore(storeID uint) ([]model.Employee, error) {
+	var employees []model.Employee
+	if err := r.DB.Where("competent_store_id = ?", storeID).Order("id").Find(&employees).Error; err != nil {
+		return nil, err
+	}
+	return employees, nil
+}
+
+// 従業員と登録時の時給の履歴・監査ログを1つのトランザクションで登録
+func (r *EmployeeRepositoryImpl) ImportEmployees(imports []repositories.EmployeeImport) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, item := range imports {
+			if err := tx.Create(item.Employee).Error; err != nil {
+				return err
+			}
+			if item.WageHistory != nil {
+				item.WageHistory.EmployeeID = item.Employee.ID
+				if err := tx.Create(item.WageHistory).Error; err != nil {
+					return err
+				}
+			}
+			if item.AuditLog != nil {
+				item.AuditLog.EmployeeID = item.Employee.ID
+				item.AuditLog.EntityID = item.Employee.ID
+				if err := tx.Create(item.AuditLog).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// 退職処理の項目の更新と監査ログを1つのトランザクションで保存（在籍に戻す場合は nil で更新する）
+func (r *EmployeeRepositoryImpl) UpdateEmploymentStatus(employee *model.Employee, auditLog *model.AuditLog) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(employee).
+			Select("deactivated_at", "deactivated_by_id", "deactivation_reason", "retention_until").
+			Updates(employee).Error
+		if err != nil || auditLog == nil {
+			return err
+		}
+		return tx.Create(auditLog).Error
+	})
+}
+
+// 従業員の更新と時給の履歴・監査ログを1つのトランザクションで保存
+func (r *EmployeeRepositoryImpl) SaveEmployeeChange(change repositories.EmployeeChange) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(change.Employee).Updates(change.Employee).Error; err != nil {
+			return err
+		}
+		if change.WageHistory != nil {
+			if err := tx.Create(change.WageHistory).Error; err != nil {
+				return err
+			}
+		}
+		if change.AuditLog != nil {
+			if err := tx.Create(change.AuditLog).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// 現在の時給が異なる場合のみ時給を更新し、更新した場合は監査ログも同じトランザクションで記録する
+// 複数のサーバで同時に反映しても、時給の更新と監査ログの記録は1回だけ行われる
+func (r *EmployeeRepositoryImpl) ApplyHourlyPay(employeeID uint, hourlyPay int, auditLog *model.AuditLog) (bool, error) {
+	applied := false
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Employee{}).Where("id = ? AND hourly_pay <> ?", employeeID, hourlyPay).Update("hourly_pay", hourlyPay)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		applied = true
+		if auditLog == nil {
+			return nil
+		}
+		return tx.Create(auditLog).Error
+	})
+	return applied, err
+}
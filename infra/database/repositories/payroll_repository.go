@@ -0,0 +1,34 @@
+package repository
+
+import (
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"gorm.io/gorm"
+)
+
+type PayrollRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewPayrollRepository(db *gorm.DB) *PayrollRepositoryImpl {
+	return &PayrollRepositoryImpl{DB: db}
+}
+
+// 給与計算の結果を保存（控除の内訳も合わせて保存する）
+func (r *PayrollRepositoryImpl) CreatePayrollRun(run *model.PayrollRun) error {
+	return r.DB.Create(run).Error
+}
+
+// 指定した月の最新の給与計算の結果を取得
+func (r *PayrollRepositoryImpl) FindLatestPayrollRun(employeeID uint, year int, month int) (*model.PayrollRun, error) {
+	var run model.PayrollRun
+	if err := r.DB.Preload("Deductions", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Where("employee_id = ? AND year = ? AND month = ?", employeeID, year, month).
+		Order("id DESC").
+		First(&run).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
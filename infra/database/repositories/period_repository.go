@@ -0,0 +1,51 @@
+package repository
+
+import (
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"gorm.io/gorm"
+)
+
+type PeriodRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewPeriodRepository(db *gorm.DB) *PeriodRepositoryImpl {
+	return &PeriodRepositoryImpl{DB: db}
+}
+
+// 締めの作成
+func (r *PeriodRepositoryImpl) CreatePeriodClose(period *model.PeriodClose) error {
+	return r.DB.Create(period).Error
+}
+
+// 店舗・月の有効な締めを取得（締めていない場合は nil）
+func (r *PeriodRepositoryImpl) FindActivePeriodClose(storeID uint, year int, month int) (*model.PeriodClose, error) {
+	var period model.PeriodClose
+	if err := r.DB.Where("store_id = ? AND year = ? AND month = ? AND reopened_at IS NULL", storeID, year, month).
+		First(&period).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &period, nil
+}
+
+// 指定した年の締めの履歴を取得（storeID が0の場合は全店舗、月・締めた日時の順）
+func (r *PeriodRepositoryImpl) GetPeriodCloses(storeID uint, year int) ([]model.PeriodClose, error) {
+	query := r.DB.Where("year = ?", year)
+	if storeID != 0 {
+		query = query.Where("store_id = ?", storeID)
+	}
+
+	var periods []model.PeriodClose
+	if err := query.Order("month, store_id, closed_at").Find(&periods).Error; err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+// 締めの更新
+func (r *PeriodRepositoryImpl) UpdatePeriodClose(period *model.PeriodClose) error {
+	return r.DB.Omit("Store").Save(period).Error
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"gorm.io/gorm"
+)
+
+type ShiftRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewShiftRepository(db *gorm.DB) *ShiftRepositoryImpl {
+	return &ShiftRepositoryImpl{DB: db}
+}
+
+// シフト作成
+func (r *ShiftRepositoryImpl) CreateShift(shift *model.Shift) error {
+	return r.DB.Create(shift).Error
+}
+
+// シフト取得
+func (r *ShiftRepositoryImpl) FindShiftByID(shiftID uint) (*model.Shift, error) {
+	var shift model.Shift
+	if err := r.DB.Where("id = ?", shiftID).First(&shift).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &shift, nil
+}
+
+// 店舗のシフトを取得（開始時刻が from 以上 to 未満）
+func (r *ShiftRepositoryImpl) GetStoreShifts(storeID uint, from time.Time, to time.Time) ([]model.Shift, error) {
+	var shifts []model.Shift
+	if err := r.DB.Where("store_id = ? AND start_time >= ? AND start_time < ?", storeID, from, to).
+		Order("start_time, employee_id").
+		Find(&shifts).Error; err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
+
+// 従業員のシフトを取得（開始時刻が from 以上 to 未満）
+func (r *ShiftRepositoryImpl) GetEmployeeShifts(employeeID uint, from time.Time, to time.Time, publishedOnly bool) ([]model.Shift, error) {
+	var shifts []model.Shift
+	query := r.DB.Where("employee_id = ? AND start_time >= ? AND start_time < ?", employeeID, from, to)
+	if publishedOnly {
+		query = query.Where("published_at IS NOT NULL")
+	}
+	if err := query.Order("start_time").Find(&shifts).Error; err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
+
+// 従業員の他のシフトと時間が重なるか
+func (r *ShiftRepositoryImpl) HasOverlappingShift(employeeID uint, start time.Time, end time.Time, excludeID uint) (bool, error) {
+	var count int64
+	if err := r.DB.Model(&model.Shift{}).
+		Where("employee_id = ? AND id <> ? AND start_time < ? AND end_time > ?", employeeID, excludeID, end, start).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// シフト更新
+func (r *ShiftRepositoryImpl) UpdateShift(shift *model.Shift) error {
+	return r.DB.Save(shift).Error
+}
+
+// シフト削除
+func (r *ShiftRepositoryImpl) DeleteShift(shift *model.Shift) error {
+	return r.DB.Delete(shift).Error
+}
+
+// 店舗の下書きのシフトを公開（開始時刻が from 以上 to 未満）
+func (r *ShiftRepositoryImpl) PublishShifts(storeID uint, from time.Time, to time.Time, publishedAt time.Time) (int64, error) {
+	result := r.DB.Model(&model.Shift{}).
+		Where("store_id = ? AND start_time >= ? AND start_time < ? AND published_at IS NULL", storeID, from, to).
+		Update("published_at", publishedAt)
+	return result.RowsAffected, result.Error
+}
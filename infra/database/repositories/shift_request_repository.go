@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"gorm.io/gorm"
+)
+
+type AvailabilityRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewAvailabilityRepository(db *gorm.DB) *AvailabilityRepositoryImpl {
+	return &AvailabilityRepositoryImpl{DB: db}
+}
+
+// 従業員の勤務希望を取得（対象日が from 以上 to 未満）
+func (r *AvailabilityRepositoryImpl) GetEmployeeAvailabilities(employeeID uint, from time.Time, to time.Time) ([]model.Availability, error) {
+	var availabilities []model.Availability
+	if err := r.DB.Where("employee_id = ? AND date >= ? AND date < ?", employeeID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("date, start_time").
+		Find(&availabilities).Error; err != nil {
+		return nil, err
+	}
+	return availabilities, nil
+}
+
+// 店舗を担当する従業員の勤務希望を取得（対象日が from 以上 to 未満）
+func (r *AvailabilityRepositoryImpl) GetStoreAvailabilities(storeID uint, from time.Time, to time.Time) ([]model.Availability, error) {
+	var availabilities []model.Availability
+	if err := r.DB.Where("employee_id IN (?)", r.DB.Model(&model.Employee{}).Select("id").Where("competent_store_id = ?", storeID)).
+		Where("date >= ? AND date < ?", from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("date, employee_id, start_time").
+		Find(&availabilities).Error; err != nil {
+		return nil, err
+	}
+	return availabilities, nil
+}
+
+// 従業員の期間内の勤務希望を置き換える
+func (r *AvailabilityRepositoryImpl) ReplaceAvailabilities(employeeID uint, from time.Time, to time.Time, availabilities []model.Availability) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("employee_id = ? AND date >= ? AND date < ?", employeeID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+			Delete(&model.Availability{}).Error; err != nil {
+			return err
+		}
+		if len(availabilities) == 0 {
+			return nil
+		}
+		return tx.Create(&availabilities).Error
+	})
+}
+
+type ShiftSwapRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewShiftSwapRepository(db *gorm.DB) *ShiftSwapRepositoryImpl {
+	return &ShiftSwapRepositoryImpl{DB: db}
+}
+
+// シフト交代の申請作成
+func (r *ShiftSwapRepositoryImpl) CreateShiftSwapRequest(request *model.ShiftSwapRequest) error {
+	return r.DB.Create(request).Error
+}
+
+// シフト交代の申請取得
+func (r *ShiftSwapRepositoryImpl) FindShiftSwapRequestByID(requestID uint) (*model.ShiftSwapRequest, error) {
+	var request model.ShiftSwapRequest
+	if err := r.DB.Where("id = ?", requestID).First(&request).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+// 従業員が申請した、または交代相手になっている申請を取得（新しい順）
+func (r *ShiftSwapRepositoryImpl) GetEmployeeShiftSwapRequests(employeeID uint) ([]model.ShiftSwapRequest, error) {
+	var requests []model.ShiftSwapRequest
+	if err := r.DB.Where("requester_id = ? OR target_employee_id = ?", employeeID, employeeID).
+		Order("created_at DESC").
+		Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// 店舗の申請を取得（status が空の場合はすべての状態、古い順）
+func (r *ShiftSwapRepositoryImpl) GetStoreShiftSwapRequests(storeID uint, status model.SwapStatus) ([]model.ShiftSwapRequest, error) {
+	var requests []model.ShiftSwapRequest
+	query := r.DB.Where("store_id = ?", storeID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("created_at").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// シフトに承認待ちの申請があるか（交換する相手のシフトを含む）
+func (r *ShiftSwapRepositoryImpl) HasPendingShiftSwapRequest(shiftID uint) (bool, error) {
+	var count int64
+	if err := r.DB.Model(&model.ShiftSwapRequest{}).
+		Where("status = ? AND (shift_id = ? OR counter_shift_id = ?)", model.SwapStatusPending, shiftID, shiftID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// シフト交代の申請更新
+func (r *ShiftSwapRepositoryImpl) UpdateShiftSwapRequest(request *model.ShiftSwapRequest) error {
+	return r.DB.Save(request).Error
+}
+
+// 申請を承認し、担当者を入れ替えたシフトと合わせて保存する
+func (r *ShiftSwapRepositoryImpl) ApproveShiftSwapRequest(request *model.ShiftSwapRequest, shifts []*model.Shift) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, shift := range shifts {
+			if err := tx.Save(shift).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(request).Error
+	})
+}
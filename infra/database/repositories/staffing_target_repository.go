@@ -0,0 +1,36 @@
+package repository
+
+import (
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"gorm.io/gorm"
+)
+
+type StaffingTargetRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewStaffingTargetRepository(db *gorm.DB) *StaffingTargetRepositoryImpl {
+	return &StaffingTargetRepositoryImpl{DB: db}
+}
+
+// 店舗の必要人数を取得（曜日・開始時刻順）
+func (r *StaffingTargetRepositoryImpl) GetStaffingTargets(storeID uint) ([]model.StaffingTarget, error) {
+	var targets []model.StaffingTarget
+	if err := r.DB.Where("store_id = ?", storeID).Order("weekday, start_hour").Find(&targets).Error; err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// 店舗の必要人数を置き換える
+func (r *StaffingTargetRepositoryImpl) ReplaceStaffingTargets(storeID uint, targets []model.StaffingTarget) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("store_id = ?", storeID).Delete(&model.StaffingTarget{}).Error; err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return nil
+		}
+		return tx.Create(&targets).Error
+	})
+}
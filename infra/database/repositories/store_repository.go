@@ -0,0 +1,49 @@
+package repository
+
+import (
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"gorm.io/gorm"
+)
+
+type StoreRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewStoreRepository(db *gorm.DB) *StoreRepositoryImpl {
+	return &StoreRepositoryImpl{DB: db}
+}
+
+// 店舗作成
+func (r *StoreRepositoryImpl) CreateStore(store *model.Store) error {
+	return r.DB.Create(store).Error
+}
+
+// 店舗取得
+func (r *StoreRepositoryImpl) FindStoreByID(storeID uint) (*model.Store, error) {
+	var store model.Store
+	if err := r.DB.Where("id = ?", storeID).First(&store).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &store, nil
+}
+
+// 店舗一覧取得
+func (r *StoreRepositoryImpl) GetAllStores(includeArchived bool) ([]model.Store, error) {
+	var stores []model.Store
+	query := r.DB.Order("id")
+	if !includeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+	if err := query.Find(&stores).Error; err != nil {
+		return nil, err
+	}
+	return stores, nil
+}
+
+// 店舗更新
+func (r *StoreRepositoryImpl) UpdateStore(store *model.Store) error {
+	return r.DB.Save(store).Error
+}
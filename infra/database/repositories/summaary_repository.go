@@ -4,7 +4,9 @@ import (
 	"time"
 
 	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type SummaryRepositoryImpl struct {
@@ -23,10 +25,13 @@ func (r *SummaryRepositoryImpl) GetAllEmployee() ([]model.Employee, error) {
 	return employees, nil
 }
 
-func (r *SummaryRepositoryImpl) GetAttendance(employeeID uint, year int, month int) ([]model.Attendance, error) {
+// 指定した期間（両端を含む）の勤怠を勤務日順に取得
+func (r *SummaryRepositoryImpl) GetAttendanceBetween(employeeID uint, from time.Time, to time.Time) ([]model.Attendance, error) {
 	var attendances []model.Attendance
 
-	err := r.DB.Where("employee_id = ? AND EXTRACT(YEAR FROM work_date) = ? AND EXTRACT(MONTH FROM work_date) = ?", employeeID, year, month).
+	err := r.DB.Preload("Segments", orderByStartTime).Preload("Breaks", orderByStartTime).
+		Where("employee_id = ? AND work_date BETWEEN ? AND ?", employeeID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("work_date").
 		Find(&attendances).Error
 	if err != nil {
 		return nil, err
@@ -35,18 +40,10 @@ func (r *SummaryRepositoryImpl) GetAttendance(employeeID uint, year int, month i
 	return attendances, nil
 }
 
-func (r *SummaryRepositoryImpl) GetHourlyPay(employeeID uint) (int, error) {
-	var employee model.Employee
-	if err := r.DB.Where("id = ?", employeeID).First(&employee).Error; err != nil {
-		return 0, err
-	}
-	return employee.HourlyPay, nil
-}
-
 func (r *SummaryRepositoryImpl) GetAttendanceByID(attedanceID uint) (*model.Attendance, error) {
 	var attendance model.Attendance
 
-	err := r.DB.Where("id = ?", attedanceID).First(&attendance).Error
+	err := r.DB.Preload("Segments", orderByStartTime).Preload("Breaks", orderByStartTime).Where("id = ?", attedanceID).First(&attendance).Error
 	if err != nil {
 		return nil, err
 	}
@@ -61,21 +58,99 @@ func (r *SummaryRepositoryImpl) UpdateAttendance(attendance *model.Attendance) e
 	}
 
 	// IDでレコードを検索し、更新
-	if err := tx.Model(&model.Attendance{}).Where("id = ?", attendance.ID).Updates(attendance).Error; err != nil {
+	if err := tx.Model(&model.Attendance{}).Where("id = ?", attendance.ID).Omit(clause.Associations).Updates(attendance).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// 勤務区間を受け取った内容で置き換える
+	if err := tx.Where("attendance_id = ?", attendance.ID).Delete(&model.WorkSegment{}).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
+	for i := range attendance.Segments {
+		segment := attendance.Segments[i]
+		segment.ID = 0
+		segment.AttendanceID = attendance.ID
+		if err := tx.Create(&segment).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		attendance.Segments[i] = segment
+	}
+
+	// 休憩を受け取った内容で置き換える
+	if err := tx.Where("attendance_id = ?", attendance.ID).Delete(&model.AttendanceBreak{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for i := range attendance.Breaks {
+		breakRecord := attendance.Breaks[i]
+		breakRecord.ID = 0
+		breakRecord.AttendanceID = attendance.ID
+		if err := tx.Create(&breakRecord).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		attendance.Breaks[i] = breakRecord
+	}
 
 	return tx.Commit().Error
 }
 
-func (r *SummaryRepositoryImpl) GetWorkDateByID(id uint) (time.Time, error) {
-	var attendance model.Attendance
-	err := r.DB.Where("id = ?", id).First(&attendance).Error
-	if err != nil {
-		return time.Time{}, err // errorの場合はゼロ値のtime.Timeを返す
-	}
+// 勤怠をまとめて作成・更新し、監査ログを記録する（1件でも失敗した場合はすべて取り消す）
+func (r *SummaryRepositoryImpl) ImportAttendances(imports []repositories.AttendanceImport) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, item := range imports {
+			attendance := item.Attendance
+			if attendance.ID == 0 {
+				if err := tx.Create(attendance).Error; err != nil {
+					return err
+				}
+			} else if err := replaceAttendance(tx, attendance); err != nil {
+				return err
+			}
+
+			if item.AuditLog == nil {
+				continue
+			}
+			item.AuditLog.EntityID = attendance.ID
+			if err := tx.Create(item.AuditLog).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
 
-	// attendanceから日付を取得して返す
-	return attendance.WorkDate, nil
+// 既存の勤怠のステータスを更新し、勤務区間・休憩を置き換える
+func replaceAttendance(tx *gorm.DB, attendance *model.Attendance) error {
+	if err := tx.Model(&model.Attendance{}).Where("id = ?", attendance.ID).Update("status_id", attendance.StatusID).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("attendance_id = ?", attendance.ID).Delete(&model.WorkSegment{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("attendance_id = ?", attendance.ID).Delete(&model.AttendanceBreak{}).Error; err != nil {
+		return err
+	}
+	for i := range attendance.Segments {
+		attendance.Segments[i].ID = 0
+		attendance.Segments[i].AttendanceID = attendance.ID
+	}
+	for i := range attendance.Breaks {
+		attendance.Breaks[i].ID = 0
+		attendance.Breaks[i].AttendanceID = attendance.ID
+	}
+	if len(attendance.Segments) > 0 {
+		if err := tx.Create(&attendance.Segments).Error; err != nil {
+			return err
+		}
+	}
+	if len(attendance.Breaks) > 0 {
+		if err := tx.Create(&attendance.Breaks).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
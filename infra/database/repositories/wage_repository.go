@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"gorm.io/gorm"
+)
+
+type WageRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewWageRepository(db *gorm.DB) *WageRepositoryImpl {
+	return &WageRepositoryImpl{DB: db}
+}
+
+// 時給の履歴を追加
+func (r *WageRepositoryImpl) CreateWageHistory(history *model.WageHistory) error {
+	return r.DB.Create(history).Error
+}
+
+// 従業員の時給の履歴を適用開始日順に取得
+func (r *WageRepositoryImpl) GetWageHistories(employeeID uint) ([]model.WageHistory, error) {
+	var histories []model.WageHistory
+	if err := r.DB.Where("employee_id = ?", employeeID).
+		Order("effective_from, id").
+		Find(&histories).Error; err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
+
+// 指定した日までに適用が始まっている時給の履歴を、従業員・適用開始日順に取得
+func (r *WageRepositoryImpl) GetEffectiveWageHistories(date time.Time) ([]model.WageHistory, error) {
+	var histories []model.WageHistory
+	if err := r.DB.Where("effective_from < ?", date.AddDate(0, 0, 1).Format("2006-01-02")).
+		Order("employee_id, effective_from, id").
+		Find(&histories).Error; err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
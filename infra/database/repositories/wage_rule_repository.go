@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"gorm.io/gorm"
+)
+
+type WageRuleRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewWageRuleRepository(db *gorm.DB) *WageRuleRepositoryImpl {
+	return &WageRuleRepositoryImpl{DB: db}
+}
+
+// 賃金ルール作成
+func (r *WageRuleRepositoryImpl) CreateWageRule(rule *model.WageRule) error {
+	return r.DB.Create(rule).Error
+}
+
+// 賃金ルール取得
+func (r *WageRuleRepositoryImpl) FindWageRuleByID(ruleID uint) (*model.WageRule, error) {
+	var rule model.WageRule
+	if err := r.DB.Where("id = ?", ruleID).First(&rule).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// 賃金ルール一覧取得
+func (r *WageRuleRepositoryImpl) GetWageRules() ([]model.WageRule, error) {
+	var rules []model.WageRule
+	if err := r.DB.Order("id").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// 従業員に適用される賃金ルール（全従業員向けを含む）を取得
+func (r *WageRuleRepositoryImpl) GetWageRulesForEmployee(employeeID uint) ([]model.WageRule, error) {
+	var rules []model.WageRule
+	if err := r.DB.Where("employee_id IS NULL OR employee_id = ?", employeeID).
+		Order("id").
+		Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// 賃金ルール更新
+func (r *WageRuleRepositoryImpl) UpdateWageRule(rule *model.WageRule) error {
+	return r.DB.Save(rule).Error
+}
+
+// 賃金ルールに適用終了日を設定し、続けて適用する新しいルールを作成
+func (r *WageRuleRepositoryImpl) ReplaceWageRule(current *model.WageRule, next *model.WageRule) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(current).Error; err != nil {
+			return err
+		}
+		return tx.Create(next).Error
+	})
+}
+
+// 賃金ルール削除
+func (r *WageRuleRepositoryImpl) DeleteWageRule(rule *model.WageRule) error {
+	return r.DB.Delete(rule).Error
+}
+
+type HolidayRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewHolidayRepository(db *gorm.DB) *HolidayRepositoryImpl {
+	return &HolidayRepositoryImpl{DB: db}
+}
+
+// 祝日登録
+func (r *HolidayRepositoryImpl) CreateHoliday(holiday *model.Holiday) error {
+	return r.DB.Create(holiday).Error
+}
+
+// 祝日取得
+func (r *HolidayRepositoryImpl) FindHolidayByID(holidayID uint) (*model.Holiday, error) {
+	var holiday model.Holiday
+	if err := r.DB.Where("id = ?", holidayID).First(&holiday).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+// 指定した期間（両端を含む）の祝日を日付順に取得
+func (r *HolidayRepositoryImpl) GetHolidaysBetween(from time.Time, to time.Time) ([]model.Holiday, error) {
+	var holidays []model.Holiday
+	if err := r.DB.Where("date BETWEEN ? AND ?", from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("date").
+		Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+// 祝日削除（同じ日付を再登録できるよう物理削除する）
+func (r *HolidayRepositoryImpl) DeleteHoliday(holiday *model.Holiday) error {
+	return r.DB.Unscoped().Delete(holiday).Error
+}
+
+type MinimumWageRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewMinimumWageRepository(db *gorm.DB) *MinimumWageRepositoryImpl {
+	return &MinimumWageRepositoryImpl{DB: db}
+}
+
+// 最低賃金登録
+func (r *MinimumWageRepositoryImpl) CreateMinimumWage(wage *model.MinimumWage) error {
+	return r.DB.Create(wage).Error
+}
+
+// 最低賃金取得
+func (r *MinimumWageRepositoryImpl) FindMinimumWageByID(wageID uint) (*model.MinimumWage, error) {
+	var wage model.MinimumWage
+	if err := r.DB.Where("id = ?", wageID).First(&wage).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &wage, nil
+}
+
+// 最低賃金を都道府県・適用開始日順に取得（prefecture が空の場合は全都道府県）
+func (r *MinimumWageRepositoryImpl) GetMinimumWages(prefecture string) ([]model.MinimumWage, error) {
+	query := r.DB.Order("prefecture").Order("effective_from")
+	if prefecture != "" {
+		query = query.Where("prefecture = ?", prefecture)
+	}
+	var wages []model.MinimumWage
+	if err := query.Find(&wages).Error; err != nil {
+		return nil, err
+	}
+	return wages, nil
+}
+
+// 最低賃金削除（同じ都道府県・適用開始日を再登録できるよう物理削除する）
+func (r *MinimumWageRepositoryImpl) DeleteMinimumWage(wage *model.MinimumWage) error {
+	return r.DB.Unscoped().Delete(wage).Error
+}
@@ -0,0 +1,62 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techyoichiro/jobreco-api/crypto"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	controller "github.com/techyoichiro/jobreco-api/interface/controllers"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+// EmployeeFinder アクセストークンの従業員の現在の情報を取得する（退職済みの場合はエラー）
+type EmployeeFinder func(employeeID uint) (*model.Employee, error)
+
+// AuthMiddleware Authorizationヘッダのアクセストークンを検証し、操作者をコンテキストに格納する
+// 権限・担当店舗はトークンの発行時点ではなく、リクエストごとに取得した従業員の現在の情報を使う
+func AuthMiddleware(findEmployee EmployeeFinder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		tokenString, found := strings.CutPrefix(header, "Bearer ")
+		if !found || tokenString == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "認証トークンがありません"})
+			return
+		}
+
+		claims, err := crypto.ParseToken(tokenString)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "認証トークンが無効です"})
+			return
+		}
+
+		employee, err := findEmployee(claims.EmployeeID)
+		if errors.Is(err, services.ErrEmployeeNotFound) || errors.Is(err, services.ErrEmployeeDeactivated) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "認証トークンが無効です"})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Set(controller.ContextEmployeeIDKey, employee.ID)
+		c.Set(controller.ContextRoleIDKey, employee.RoleID)
+		c.Set(controller.ContextStoreIDKey, int(employee.StoreID()))
+		c.Next()
+	}
+}
+
+// RequirePermission 操作者の権限が指定した操作を許可していない場合は403を返す
+func RequirePermission(p model.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := model.Role(c.GetInt(controller.ContextRoleIDKey))
+		if !role.HasPermission(p) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "この操作を行う権限がありません"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,88 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techyoichiro/jobreco-api/crypto"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	controller "github.com/techyoichiro/jobreco-api/interface/controllers"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	os.Setenv("JWT_SECRET_KEY", "test_secret")
+
+	// トークンは店舗1の一般従業員として発行
+	token, _, err := crypto.GenerateToken(5, 1, 1)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	storeID := 2
+	deactivatedAt := time.Now()
+	// トークン発行後に店長・店舗2へ変更された従業員
+	current := &model.Employee{RoleID: int(model.RoleStoreManager), CompetentStoreID: &storeID}
+	current.ID = 5
+	deactivated := &model.Employee{RoleID: 1, DeactivatedAt: &deactivatedAt}
+	deactivated.ID = 5
+
+	findEmployee := func(employee *model.Employee) EmployeeFinder {
+		return func(employeeID uint) (*model.Employee, error) {
+			if employeeID != employee.ID {
+				return nil, services.ErrEmployeeNotFound
+			}
+			if !employee.IsActive() {
+				return nil, services.ErrEmployeeDeactivated
+			}
+			return employee, nil
+		}
+	}
+
+	tests := []struct {
+		name       string
+		header     string
+		employee   *model.Employee
+		wantStatus int
+	}{
+		{name: "Valid token", header: "Bearer " + token, employee: current, wantStatus: http.StatusOK},
+		{name: "No header", header: "", employee: current, wantStatus: http.StatusUnauthorized},
+		{name: "No bearer prefix", header: token, employee: current, wantStatus: http.StatusUnauthorized},
+		{name: "Invalid token", header: "Bearer invalid_token", employee: current, wantStatus: http.StatusUnauthorized},
+		{name: "Deactivated employee", header: "Bearer " + token, employee: deactivated, wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.GET("/", AuthMiddleware(findEmployee(tt.employee)), func(c *gin.Context) {
+				if got := c.GetUint(controller.ContextEmployeeIDKey); got != 5 {
+					t.Errorf("expected employeeID 5, got %v", got)
+				}
+				// トークンの内容ではなく現在の権限・担当店舗が使われる
+				if got := c.GetInt(controller.ContextRoleIDKey); got != int(model.RoleStoreManager) {
+					t.Errorf("expected roleID %v, got %v", int(model.RoleStoreManager), got)
+				}
+				if got := c.GetInt(controller.ContextStoreIDKey); got != 2 {
+					t.Errorf("expected storeID 2, got %v", got)
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %v, got %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
@@ -3,11 +3,12 @@ package router
 import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
 	controller "github.com/techyoichiro/jobreco-api/interface/controllers"
 )
 
 // SetupRouter sets up the routes for the application.
-func SetupRouter(authController *controller.AuthController, attendanceController *controller.AttendanceController, summaryController *controller.SummaryController) *gin.Engine {
+func SetupRouter(authController *controller.AuthController, attendanceController *controller.AttendanceController, summaryController *controller.SummaryController, storeController *controller.StoreController, payrollController *controller.PayrollController, wageController *controller.WageController, wageRuleController *controller.WageRuleController, shiftController *controller.ShiftController, shiftRequestController *controller.ShiftRequestController, correctionController *controller.CorrectionController, auditController *controller.AuditController, periodController *controller.PeriodController, exportController *controller.ExportController, importController *controller.ImportController, employeeController *controller.EmployeeController) *gin.Engine {
 	router := gin.Default()
 
 	// CORS設定を手動で追加
@@ -33,25 +34,155 @@ func SetupRouter(authController *controller.AuthController, attendanceController
 	{
 		authRouter.POST("/signup", authController.PostSignup)
 		authRouter.POST("/login", authController.PostLogin)
-		authRouter.POST("/change-password", authController.PostChangePassword)
-		authRouter.POST("/update", authController.PostUpdateAccount)
+	}
+
+	// 以降のルートはアクセストークンが必要
+	authorized := router.Group("/", AuthMiddleware(authController.FindActiveEmployee))
 
+	accountRouter := authorized.Group("/auth", RequirePermission(model.PermUpdateAccount))
+	{
+		accountRouter.POST("/change-password", authController.PostChangePassword)
+		accountRouter.POST("/update", authController.PostUpdateAccount)
 	}
 
-	attendanceRouter := router.Group("/attendance")
+	attendanceRouter := authorized.Group("/attendance", RequirePermission(model.PermPunch))
 	{
+		attendanceRouter.GET("/actions", attendanceController.GetAllowedActions)
 		attendanceRouter.POST("/clockin", attendanceController.PostClockIn)
 		attendanceRouter.POST("/clockout", attendanceController.PostClockOut)
 		attendanceRouter.POST("/goout", attendanceController.PostGoOut)
 		attendanceRouter.POST("/return", attendanceController.PostReturn)
 	}
 
-	summaryRouter := router.Group("/summary")
+	summaryRouter := authorized.Group("/summary")
+	{
+		summaryRouter.GET("/init", RequirePermission(model.PermListEmployees), summaryController.GetAllEmployee)
+		summaryRouter.GET("/:employeeId/:year/:month", RequirePermission(model.PermViewSummary), summaryController.GetAttendance)
+		summaryRouter.GET("/:employeeId/:year/:month/reconciliation", RequirePermission(model.PermViewSummary), summaryController.GetReconciliation)
+		summaryRouter.GET("/edit/:attendanceID", RequirePermission(model.PermViewSummary), summaryController.GetAttendanceByID)
+		summaryRouter.POST("/edit/:attendanceID", RequirePermission(model.PermEditAttendance), correctionController.PostAttendanceEdit)
+	}
+
+	correctionRouter := authorized.Group("/corrections")
+	{
+		correctionRouter.GET("", RequirePermission(model.PermEditAttendance), correctionController.GetMyCorrections)
+		correctionRouter.POST("", RequirePermission(model.PermEditAttendance), correctionController.PostCorrection)
+		correctionRouter.GET("/review", RequirePermission(model.PermApproveCorrection), correctionController.GetReviewableCorrections)
+		correctionRouter.GET("/:correctionID", correctionController.GetCorrection)
+		correctionRouter.POST("/:correctionID/cancel", RequirePermission(model.PermEditAttendance), correctionController.PostCancelCorrection)
+		correctionRouter.POST("/:correctionID/approve", RequirePermission(model.PermApproveCorrection), correctionController.PostApproveCorrection)
+		correctionRouter.POST("/:correctionID/reject", RequirePermission(model.PermApproveCorrection), correctionController.PostRejectCorrection)
+	}
+
+	storeRouter := authorized.Group("/stores")
+	{
+		storeRouter.GET("", storeController.GetStores)
+		storeRouter.POST("", RequirePermission(model.PermManageStores), storeController.PostStore)
+		storeRouter.PUT("/:storeID", RequirePermission(model.PermManageStores), storeController.PutStore)
+		storeRouter.POST("/:storeID/archive", RequirePermission(model.PermManageStores), storeController.PostArchiveStore)
+	}
+
+	payrollRouter := authorized.Group("/payroll")
+	{
+		payrollRouter.GET("/:employeeId/:year/:month", RequirePermission(model.PermViewPayroll), payrollController.GetPayroll)
+		payrollRouter.POST("/:employeeId/:year/:month", RequirePermission(model.PermRunPayroll), payrollController.PostPayroll)
+	}
+
+	wageRouter := authorized.Group("/wages")
+	{
+		wageRouter.GET("/:employeeId", RequirePermission(model.PermViewPayroll), wageController.GetWageHistories)
+		wageRouter.POST("/:employeeId", RequirePermission(model.PermUpdateHourlyPay), wageController.PostWage)
+	}
+
+	wageRuleRouter := authorized.Group("/wage-rules", RequirePermission(model.PermUpdateHourlyPay))
+	{
+		wageRuleRouter.GET("", wageRuleController.GetWageRules)
+		wageRuleRouter.POST("", wageRuleController.PostWageRule)
+		wageRuleRouter.PUT("/:ruleID", wageRuleController.PutWageRule)
+		wageRuleRouter.DELETE("/:ruleID", wageRuleController.DeleteWageRule)
+	}
+
+	holidayRouter := authorized.Group("/holidays")
+	{
+		holidayRouter.GET("", wageRuleController.GetHolidays)
+		holidayRouter.POST("", RequirePermission(model.PermManageStores), wageRuleController.PostHoliday)
+		holidayRouter.DELETE("/:holidayID", RequirePermission(model.PermManageStores), wageRuleController.DeleteHoliday)
+	}
+
+	minimumWageRouter := authorized.Group("/minimum-wages")
+	{
+		minimumWageRouter.GET("", wageRuleController.GetMinimumWages)
+		minimumWageRouter.POST("", RequirePermission(model.PermManageStores), wageRuleController.PostMinimumWage)
+		minimumWageRouter.DELETE("/:minimumWageID", RequirePermission(model.PermManageStores), wageRuleController.DeleteMinimumWage)
+	}
+
+	shiftRouter := authorized.Group("/shifts")
+	{
+		shiftRouter.GET("/mine", RequirePermission(model.PermViewShifts), shiftController.GetMyShifts)
+		shiftRouter.GET("", RequirePermission(model.PermManageShifts), shiftController.GetStoreShifts)
+		shiftRouter.POST("", RequirePermission(model.PermManageShifts), shiftController.PostShift)
+		shiftRouter.PUT("/:shiftID", RequirePermission(model.PermManageShifts), shiftController.PutShift)
+		shiftRouter.DELETE("/:shiftID", RequirePermission(model.PermManageShifts), shiftController.DeleteShift)
+		shiftRouter.POST("/copy", RequirePermission(model.PermManageShifts), shiftController.PostCopyShifts)
+		shiftRouter.POST("/publish", RequirePermission(model.PermManageShifts), shiftController.PostPublishShifts)
+		shiftRouter.POST("/generate", RequirePermission(model.PermManageShifts), shiftController.PostGenerateShifts)
+	}
+
+	staffingRouter := authorized.Group("/staffing-targets", RequirePermission(model.PermManageShifts))
+	{
+		staffingRouter.GET("", shiftController.GetStaffingTargets)
+		staffingRouter.PUT("", shiftController.PutStaffingTargets)
+	}
+
+	availabilityRouter := authorized.Group("/availability")
+	{
+		availabilityRouter.GET("", RequirePermission(model.PermRequestShifts), shiftRequestController.GetMyAvailabilities)
+		availabilityRouter.PUT("", RequirePermission(model.PermRequestShifts), shiftRequestController.PutAvailabilities)
+		availabilityRouter.GET("/store", RequirePermission(model.PermManageShifts), shiftRequestController.GetStoreAvailabilities)
+	}
+
+	shiftSwapRouter := authorized.Group("/shift-swaps")
+	{
+		shiftSwapRouter.GET("", RequirePermission(model.PermRequestShifts), shiftRequestController.GetMyShiftSwaps)
+		shiftSwapRouter.POST("", RequirePermission(model.PermRequestShifts), shiftRequestController.PostShiftSwap)
+		shiftSwapRouter.POST("/:requestID/cancel", RequirePermission(model.PermRequestShifts), shiftRequestController.PostCancelShiftSwap)
+		shiftSwapRouter.GET("/store", RequirePermission(model.PermManageShifts), shiftRequestController.GetStoreShiftSwaps)
+		shiftSwapRouter.POST("/:requestID/approve", RequirePermission(model.PermManageShifts), shiftRequestController.PostApproveShiftSwap)
+		shiftSwapRouter.POST("/:requestID/reject", RequirePermission(model.PermManageShifts), shiftRequestController.PostRejectShiftSwap)
+	}
+
+	auditRouter := authorized.Group("/audit-logs", RequirePermission(model.PermViewAuditLog))
+	{
+		auditRouter.GET("", auditController.GetAuditLogs)
+	}
+
+	periodRouter := authorized.Group("/periods")
+	{
+		periodRouter.GET("", RequirePermission(model.PermClosePeriod), periodController.GetPeriodCloses)
+		periodRouter.POST("/:storeID/:year/:month/close", RequirePermission(model.PermClosePeriod), periodController.PostClosePeriod)
+		periodRouter.POST("/:storeID/:year/:month/reopen", RequirePermission(model.PermReopenPeriod), periodController.PostReopenPeriod)
+	}
+
+	exportRouter := authorized.Group("/export")
+	{
+		exportRouter.GET("/summary/employees/:employeeId/:year/:month", RequirePermission(model.PermViewSummary), exportController.GetEmployeeSummaryCSV)
+		exportRouter.GET("/summary/stores/:storeID/:year/:month", RequirePermission(model.PermListEmployees), exportController.GetStoreSummaryCSV)
+		exportRouter.GET("/timesheet/stores/:storeID/:year/:month", RequirePermission(model.PermListEmployees), exportController.GetStoreTimesheetXLSX)
+		exportRouter.GET("/payslips/employees/:employeeId/:year/:month", RequirePermission(model.PermViewPayroll), exportController.GetPayslipPDF)
+		exportRouter.GET("/payslips/stores/:storeID/:year/:month", RequirePermission(model.PermListEmployees), exportController.GetStorePayslipsZip)
+	}
+
+	importRouter := authorized.Group("/import", RequirePermission(model.PermImportAttendance))
+	{
+		importRouter.POST("/attendance", importController.PostAttendanceCSV)
+	}
+
+	employeeRouter := authorized.Group("/employees", RequirePermission(model.PermManageEmployees))
 	{
-		summaryRouter.GET("/init", summaryController.GetAllEmployee)
-		summaryRouter.GET("/:employeeId/:year/:month", summaryController.GetAttendance)
-		summaryRouter.GET("/edit/:attendanceID", summaryController.GetAttendanceByID)
-		summaryRouter.POST("/edit/:attendanceID", summaryController.UpdateAttendance)
+		employeeRouter.POST("/import", employeeController.PostImportEmployees)
+		employeeRouter.POST("/:employeeId/deactivate", employeeController.PostDeactivateEmployee)
+		employeeRouter.POST("/:employeeId/reactivate", employeeController.PostReactivateEmployee)
+		employeeRouter.POST("/:employeeId/store", RequirePermission(model.PermAssignStore), employeeController.PostAssignStore)
 	}
 
 	return router
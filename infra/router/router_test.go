@@ -10,9 +10,21 @@ import (
 
 func TestSetupRouter(t *testing.T) {
 	type args struct {
-		authController       *controller.AuthController
-		attendanceController *controller.AttendanceController
-		summaryController    *controller.SummaryController
+		authController         *controller.AuthController
+		attendanceController   *controller.AttendanceController
+		summaryController      *controller.SummaryController
+		storeController        *controller.StoreController
+		payrollController      *controller.PayrollController
+		wageController         *controller.WageController
+		wageRuleController     *controller.WageRuleController
+		shiftController        *controller.ShiftController
+		shiftRequestController *controller.ShiftRequestController
+		correctionController   *controller.CorrectionController
+		auditController        *controller.AuditController
+		periodController       *controller.PeriodController
+		exportController       *controller.ExportController
+		importController       *controller.ImportController
+		employeeController     *controller.EmployeeController
 	}
 	tests := []struct {
 		name string
@@ -23,7 +35,7 @@ func TestSetupRouter(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := SetupRouter(tt.args.authController, tt.args.attendanceController, tt.args.summaryController); !reflect.DeepEqual(got, tt.want) {
+			if got := SetupRouter(tt.args.authController, tt.args.attendanceController, tt.args.summaryController, tt.args.storeController, tt.args.payrollController, tt.args.wageController, tt.args.wageRuleController, tt.args.shiftController, tt.args.shiftRequestController, tt.args.correctionController, tt.args.auditController, tt.args.periodController, tt.args.exportController, tt.args.importController, tt.args.employeeController); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("SetupRouter() = %v, want %v", got, tt.want)
 			}
 		})
@@ -1,9 +1,11 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
 	"github.com/techyoichiro/jobreco-api/usecase/services"
 )
 
@@ -17,51 +19,78 @@ func NewAttendanceController(service *services.AttendanceService) *AttendanceCon
 	}
 }
 
-// 出勤
-func (ac *AttendanceController) PostClockIn(c *gin.Context) {
-	var req struct {
-		EmployeeID uint `json:"employee_id"`
-		StoreID    uint `json:"store_id"`
-	}
+// 打刻リクエスト
+type PunchRequest struct {
+	StoreID uint `json:"store_id"`
+}
 
-	if err := c.BindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+// 打刻結果を返す（現在のステータスで行えない打刻は409）
+func respondPunch(c *gin.Context, status model.AttendanceStatus, err error) {
+	var transitionErr *services.TransitionError
+	if errors.As(err, &transitionErr) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":           transitionErr.Error(),
+			"code":            "invalid_transition",
+			"statusID":        transitionErr.Current,
+			"action":          transitionErr.Action,
+			"allowed_actions": transitionErr.Allowed,
+		})
 		return
 	}
-
-	if err := ac.service.ClockIn(req.EmployeeID, req.StoreID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"statusID": 1})
+	c.JSON(http.StatusOK, gin.H{
+		"statusID":        status,
+		"allowed_actions": services.AllowedActions(status),
+	})
 }
 
-// 退勤
-func (ac *AttendanceController) PostClockOut(c *gin.Context) {
-	var req struct {
-		EmployeeID uint `json:"employee_id"`
-		StoreID    uint `json:"store_id"`
+// 現在のステータスと可能な打刻を取得
+func (ac *AttendanceController) GetAllowedActions(c *gin.Context) {
+	status, actions, err := ac.service.GetAllowedActions(currentEmployeeID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
+	c.JSON(http.StatusOK, gin.H{
+		"statusID":        status,
+		"allowed_actions": actions,
+	})
+}
+
+// 出勤
+func (ac *AttendanceController) PostClockIn(c *gin.Context) {
+	var req PunchRequest
 	if err := c.BindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
 		return
 	}
 
-	if err := ac.service.ClockOut(req.EmployeeID, req.StoreID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	status, err := ac.service.ClockIn(currentEmployeeID(c), req.StoreID)
+	respondPunch(c, status, err)
+}
+
+// 退勤
+func (ac *AttendanceController) PostClockOut(c *gin.Context) {
+	var req PunchRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"statusID": 3})
+	status, err := ac.service.ClockOut(currentEmployeeID(c), req.StoreID)
+	respondPunch(c, status, err)
 }
 
 // 外出
 func (ac *AttendanceController) PostGoOut(c *gin.Context) {
 	var req struct {
-		EmployeeID uint `json:"employee_id"`
-		StoreID    uint `json:"store_id"`
+		StoreID   uint            `json:"store_id"`
+		BreakType model.BreakType `json:"break_type"`
 	}
 
 	if err := c.BindJSON(&req); err != nil {
@@ -69,30 +98,18 @@ func (ac *AttendanceController) PostGoOut(c *gin.Context) {
 		return
 	}
 
-	if err := ac.service.GoOut(req.EmployeeID, req.StoreID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{"statusID": 2})
+	status, err := ac.service.GoOut(currentEmployeeID(c), req.StoreID, req.BreakType)
+	respondPunch(c, status, err)
 }
 
 // 戻り
 func (ac *AttendanceController) PostReturn(c *gin.Context) {
-	var req struct {
-		EmployeeID uint `json:"employee_id"`
-		StoreID    uint `json:"store_id"`
-	}
-
+	var req PunchRequest
 	if err := c.BindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
 		return
 	}
 
-	if err := ac.service.Return(req.EmployeeID, req.StoreID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{"statusID": 4})
+	status, err := ac.service.Return(currentEmployeeID(c), req.StoreID)
+	respondPunch(c, status, err)
 }
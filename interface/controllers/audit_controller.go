@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+type AuditController struct {
+	service *services.AuditService
+}
+
+func NewAuditController(service *services.AuditService) *AuditController {
+	return &AuditController{service: service}
+}
+
+// 監査ログの検索（employee_id・entity・from・to、期間の指定がなければ今日から1日分）
+func (ac *AuditController) GetAuditLogs(c *gin.Context) {
+	var filter repositories.AuditLogFilter
+	if employeeID := c.Query("employee_id"); employeeID != "" {
+		parsed, err := strconv.ParseUint(employeeID, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+			return
+		}
+		filter.EmployeeID = uint(parsed)
+	}
+	filter.Entity = model.AuditEntity(c.Query("entity"))
+
+	from, to, ok := dateRange(c, 1)
+	if !ok {
+		return
+	}
+	filter.From, filter.To = from, to
+
+	logs, err := ac.service.GetAuditLogs(currentActor(c), filter)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, logs)
+}
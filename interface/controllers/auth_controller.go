@@ -5,24 +5,33 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
 	"github.com/techyoichiro/jobreco-api/usecase/services"
 )
 
 type AuthController struct {
-	service *services.AuthService
+	service           *services.AuthService
+	attendanceService *services.AttendanceService
 }
 
-func NewAuthController(service *services.AuthService) *AuthController {
+func NewAuthController(service *services.AuthService, attendanceService *services.AttendanceService) *AuthController {
 	return &AuthController{
-		service: service,
+		service:           service,
+		attendanceService: attendanceService,
 	}
 }
 
+// アクセストークンの従業員の現在の情報を取得（認証ミドルウェアから使う）
+func (ac *AuthController) FindActiveEmployee(employeeID uint) (*model.Employee, error) {
+	return ac.service.FindActiveEmployee(employeeID)
+}
+
 func (ac *AuthController) PostSignup(c *gin.Context) {
 	var req struct {
 		Name     string `json:"name"`
 		LoginID  string `json:"login_id"`
 		Password string `json:"password"`
+		StoreID  uint   `json:"store_id"`
 	}
 
 	if err := c.BindJSON(&req); err != nil {
@@ -30,9 +39,9 @@ func (ac *AuthController) PostSignup(c *gin.Context) {
 		return
 	}
 
-	employee, err := ac.service.Signup(req.Name, req.LoginID, req.Password)
+	employee, err := ac.service.Signup(req.Name, req.LoginID, req.Password, req.StoreID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -64,29 +73,38 @@ func (ac *AuthController) PostLogin(c *gin.Context) {
 	}
 
 	// ログインユーザーに紐づく status_id を取得
-	statusID, err := ac.service.GetStatusByEmpID(emp.ID)
+	statusID, allowedActions, err := ac.attendanceService.GetAllowedActions(emp.ID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve status_id"})
 		return
 	}
 
-	// ユーザー情報と status_id を返す
+	// アクセストークンを発行
+	token, expiresAt, err := ac.service.IssueToken(emp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue token"})
+		return
+	}
+
+	// ユーザー情報と status_id、アクセストークンを返す
 	c.JSON(http.StatusOK, gin.H{
+		"token":      token,
+		"expires_at": expiresAt,
 		"employee": gin.H{
 			"ID":          emp.ID,
 			"Name":        emp.Name,
 			"RoleID":      emp.RoleID,
 			"HourlyPay":   emp.HourlyPay,
-			"CompetentID": emp.CompetentStoreID,
+			"CompetentID": emp.StoreID(),
 		},
-		"status_id": statusID,
+		"status_id":       statusID,
+		"allowed_actions": allowedActions,
 	})
 }
 
 // パスワード変更
 func (ac *AuthController) PostChangePassword(c *gin.Context) {
 	var req struct {
-		ID              string `json:"employee_id"`
 		CurrentPassword string `json:"current_password"`
 		NewPassword     string `json:"new_password"`
 	}
@@ -97,8 +115,8 @@ func (ac *AuthController) PostChangePassword(c *gin.Context) {
 		return
 	}
 
-	// 従業員IDから暗号化されたlogin_idを取得
-	loginID, err := ac.service.GetLoginIDByEmpID(req.ID)
+	// トークンの従業員IDから暗号化されたlogin_idを取得
+	loginID, err := ac.service.GetLoginIDByEmpID(strconv.FormatUint(uint64(currentEmployeeID(c)), 10))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve login_id"})
 		return
@@ -128,17 +146,26 @@ func (ac *AuthController) PostUpdateAccount(c *gin.Context) {
 		return
 	}
 
-	// 各フィールドを数値に変換する
-	id, err := strconv.Atoi(req.ID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "employee_id must be a valid number"})
-		return
+	// 更新対象は指定がなければトークンの従業員
+	id := int(currentEmployeeID(c))
+	if req.ID != "" {
+		targetID, err := strconv.Atoi(req.ID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "employee_id must be a valid number"})
+			return
+		}
+		id = targetID
 	}
 
-	hourlyPay, err := strconv.Atoi(req.HourlyPay)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "hourly_pay must be a valid number"})
-		return
+	// 各フィールドを数値に変換する（時給は指定がなければ変更しない）
+	var hourlyPay *int
+	if req.HourlyPay != "" {
+		pay, err := strconv.Atoi(req.HourlyPay)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "hourly_pay must be a valid number"})
+			return
+		}
+		hourlyPay = &pay
 	}
 
 	competentStoreID, err := strconv.Atoi(req.CompetentStoreID)
@@ -148,8 +175,8 @@ func (ac *AuthController) PostUpdateAccount(c *gin.Context) {
 	}
 
 	// 数値に変換した値をサービス層に渡して更新処理を実行
-	if err := ac.service.UpdateAccount(id, req.Name, hourlyPay, competentStoreID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := ac.service.UpdateAccount(currentActor(c), id, req.Name, hourlyPay, competentStoreID); err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+// 認証ミドルウェアがコンテキストに格納するキー
+const (
+	ContextEmployeeIDKey = "employeeID"
+	ContextRoleIDKey     = "roleID"
+	ContextStoreIDKey    = "storeID"
+)
+
+// トークンから取得した操作者の従業員IDを返す
+func currentEmployeeID(c *gin.Context) uint {
+	return c.GetUint(ContextEmployeeIDKey)
+}
+
+// トークンから取得した操作者を返す
+func currentActor(c *gin.Context) services.Actor {
+	return services.Actor{
+		EmployeeID: c.GetUint(ContextEmployeeIDKey),
+		Role:       model.Role(c.GetInt(ContextRoleIDKey)),
+		StoreID:    c.GetInt(ContextStoreIDKey),
+	}
+}
+
+// サービス層のエラーに対応するステータスコードを返す
+func errorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrForbidden), errors.Is(err, services.ErrEmployeeDeactivated):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrStoreNotFound), errors.Is(err, services.ErrPayrollNotFound),
+		errors.Is(err, services.ErrWageRuleNotFound), errors.Is(err, services.ErrHolidayNotFound),
+		errors.Is(err, services.ErrMinimumWageNotFound),
+		errors.Is(err, services.ErrShiftNotFound), errors.Is(err, services.ErrShiftSwapNotFound),
+		errors.Is(err, services.ErrCorrectionNotFound), errors.Is(err, services.ErrEmployeeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrPeriodClosed):
+		return http.StatusConflict
+	default:
+		return fallback
+	}
+}
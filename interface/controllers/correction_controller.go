@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+type CorrectionController struct {
+	service *services.CorrectionService
+}
+
+func NewCorrectionController(service *services.CorrectionService) *CorrectionController {
+	return &CorrectionController{service: service}
+}
+
+// 修正する項目を指定した修正申請のリクエスト
+type CorrectionRequest struct {
+	AttendanceID uint   `json:"attendance_id"`
+	Reason       string `json:"reason"`
+	Items        []struct {
+		Field    string `json:"field"`
+		NewValue string `json:"new_value"`
+	} `json:"items"`
+}
+
+// 勤怠の編集画面からの修正申請のリクエスト（編集後の勤怠と理由）
+type AttendanceEditRequest struct {
+	model.AttendanceResponse
+	Reason string `json:"Reason"`
+}
+
+// 修正申請IDを取得（不正な場合はレスポンスを返す）
+func correctionID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("correctionID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid correction ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// 自分の修正申請の一覧取得
+func (cc *CorrectionController) GetMyCorrections(c *gin.Context) {
+	corrections, err := cc.service.GetMyCorrections(currentActor(c))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, corrections)
+}
+
+// 承認できる修正申請の一覧取得（status の指定がなければ承認待ち）
+func (cc *CorrectionController) GetReviewableCorrections(c *gin.Context) {
+	status := model.CorrectionStatus(c.DefaultQuery("status", string(model.CorrectionPending)))
+
+	corrections, err := cc.service.GetReviewableCorrections(currentActor(c), status)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, corrections)
+}
+
+// 修正申請の取得
+func (cc *CorrectionController) GetCorrection(c *gin.Context) {
+	id, ok := correctionID(c)
+	if !ok {
+		return
+	}
+
+	correction, err := cc.service.GetCorrection(currentActor(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, correction)
+}
+
+// 修正する項目を指定して修正申請
+func (cc *CorrectionController) PostCorrection(c *gin.Context) {
+	var req CorrectionRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	items := make([]model.AttendanceCorrectionItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, model.AttendanceCorrectionItem{Field: item.Field, NewValue: item.NewValue})
+	}
+
+	correction, err := cc.service.RequestCorrection(currentActor(c), req.AttendanceID, req.Reason, items)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, correction)
+}
+
+// 編集後の勤怠を送信して修正申請（承認されるまで勤怠には反映しない）
+func (cc *CorrectionController) PostAttendanceEdit(c *gin.Context) {
+	attendanceID, err := strconv.ParseUint(c.Param("attendanceID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attendance ID"})
+		return
+	}
+
+	var req AttendanceEditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.AttendanceResponse.ID = uint(attendanceID)
+
+	correction, err := cc.service.RequestAttendanceEdit(currentActor(c), &req.AttendanceResponse, req.Reason)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "勤怠の修正を申請しました", "correction": correction})
+}
+
+// 修正申請の取り下げ
+func (cc *CorrectionController) PostCancelCorrection(c *gin.Context) {
+	id, ok := correctionID(c)
+	if !ok {
+		return
+	}
+
+	correction, err := cc.service.CancelCorrection(currentActor(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, correction)
+}
+
+// 修正申請の承認（勤怠に反映する）
+func (cc *CorrectionController) PostApproveCorrection(c *gin.Context) {
+	cc.reviewCorrection(c, cc.service.ApproveCorrection)
+}
+
+// 修正申請の却下
+func (cc *CorrectionController) PostRejectCorrection(c *gin.Context) {
+	cc.reviewCorrection(c, cc.service.RejectCorrection)
+}
+
+// 承認・却下の共通処理（コメントは任意）
+func (cc *CorrectionController) reviewCorrection(c *gin.Context, review func(services.Actor, uint, string) (*model.AttendanceCorrection, error)) {
+	id, ok := correctionID(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Note string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	correction, err := review(currentActor(c), id, req.Note)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, correction)
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+type EmployeeController struct {
+	service *services.EmployeeService
+}
+
+func NewEmployeeController(service *services.EmployeeService) *EmployeeController {
+	return &EmployeeController{service: service}
+}
+
+// 在籍状況のレスポンス
+func employmentResponse(employee *model.Employee) gin.H {
+	return gin.H{
+		"ID":                 employee.ID,
+		"Name":               employee.Name,
+		"DeactivatedAt":      employee.DeactivatedAt,
+		"DeactivationReason": employee.DeactivationReason,
+		"RetentionUntil":     employee.RetentionUntil,
+	}
+}
+
+// 従業員を一括登録
+// JSON（従業員の配列）、multipart の file 項目、またはリクエストボディのCSVを受け付ける
+// dry_run=true の場合は検証のみ行い、エラーがあれば行ごとのエラーを 422 で返す
+func (ec *EmployeeController) PostImportEmployees(c *gin.Context) {
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dry_run"})
+			return
+		}
+	}
+
+	var result *services.EmployeeImportResult
+	var err error
+	if c.ContentType() == "application/json" {
+		var inputs []services.EmployeeInput
+		if err := c.ShouldBindJSON(&inputs); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+		for i := range inputs {
+			inputs[i].Line = i + 1
+		}
+		result, err = ec.service.OnboardEmployees(currentActor(c), inputs, dryRun)
+	} else {
+		enc, encErr := services.ParseCSVEncoding(c.Query("encoding"))
+		if encErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": encErr.Error()})
+			return
+		}
+		var body io.Reader = c.Request.Body
+		if file, fileErr := c.FormFile("file"); fileErr == nil {
+			opened, openErr := file.Open()
+			if openErr != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": openErr.Error()})
+				return
+			}
+			defer opened.Close()
+			body = opened
+		}
+		result, err = ec.service.ImportEmployeesCSV(currentActor(c), body, enc, dryRun)
+	}
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	if len(result.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// 従業員の退職処理
+func (ec *EmployeeController) PostDeactivateEmployee(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("employeeId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return
+	}
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	employee, err := ec.service.DeactivateEmployee(currentActor(c), uint(employeeID), req.Reason)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"employee": employmentResponse(employee)})
+}
+
+// 従業員の退職処理の取り消し
+func (ec *EmployeeController) PostReactivateEmployee(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("employeeId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return
+	}
+
+	employee, err := ec.service.ReactivateEmployee(currentActor(c), uint(employeeID))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"employee": employmentResponse(employee)})
+}
+
+// 従業員の担当店舗を変更
+func (ec *EmployeeController) PostAssignStore(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("employeeId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return
+	}
+	var req struct {
+		StoreID uint `json:"store_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.StoreID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "店舗を選択してください"})
+		return
+	}
+
+	employee, err := ec.service.AssignStore(currentActor(c), uint(employeeID), req.StoreID)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"employee": gin.H{"ID": employee.ID, "Name": employee.Name, "CompetentID": employee.StoreID()}})
+}
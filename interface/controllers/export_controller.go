@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+type ExportController struct {
+	summaryService *services.SummaryService
+	payrollService *services.PayrollService
+}
+
+func NewExportController(summaryService *services.SummaryService, payrollService *services.PayrollService) *ExportController {
+	return &ExportController{summaryService: summaryService, payrollService: payrollService}
+}
+
+// パスの年・月を取得
+func exportMonth(c *gin.Context) (int, int, bool) {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year format"})
+		return 0, 0, false
+	}
+	month, err := strconv.Atoi(c.Param("month"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format"})
+		return 0, 0, false
+	}
+	return year, month, true
+}
+
+// 勤怠サマリをCSVで返す（encoding=sjis でShift_JIS、指定がなければBOM付きUTF-8）
+func respondSummaryCSV(c *gin.Context, filename string, summaries []services.EmployeeSummary) {
+	enc, err := services.ParseCSVEncoding(c.Query("encoding"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := services.WriteSummaryCSV(&buf, summaries, enc); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if enc == services.CSVEncodingShiftJIS {
+		contentType = "text/csv; charset=Shift_JIS"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// 従業員の月次の勤怠サマリをCSVで出力
+func (ec *ExportController) GetEmployeeSummaryCSV(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("employeeId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return
+	}
+	year, month, ok := exportMonth(c)
+	if !ok {
+		return
+	}
+
+	summary, err := ec.summaryService.GetEmployeeAttendance(currentActor(c), uint(employeeID), year, month)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("attendance_%d_%04d%02d.csv", employeeID, year, month)
+	respondSummaryCSV(c, filename, []services.EmployeeSummary{*summary})
+}
+
+// 店舗の従業員全員の月次の勤怠サマリをCSVで出力
+func (ec *ExportController) GetStoreSummaryCSV(c *gin.Context) {
+	storeID, err := strconv.ParseUint(c.Param("storeID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid store ID"})
+		return
+	}
+	year, month, ok := exportMonth(c)
+	if !ok {
+		return
+	}
+
+	summary, err := ec.summaryService.GetStoreAttendance(currentActor(c), uint(storeID), year, month)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("attendance_store%d_%04d%02d.csv", storeID, year, month)
+	respondSummaryCSV(c, filename, summary.Employees)
+}
+
+// 店舗の月次の勤務表をExcel形式で出力（集計シートと従業員ごとのシート）
+func (ec *ExportController) GetStoreTimesheetXLSX(c *gin.Context) {
+	storeID, err := strconv.ParseUint(c.Param("storeID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid store ID"})
+		return
+	}
+	year, month, ok := exportMonth(c)
+	if !ok {
+		return
+	}
+
+	actor := currentActor(c)
+	summary, err := ec.summaryService.GetStoreAttendance(actor, uint(storeID), year, month)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	payrolls, err := ec.payrollService.GetStorePayrolls(actor, uint(storeID), year, month)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	var buf bytes.Buffer
+	title := fmt.Sprintf("%s %d年%d月 勤務表", summary.Store.Name, year, month)
+	if err := services.WriteTimesheetXLSX(&buf, title, summary.Employees, payrolls); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("timesheet_store%d_%04d%02d.xlsx", storeID, year, month)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", buf.Bytes())
+}
+
+// 従業員の給与明細をPDFで出力
+func (ec *ExportController) GetPayslipPDF(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("employeeId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return
+	}
+	year, month, ok := exportMonth(c)
+	if !ok {
+		return
+	}
+
+	payslip, err := ec.payrollService.GetPayslip(currentActor(c), uint(employeeID), year, month)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := services.WritePayslipPDF(&buf, *payslip); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, services.PayslipFilename(*payslip)))
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// 店舗の従業員の給与明細をPDFにしてzipでまとめて出力
+func (ec *ExportController) GetStorePayslipsZip(c *gin.Context) {
+	storeID, err := strconv.ParseUint(c.Param("storeID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid store ID"})
+		return
+	}
+	year, month, ok := exportMonth(c)
+	if !ok {
+		return
+	}
+
+	payslips, err := ec.payrollService.GetStorePayslips(currentActor(c), uint(storeID), year, month)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := services.WritePayslipsZip(&buf, payslips); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("payslips_store%d_%04d%02d.zip", storeID, year, month)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
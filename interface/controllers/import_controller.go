@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+type ImportController struct {
+	importService *services.ImportService
+}
+
+func NewImportController(importService *services.ImportService) *ImportController {
+	return &ImportController{importService: importService}
+}
+
+// 過去の勤怠をCSVから取り込む
+// multipart の file 項目、またはリクエストボディのCSVを受け付ける
+// dry_run=true の場合は検証のみ行い、エラーがあれば行ごとのエラーを 422 で返す
+func (ic *ImportController) PostAttendanceCSV(c *gin.Context) {
+	enc, err := services.ParseCSVEncoding(c.Query("encoding"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dry_run"})
+			return
+		}
+	}
+
+	var body io.Reader = c.Request.Body
+	if file, err := c.FormFile("file"); err == nil {
+		opened, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		defer opened.Close()
+		body = opened
+	}
+
+	result, err := ic.importService.ImportAttendanceCSV(currentActor(c), body, enc, dryRun)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	if len(result.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+type PayrollController struct {
+	service *services.PayrollService
+}
+
+func NewPayrollController(service *services.PayrollService) *PayrollController {
+	return &PayrollController{service: service}
+}
+
+// パスの従業員ID・年・月を取得
+func payrollPeriod(c *gin.Context) (uint, int, int, bool) {
+	employeeID, err := strconv.ParseUint(c.Param("employeeId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return 0, 0, 0, false
+	}
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year format"})
+		return 0, 0, 0, false
+	}
+	month, err := strconv.Atoi(c.Param("month"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format"})
+		return 0, 0, 0, false
+	}
+	return uint(employeeID), year, month, true
+}
+
+// 最新の給与計算の結果を取得
+func (pc *PayrollController) GetPayroll(c *gin.Context) {
+	employeeID, year, month, ok := payrollPeriod(c)
+	if !ok {
+		return
+	}
+
+	run, err := pc.service.GetPayroll(currentActor(c), employeeID, year, month)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// 給与を計算して保存（手当・控除は任意）
+func (pc *PayrollController) PostPayroll(c *gin.Context) {
+	employeeID, year, month, ok := payrollPeriod(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Allowances int `json:"allowances"`
+		Deductions []struct {
+			Name   string `json:"name"`
+			Amount int    `json:"amount"`
+		} `json:"deductions"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	deductions := make([]model.PayrollDeduction, 0, len(req.Deductions))
+	for _, deduction := range req.Deductions {
+		deductions = append(deductions, model.PayrollDeduction{Name: deduction.Name, Amount: deduction.Amount})
+	}
+
+	run, err := pc.service.RunPayroll(currentActor(c), employeeID, year, month, req.Allowances, deductions)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
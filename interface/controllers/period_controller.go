@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+type PeriodController struct {
+	service *services.PeriodService
+}
+
+func NewPeriodController(service *services.PeriodService) *PeriodController {
+	return &PeriodController{service: service}
+}
+
+// パスの店舗ID・年・月を取得
+func closingPeriod(c *gin.Context) (uint, int, int, bool) {
+	storeID, err := strconv.ParseUint(c.Param("storeID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid store ID"})
+		return 0, 0, 0, false
+	}
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year format"})
+		return 0, 0, 0, false
+	}
+	month, err := strconv.Atoi(c.Param("month"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format"})
+		return 0, 0, 0, false
+	}
+	return uint(storeID), year, month, true
+}
+
+// 締めの履歴を取得（store_id は任意、year の指定がなければ今年）
+func (pc *PeriodController) GetPeriodCloses(c *gin.Context) {
+	var storeID uint64
+	if value := c.Query("store_id"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid store ID"})
+			return
+		}
+		storeID = parsed
+	}
+	year := today().Year()
+	if value := c.Query("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year format"})
+			return
+		}
+		year = parsed
+	}
+
+	periods, err := pc.service.GetPeriodCloses(currentActor(c), uint(storeID), year)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, periods)
+}
+
+// 店舗の月を締める
+func (pc *PeriodController) PostClosePeriod(c *gin.Context) {
+	storeID, year, month, ok := closingPeriod(c)
+	if !ok {
+		return
+	}
+
+	period, err := pc.service.ClosePeriod(currentActor(c), storeID, year, month)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, period)
+}
+
+// 締めた月を再開する（理由は必須）
+func (pc *PeriodController) PostReopenPeriod(c *gin.Context) {
+	storeID, year, month, ok := closingPeriod(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	period, err := pc.service.ReopenPeriod(currentActor(c), storeID, year, month, req.Reason)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, period)
+}
@@ -0,0 +1,310 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+type ShiftController struct {
+	service     *services.ShiftService
+	planService *services.ShiftPlanService
+}
+
+func NewShiftController(service *services.ShiftService, planService *services.ShiftPlanService) *ShiftController {
+	return &ShiftController{service: service, planService: planService}
+}
+
+// シフトの登録・更新リクエスト（時刻は RFC3339 形式）
+type ShiftRequest struct {
+	EmployeeID uint      `json:"employee_id"`
+	StoreID    uint      `json:"store_id"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	Position   string    `json:"position"`
+	Note       string    `json:"note"`
+}
+
+func (r *ShiftRequest) toModel() *model.Shift {
+	return &model.Shift{
+		EmployeeID: r.EmployeeID,
+		StoreID:    r.StoreID,
+		StartTime:  r.StartTime,
+		EndTime:    r.EndTime,
+		Position:   r.Position,
+		Note:       r.Note,
+	}
+}
+
+// YYYY-MM-DD 形式の日付を日本時間の0時として解釈（空の場合は既定値）
+func parseDate(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.FixedZone("Asia/Tokyo", 9*60*60))
+}
+
+// 今日の0時（日本時間）
+func today() time.Time {
+	now := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// クエリの from・to（to の日を含む）を期間に変換
+func dateRange(c *gin.Context, defaultDays int) (time.Time, time.Time, bool) {
+	from, err := parseDate(c.Query("from"), today())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be YYYY-MM-DD"})
+		return time.Time{}, time.Time{}, false
+	}
+	to, err := parseDate(c.Query("to"), from.AddDate(0, 0, defaultDays-1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be YYYY-MM-DD"})
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to.AddDate(0, 0, 1), true
+}
+
+// 店舗のシフト一覧取得（store_id・from・to、期間の指定がなければ今日から1週間）
+func (sc *ShiftController) GetStoreShifts(c *gin.Context) {
+	storeID, err := strconv.ParseUint(c.Query("store_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid store ID"})
+		return
+	}
+	from, to, ok := dateRange(c, 7)
+	if !ok {
+		return
+	}
+
+	shifts, err := sc.service.GetStoreShifts(currentActor(c), uint(storeID), from, to)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, shifts)
+}
+
+// 自分の今後のシフトを取得（期間の指定がなければ今日から2週間）
+func (sc *ShiftController) GetMyShifts(c *gin.Context) {
+	from, to, ok := dateRange(c, 14)
+	if !ok {
+		return
+	}
+
+	shifts, err := sc.service.GetMyShifts(currentActor(c), from, to)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, shifts)
+}
+
+// シフト作成
+func (sc *ShiftController) PostShift(c *gin.Context) {
+	var req ShiftRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	shift := req.toModel()
+	if err := sc.service.CreateShift(currentActor(c), shift); err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, shift)
+}
+
+// シフト更新
+func (sc *ShiftController) PutShift(c *gin.Context) {
+	shiftID, err := strconv.ParseUint(c.Param("shiftID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shift ID"})
+		return
+	}
+
+	var req ShiftRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	shift, err := sc.service.UpdateShift(currentActor(c), uint(shiftID), req.toModel())
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, shift)
+}
+
+// シフト削除
+func (sc *ShiftController) DeleteShift(c *gin.Context) {
+	shiftID, err := strconv.ParseUint(c.Param("shiftID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shift ID"})
+		return
+	}
+
+	if err := sc.service.DeleteShift(currentActor(c), uint(shiftID)); err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "シフトを削除しました"})
+}
+
+// 前週のシフトをコピー
+func (sc *ShiftController) PostCopyShifts(c *gin.Context) {
+	var req struct {
+		StoreID   uint   `json:"store_id"`
+		WeekStart string `json:"week_start"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	weekStart, err := time.Parse("2006-01-02", req.WeekStart)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "week_start must be YYYY-MM-DD"})
+		return
+	}
+
+	result, err := sc.service.CopyFromPreviousWeek(currentActor(c), req.StoreID, weekStart)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// 下書きのシフトを公開（from・to の日を含む）
+func (sc *ShiftController) PostPublishShifts(c *gin.Context) {
+	var req struct {
+		StoreID uint   `json:"store_id"`
+		From    string `json:"from"`
+		To      string `json:"to"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	from, err := parseDate(req.From, today())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be YYYY-MM-DD"})
+		return
+	}
+	to, err := parseDate(req.To, from.AddDate(0, 0, 6))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be YYYY-MM-DD"})
+		return
+	}
+
+	published, err := sc.service.PublishShifts(currentActor(c), req.StoreID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"published": published})
+}
+
+// 必要人数（1件分）のリクエスト
+type StaffingTargetRequest struct {
+	Weekday   int `json:"weekday"`
+	StartHour int `json:"start_hour"`
+	EndHour   int `json:"end_hour"`
+	Headcount int `json:"headcount"`
+}
+
+// 店舗の必要人数を取得（store_id）
+func (sc *ShiftController) GetStaffingTargets(c *gin.Context) {
+	storeID, err := strconv.ParseUint(c.Query("store_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid store ID"})
+		return
+	}
+
+	targets, err := sc.planService.GetStaffingTargets(currentActor(c), uint(storeID))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, targets)
+}
+
+// 店舗の必要人数を登録
+func (sc *ShiftController) PutStaffingTargets(c *gin.Context) {
+	var req struct {
+		StoreID uint                    `json:"store_id"`
+		Targets []StaffingTargetRequest `json:"targets"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	targets := make([]model.StaffingTarget, 0, len(req.Targets))
+	for _, target := range req.Targets {
+		targets = append(targets, model.StaffingTarget{
+			Weekday:   target.Weekday,
+			StartHour: target.StartHour,
+			EndHour:   target.EndHour,
+			Headcount: target.Headcount,
+		})
+	}
+
+	saved, err := sc.planService.UpdateStaffingTargets(currentActor(c), req.StoreID, targets)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// 指定した週のシフトを自動作成（下書きとして作成し、公開はしない）
+// 最大勤務時間・最短の長さは省略すると既定値（1日8時間・週40時間・3時間）を使う
+func (sc *ShiftController) PostGenerateShifts(c *gin.Context) {
+	var req struct {
+		StoreID        uint   `json:"store_id"`
+		WeekStart      string `json:"week_start"`
+		MaxDailyHours  int    `json:"max_daily_hours"`
+		MaxWeeklyHours int    `json:"max_weekly_hours"`
+		MinShiftHours  int    `json:"min_shift_hours"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	weekStart, err := time.Parse("2006-01-02", req.WeekStart)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "week_start must be YYYY-MM-DD"})
+		return
+	}
+
+	plan, err := sc.planService.GenerateShifts(currentActor(c), req.StoreID, weekStart, services.ShiftPlanLimits{
+		MaxDaily:  req.MaxDailyHours,
+		MaxWeekly: req.MaxWeeklyHours,
+		MinShift:  req.MinShiftHours,
+	})
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+type ShiftRequestController struct {
+	service *services.ShiftRequestService
+}
+
+func NewShiftRequestController(service *services.ShiftRequestService) *ShiftRequestController {
+	return &ShiftRequestController{service: service}
+}
+
+// 勤務希望（1件分）のリクエスト
+type AvailabilityRequest struct {
+	Date      string                   `json:"date"`
+	Status    model.AvailabilityStatus `json:"status"`
+	StartTime string                   `json:"start_time"`
+	EndTime   string                   `json:"end_time"`
+	Note      string                   `json:"note"`
+}
+
+// シフト交代の申請リクエスト
+type ShiftSwapRequest struct {
+	Type             model.SwapType `json:"type"`
+	ShiftID          uint           `json:"shift_id"`
+	TargetEmployeeID uint           `json:"target_employee_id"`
+	CounterShiftID   *uint          `json:"counter_shift_id"`
+	Note             string         `json:"note"`
+}
+
+// 自分の勤務希望を取得（期間の指定がなければ今日から2週間）
+func (sc *ShiftRequestController) GetMyAvailabilities(c *gin.Context) {
+	from, to, ok := dateRange(c, 14)
+	if !ok {
+		return
+	}
+
+	availabilities, err := sc.service.GetMyAvailabilities(currentActor(c), from, to)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, availabilities)
+}
+
+// 店舗を担当する従業員の勤務希望を取得（store_id・from・to、期間の指定がなければ今日から1週間）
+func (sc *ShiftRequestController) GetStoreAvailabilities(c *gin.Context) {
+	storeID, err := strconv.ParseUint(c.Query("store_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid store ID"})
+		return
+	}
+	from, to, ok := dateRange(c, 7)
+	if !ok {
+		return
+	}
+
+	availabilities, err := sc.service.GetStoreAvailabilities(currentActor(c), uint(storeID), from, to)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, availabilities)
+}
+
+// 週の勤務希望を提出
+func (sc *ShiftRequestController) PutAvailabilities(c *gin.Context) {
+	var req struct {
+		WeekStart      string                `json:"week_start"`
+		Availabilities []AvailabilityRequest `json:"availabilities"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	weekStart, err := parseDate(req.WeekStart, time.Time{})
+	if err != nil || weekStart.IsZero() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "week_start must be YYYY-MM-DD"})
+		return
+	}
+
+	availabilities := make([]model.Availability, 0, len(req.Availabilities))
+	for _, entry := range req.Availabilities {
+		date, err := parseDate(entry.Date, time.Time{})
+		if err != nil || date.IsZero() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date must be YYYY-MM-DD"})
+			return
+		}
+		availabilities = append(availabilities, model.Availability{
+			Date:      date,
+			Status:    entry.Status,
+			StartTime: entry.StartTime,
+			EndTime:   entry.EndTime,
+			Note:      entry.Note,
+		})
+	}
+
+	saved, err := sc.service.SubmitAvailabilities(currentActor(c), weekStart, availabilities)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// 自分のシフト交代の申請一覧取得
+func (sc *ShiftRequestController) GetMyShiftSwaps(c *gin.Context) {
+	requests, err := sc.service.GetMyShiftSwapRequests(currentActor(c))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// 店舗のシフト交代の申請一覧取得（store_id・status）
+func (sc *ShiftRequestController) GetStoreShiftSwaps(c *gin.Context) {
+	storeID, err := strconv.ParseUint(c.Query("store_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid store ID"})
+		return
+	}
+
+	requests, err := sc.service.GetStoreShiftSwapRequests(currentActor(c), uint(storeID), model.SwapStatus(c.Query("status")))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// シフト交代の申請
+func (sc *ShiftRequestController) PostShiftSwap(c *gin.Context) {
+	var req ShiftSwapRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	request := &model.ShiftSwapRequest{
+		Type:             req.Type,
+		ShiftID:          req.ShiftID,
+		TargetEmployeeID: req.TargetEmployeeID,
+		CounterShiftID:   req.CounterShiftID,
+		Note:             req.Note,
+	}
+	if err := sc.service.RequestShiftSwap(currentActor(c), request); err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// シフト交代の申請の取り下げ
+func (sc *ShiftRequestController) PostCancelShiftSwap(c *gin.Context) {
+	requestID, err := strconv.ParseUint(c.Param("requestID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+
+	request, err := sc.service.CancelShiftSwap(currentActor(c), uint(requestID))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// シフト交代の申請の承認
+func (sc *ShiftRequestController) PostApproveShiftSwap(c *gin.Context) {
+	sc.reviewShiftSwap(c, sc.service.ApproveShiftSwap)
+}
+
+// シフト交代の申請の却下
+func (sc *ShiftRequestController) PostRejectShiftSwap(c *gin.Context) {
+	sc.reviewShiftSwap(c, sc.service.RejectShiftSwap)
+}
+
+// 承認・却下の共通処理（コメントは任意）
+func (sc *ShiftRequestController) reviewShiftSwap(c *gin.Context, review func(services.Actor, uint, string) (*model.ShiftSwapRequest, error)) {
+	requestID, err := strconv.ParseUint(c.Param("requestID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+
+	var req struct {
+		Note string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	request, err := review(currentActor(c), uint(requestID), req.Note)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+type StoreController struct {
+	service *services.StoreService
+}
+
+func NewStoreController(service *services.StoreService) *StoreController {
+	return &StoreController{service: service}
+}
+
+// 店舗の登録・更新リクエスト
+type StoreRequest struct {
+	Name                string `json:"name"`
+	Address             string `json:"address"`
+	Prefecture          string `json:"prefecture"`
+	Timezone            string `json:"timezone"`
+	OpenTime            string `json:"open_time"`
+	CloseTime           string `json:"close_time"`
+	DayCutoff           string `json:"day_cutoff"`
+	LegalHoliday        *int   `json:"legal_holiday"`                 // 0: 日曜日〜6: 土曜日（省略した場合は勤務から判定）
+	LateTolerance       *int   `json:"late_tolerance_minutes"`        // 省略した場合は既定値
+	EarlyLeaveTolerance *int   `json:"early_leave_tolerance_minutes"` // 省略した場合は既定値
+}
+
+func (r *StoreRequest) toModel() *model.Store {
+	return &model.Store{
+		Name:                r.Name,
+		Address:             r.Address,
+		Prefecture:          r.Prefecture,
+		Timezone:            r.Timezone,
+		OpenTime:            r.OpenTime,
+		CloseTime:           r.CloseTime,
+		DayCutoff:           r.DayCutoff,
+		LegalHoliday:        r.LegalHoliday,
+		LateTolerance:       r.LateTolerance,
+		EarlyLeaveTolerance: r.EarlyLeaveTolerance,
+	}
+}
+
+// 店舗一覧取得（include_archived=true でアーカイブ済みも含める）
+func (sc *StoreController) GetStores(c *gin.Context) {
+	includeArchived := c.Query("include_archived") == "true"
+
+	stores, err := sc.service.GetStores(includeArchived)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stores)
+}
+
+// 店舗作成
+func (sc *StoreController) PostStore(c *gin.Context) {
+	var req StoreRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	store := req.toModel()
+	if err := sc.service.CreateStore(store); err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, store)
+}
+
+// 店舗更新
+func (sc *StoreController) PutStore(c *gin.Context) {
+	storeID, err := strconv.ParseUint(c.Param("storeID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid store ID"})
+		return
+	}
+
+	var req StoreRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	store, err := sc.service.UpdateStore(uint(storeID), req.toModel())
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, store)
+}
+
+// 店舗アーカイブ
+func (sc *StoreController) PostArchiveStore(c *gin.Context) {
+	storeID, err := strconv.ParseUint(c.Param("storeID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid store ID"})
+		return
+	}
+
+	store, err := sc.service.ArchiveStore(uint(storeID))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, store)
+}
@@ -5,16 +5,16 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	model "github.com/techyoichiro/jobreco-api/domain/models"
 	"github.com/techyoichiro/jobreco-api/usecase/services"
 )
 
 type SummaryController struct {
-	service *services.SummaryService
+	service               *services.SummaryService
+	reconciliationService *services.ReconciliationService
 }
 
-func NewSummaryController(service *services.SummaryService) *SummaryController {
-	return &SummaryController{service: service}
+func NewSummaryController(service *services.SummaryService, reconciliationService *services.ReconciliationService) *SummaryController {
+	return &SummaryController{service: service, reconciliationService: reconciliationService}
 }
 
 // 返却用
@@ -32,9 +32,9 @@ type UpdateSummaryRequest struct {
 
 // 全従業員のIDと名前を取得するハンドラー
 func (sc *SummaryController) GetAllEmployee(c *gin.Context) {
-	employees, err := sc.service.GetAllEmployee()
+	employees, err := sc.service.GetAllEmployee(currentActor(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -45,7 +45,7 @@ func (sc *SummaryController) GetAllEmployee(c *gin.Context) {
 			ID:          employee.ID,
 			Name:        employee.Name,
 			HourlyPay:   employee.HourlyPay,
-			CompetentID: employee.CompetentStoreID,
+			CompetentID: int(employee.StoreID()),
 		})
 	}
 
@@ -77,9 +77,9 @@ func (sc *SummaryController) GetAttendance(c *gin.Context) {
 		return
 	}
 
-	response, err := sc.service.GetAttendance(uint(employeeID), year, month)
+	response, err := sc.service.GetAttendance(currentActor(c), uint(employeeID), year, month)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -100,9 +100,9 @@ func (sc *SummaryController) GetAttendanceByID(c *gin.Context) {
 	attendanceIDUint := uint(attendanceID)
 
 	// サービスメソッド呼び出し
-	attendance, err := sc.service.GetAttendanceByID(attendanceIDUint)
+	attendance, err := sc.service.GetAttendanceByID(currentActor(c), attendanceIDUint)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -114,19 +114,31 @@ func (sc *SummaryController) GetAttendanceByID(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// セグメントIDで指定された勤怠情報を更新するハンドラー
-func (sc *SummaryController) UpdateAttendance(c *gin.Context) {
-	var request *model.AttendanceResponse
-	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+// 指定した従業員・月のシフトと実績の突き合わせ結果を取得するハンドラー
+func (sc *SummaryController) GetReconciliation(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("employeeId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return
+	}
+
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year format"})
+		return
+	}
+
+	month, err := strconv.Atoi(c.Param("month"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format"})
 		return
 	}
 
-	err := sc.service.UpdateAttendance(request)
+	days, err := sc.reconciliationService.GetReconciliation(currentActor(c), uint(employeeID), year, month)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Work segment updated successfully"})
+	c.JSON(http.StatusOK, days)
 }
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+type WageController struct {
+	service *services.WageService
+}
+
+func NewWageController(service *services.WageService) *WageController {
+	return &WageController{service: service}
+}
+
+// 時給の履歴を取得
+func (wc *WageController) GetWageHistories(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("employeeId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return
+	}
+
+	histories, err := wc.service.GetWageHistories(currentActor(c), uint(employeeID))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, histories)
+}
+
+// 時給を変更（適用開始日の指定がなければ今日から）
+func (wc *WageController) PostWage(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("employeeId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return
+	}
+
+	var req struct {
+		HourlyPay     int    `json:"hourly_pay"`
+		EffectiveFrom string `json:"effective_from"`
+		Reason        string `json:"reason"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	effectiveFrom := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
+	if req.EffectiveFrom != "" {
+		effectiveFrom, err = time.Parse("2006-01-02", req.EffectiveFrom)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "effective_from must be YYYY-MM-DD"})
+			return
+		}
+	}
+
+	history, err := wc.service.ChangeHourlyPay(currentActor(c), uint(employeeID), req.HourlyPay, effectiveFrom, req.Reason)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
@@ -0,0 +1,255 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/usecase/services"
+)
+
+type WageRuleController struct {
+	service *services.WageRuleService
+}
+
+func NewWageRuleController(service *services.WageRuleService) *WageRuleController {
+	return &WageRuleController{service: service}
+}
+
+// 賃金ルールの登録・更新リクエスト
+type WageRuleRequest struct {
+	EmployeeID    *uint         `json:"employee_id"`
+	StoreID       *uint         `json:"store_id"`
+	DayType       model.DayType `json:"day_type"`
+	StartTime     string        `json:"start_time"`
+	EndTime       string        `json:"end_time"`
+	HourlyPay     int           `json:"hourly_pay"`
+	Addition      int           `json:"addition"`
+	Note          string        `json:"note"`
+	EffectiveFrom string        `json:"effective_from"` // YYYY-MM-DD（省略した場合は今日）
+	EffectiveTo   string        `json:"effective_to"`   // YYYY-MM-DD（省略した場合は終了日なし）
+}
+
+func (r *WageRuleRequest) toModel() (*model.WageRule, error) {
+	rule := &model.WageRule{
+		EmployeeID: r.EmployeeID,
+		StoreID:    r.StoreID,
+		DayType:    r.DayType,
+		StartTime:  r.StartTime,
+		EndTime:    r.EndTime,
+		HourlyPay:  r.HourlyPay,
+		Addition:   r.Addition,
+		Note:       r.Note,
+	}
+	if r.EffectiveFrom != "" {
+		effectiveFrom, err := time.Parse("2006-01-02", r.EffectiveFrom)
+		if err != nil {
+			return nil, errors.New("effective_from must be YYYY-MM-DD")
+		}
+		rule.EffectiveFrom = effectiveFrom
+	}
+	if r.EffectiveTo != "" {
+		effectiveTo, err := time.Parse("2006-01-02", r.EffectiveTo)
+		if err != nil {
+			return nil, errors.New("effective_to must be YYYY-MM-DD")
+		}
+		rule.EffectiveTo = &effectiveTo
+	}
+	return rule, nil
+}
+
+// 賃金ルール一覧取得
+func (wc *WageRuleController) GetWageRules(c *gin.Context) {
+	rules, err := wc.service.GetWageRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// 賃金ルール作成
+func (wc *WageRuleController) PostWageRule(c *gin.Context) {
+	var req WageRuleRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	rule, err := req.toModel()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := wc.service.CreateWageRule(rule); err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// 賃金ルール更新
+func (wc *WageRuleController) PutWageRule(c *gin.Context) {
+	ruleID, err := strconv.ParseUint(c.Param("ruleID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wage rule ID"})
+		return
+	}
+
+	var req WageRuleRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	input, err := req.toModel()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	rule, err := wc.service.UpdateWageRule(uint(ruleID), input)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// 賃金ルール削除
+func (wc *WageRuleController) DeleteWageRule(c *gin.Context) {
+	ruleID, err := strconv.ParseUint(c.Param("ruleID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wage rule ID"})
+		return
+	}
+
+	if err := wc.service.DeleteWageRule(uint(ruleID)); err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "賃金ルールを削除しました"})
+}
+
+// 祝日一覧取得（year の指定がなければ今年）
+func (wc *WageRuleController) GetHolidays(c *gin.Context) {
+	year := time.Now().Year()
+	if yearStr := c.Query("year"); yearStr != "" {
+		parsed, err := strconv.Atoi(yearStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year format"})
+			return
+		}
+		year = parsed
+	}
+
+	holidays, err := wc.service.GetHolidays(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, holidays)
+}
+
+// 祝日登録
+func (wc *WageRuleController) PostHoliday(c *gin.Context) {
+	var req struct {
+		Date string `json:"date"`
+		Name string `json:"name"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date must be YYYY-MM-DD"})
+		return
+	}
+
+	holiday, err := wc.service.CreateHoliday(date, req.Name)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, holiday)
+}
+
+// 祝日削除
+func (wc *WageRuleController) DeleteHoliday(c *gin.Context) {
+	holidayID, err := strconv.ParseUint(c.Param("holidayID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid holiday ID"})
+		return
+	}
+
+	if err := wc.service.DeleteHoliday(uint(holidayID)); err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "祝日を削除しました"})
+}
+
+// 最低賃金一覧取得（prefecture で都道府県を絞り込める）
+func (wc *WageRuleController) GetMinimumWages(c *gin.Context) {
+	wages, err := wc.service.GetMinimumWages(c.Query("prefecture"))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wages)
+}
+
+// 最低賃金登録
+func (wc *WageRuleController) PostMinimumWage(c *gin.Context) {
+	var req struct {
+		Prefecture    string `json:"prefecture"`
+		EffectiveFrom string `json:"effective_from"`
+		HourlyWage    int    `json:"hourly_wage"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	effectiveFrom, err := time.Parse("2006-01-02", req.EffectiveFrom)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "effective_from must be YYYY-MM-DD"})
+		return
+	}
+
+	wage, err := wc.service.CreateMinimumWage(req.Prefecture, effectiveFrom, req.HourlyWage)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wage)
+}
+
+// 最低賃金削除
+func (wc *WageRuleController) DeleteMinimumWage(c *gin.Context) {
+	wageID, err := strconv.ParseUint(c.Param("minimumWageID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid minimum wage ID"})
+		return
+	}
+
+	if err := wc.service.DeleteMinimumWage(uint(wageID)); err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "最低賃金を削除しました"})
+}
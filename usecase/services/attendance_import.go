@@ -0,0 +1,271 @@
+package services
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// 勤怠の取り込みで必須の列
+var importRequiredColumns = []string{"employee_id", "work_date", "store_id", "start_time", "end_time"}
+
+// 取り込むCSVの1行（勤務区間1件と、任意で休憩1件）
+type importRow struct {
+	Line       int
+	EmployeeID uint
+	WorkDate   time.Time
+	StoreID    uint
+	StartTime  string
+	EndTime    string
+	BreakType  model.BreakType
+	BreakStart string
+	BreakEnd   string
+}
+
+// 取り込みの行ごとのエラー
+type ImportLineError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// 取り込みの結果（エラーが1件でもあれば何も保存しない）
+type ImportResult struct {
+	DryRun    bool              `json:"dry_run"`
+	Created   int               `json:"created"`   // 作成する勤怠の件数
+	Updated   int               `json:"updated"`   // 置き換える勤怠の件数
+	Unchanged int               `json:"unchanged"` // 既存の勤怠と同じ内容の件数
+	Errors    []ImportLineError `json:"errors"`
+}
+
+// 行番号を付けたエラーを追加
+func (r *ImportResult) addError(line int, err error) {
+	r.Errors = append(r.Errors, ImportLineError{Line: line, Message: err.Error()})
+}
+
+// 指定した文字コードのCSVをUTF-8として読み込む（UTF-8の場合はBOMを除く）
+func decodeCSV(r io.Reader, enc CSVEncoding) io.Reader {
+	if enc == CSVEncodingShiftJIS {
+		return transform.NewReader(r, japanese.ShiftJIS.NewDecoder())
+	}
+	reader := bufio.NewReader(r)
+	if bom, err := reader.Peek(3); err == nil && string(bom) == "\ufeff" {
+		reader.Discard(3)
+	}
+	return reader
+}
+
+// 見出しの列名と位置の対応（必須の列がない場合はエラー）
+func importColumns(header []string, required []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("見出しに %s の列がありません", name)
+		}
+	}
+	return columns, nil
+}
+
+// 取り込むCSVを1行ずつ読み込む
+// 見出しの誤りは1行目、形式が不正な行や handle が返したエラーはその行のエラーとして addError に渡す
+func readImportCSV(r io.Reader, required []string, addError func(int, error), handle func(line int, value func(string) string) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		addError(1, errors.New("CSVが空です"))
+		return nil
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		addError(parseErr.Line, parseErr.Err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	columns, err := importColumns(header, required)
+	if err != nil {
+		addError(1, err)
+		return nil
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			if errors.As(err, &parseErr) {
+				addError(parseErr.Line, parseErr.Err)
+				continue
+			}
+			return err
+		}
+
+		value := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if err := handle(line, value); err != nil {
+			addError(line, err)
+		}
+	}
+}
+
+// 勤怠のCSVを読み込む（形式が不正な行は行ごとのエラーとして result に追加する）
+func parseImportCSV(r io.Reader, result *ImportResult) ([]importRow, error) {
+	rows := []importRow{}
+	err := readImportCSV(r, importRequiredColumns, result.addError, func(line int, value func(string) string) error {
+		row, err := parseImportRow(value)
+		if err != nil {
+			return err
+		}
+		row.Line = line
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// 1行分の値を検証して変換
+func parseImportRow(value func(string) string) (importRow, error) {
+	var row importRow
+
+	employeeID, err := strconv.ParseUint(value("employee_id"), 10, 32)
+	if err != nil {
+		return row, fmt.Errorf("従業員IDが不正です: %s", value("employee_id"))
+	}
+	row.EmployeeID = uint(employeeID)
+
+	if row.WorkDate, err = parseImportDate(value("work_date")); err != nil {
+		return row, err
+	}
+
+	storeID, err := strconv.ParseUint(value("store_id"), 10, 32)
+	if err != nil {
+		return row, fmt.Errorf("店舗IDが不正です: %s", value("store_id"))
+	}
+	row.StoreID = uint(storeID)
+
+	row.StartTime, row.EndTime = value("start_time"), value("end_time")
+	if row.StartTime == "" || row.EndTime == "" {
+		return row, errors.New("開始時間と終了時間を入力してください")
+	}
+
+	row.BreakStart, row.BreakEnd = value("break_start"), value("break_end")
+	if (row.BreakStart == "") != (row.BreakEnd == "") {
+		return row, errors.New("休憩は開始時間と終了時間の両方を入力してください")
+	}
+	if row.BreakStart != "" {
+		row.BreakType = model.BreakType(value("break_type"))
+		if row.BreakType == "" {
+			row.BreakType = model.BreakTypeRest
+		}
+		if !row.BreakType.IsValid() {
+			return row, fmt.Errorf("休憩の種類が不正です: %s", row.BreakType)
+		}
+	}
+
+	for _, hhmm := range []string{row.StartTime, row.EndTime, row.BreakStart, row.BreakEnd} {
+		if hhmm == "" {
+			continue
+		}
+		if _, err := time.Parse("15:04", hhmm); err != nil {
+			return row, fmt.Errorf("時刻はHH:MM形式で入力してください: %s", hhmm)
+		}
+	}
+	return row, nil
+}
+
+// 勤務日（YYYY-MM-DD または YYYY/M/D）
+func parseImportDate(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "2006/1/2"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("勤務日はYYYY-MM-DD形式で入力してください: %s", value)
+}
+
+// 従業員・勤務日ごとにまとめた行
+type importGroup struct {
+	EmployeeID uint
+	WorkDate   time.Time
+	Rows       []importRow
+}
+
+// 従業員・勤務日ごとに行をまとめる（最初に出てきた順）
+func groupImportRows(rows []importRow) []*importGroup {
+	groups := []*importGroup{}
+	byKey := map[string]*importGroup{}
+	for _, row := range rows {
+		key := fmt.Sprintf("%d/%s", row.EmployeeID, row.WorkDate.Format("2006-01-02"))
+		group, ok := byKey[key]
+		if !ok {
+			group = &importGroup{EmployeeID: row.EmployeeID, WorkDate: row.WorkDate}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.Rows = append(group.Rows, row)
+	}
+	return groups
+}
+
+// まとめた行を勤怠の編集内容に変換（同じ勤務日の行は時刻順に記載されている前提）
+func (g *importGroup) edit() *model.AttendanceResponse {
+	edit := &model.AttendanceResponse{}
+	for _, row := range g.Rows {
+		edit.Segments = append(edit.Segments, model.WorkSegmentResponse{StoreID: row.StoreID, StartTime: row.StartTime, EndTime: row.EndTime})
+		if row.BreakStart != "" {
+			edit.Breaks = append(edit.Breaks, model.AttendanceBreakResponse{BreakType: row.BreakType, StartTime: row.BreakStart, EndTime: row.BreakEnd})
+		}
+	}
+	return edit
+}
+
+// 勤怠の開始から終了までの時間と、休憩が勤務の時間内かを検証
+func validateImportedAttendance(attendance *model.Attendance, maxShift time.Duration) error {
+	start, end := attendanceSpan(attendance)
+	if end.Sub(start) > maxShift {
+		return fmt.Errorf("勤務の開始から終了までが%.0f時間を超えています。時刻の順序を確認してください", maxShift.Hours())
+	}
+	for _, breakRecord := range attendance.Breaks {
+		if breakRecord.StartTime.Before(start) || breakRecord.EndTime == nil || breakRecord.EndTime.After(end) {
+			return errors.New("休憩が勤務の時間外です")
+		}
+	}
+	return nil
+}
+
+// 勤怠の最初の勤務区間の開始と最後の勤務区間の終了（終了していない場合は開始）
+func attendanceSpan(attendance *model.Attendance) (time.Time, time.Time) {
+	start := attendance.Segments[0].StartTime
+	end := start
+	for _, segment := range attendance.Segments {
+		if segment.EndTime != nil && segment.EndTime.After(end) {
+			end = *segment.EndTime
+		} else if segment.StartTime.After(end) {
+			end = segment.StartTime
+		}
+	}
+	return start, end
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	header := "employee_id,work_date,store_id,start_time,end_time,break_start,break_end,break_type\n"
+
+	tests := []struct {
+		name       string
+		csv        string
+		wantRows   int
+		wantErrors []ImportLineError
+	}{
+		{"正常", header + "1,2024-03-01,1,09:00,18:00,12:00,13:00,meal\n1,2024/3/2,1,22:00,06:00,,,\n", 2, nil},
+		{"休憩の種類の省略", header + "1,2024-03-01,1,09:00,18:00,12:00,13:00,\n", 1, nil},
+		{"見出しの列の順序と大文字", "Store_ID,Employee_ID,Work_Date,Start_Time,End_Time\n1,2,2024-03-01,09:00,18:00\n", 1, nil},
+		{"必須の列がない", "employee_id,work_date,store_id,start_time\n1,2024-03-01,1,09:00\n", 0, []ImportLineError{
+			{1, "見出しに end_time の列がありません"},
+		}},
+		{"空のCSV", "", 0, []ImportLineError{{1, "CSVが空です"}}},
+		{"行ごとのエラー", header +
+			"x,2024-03-01,1,09:00,18:00,,,\n" +
+			"1,2024-13-01,1,09:00,18:00,,,\n" +
+			"1,2024-03-01,1,09:00,,,,\n" +
+			"1,2024-03-01,1,9時,18:00,,,\n" +
+			"1,2024-03-01,1,09:00,18:00,12:00,,\n" +
+			"1,2024-03-01,1,09:00,18:00,12:00,13:00,nap\n" +
+			"1,2024-03-02,1,09:00,18:00,,,\n", 1, []ImportLineError{
+			{2, "従業員IDが不正です: x"},
+			{3, "勤務日はYYYY-MM-DD形式で入力してください: 2024-13-01"},
+			{4, "開始時間と終了時間を入力してください"},
+			{5, "時刻はHH:MM形式で入力してください: 9時"},
+			{6, "休憩は開始時間と終了時間の両方を入力してください"},
+			{7, "休憩の種類が不正です: nap"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &ImportResult{}
+			rows, err := parseImportCSV(strings.NewReader(tt.csv), result)
+			if err != nil {
+				t.Fatalf("parseImportCSV() error = %v", err)
+			}
+			if len(rows) != tt.wantRows {
+				t.Errorf("parseImportCSV() rows = %d, want %d", len(rows), tt.wantRows)
+			}
+			if !reflect.DeepEqual(result.Errors, tt.wantErrors) {
+				t.Errorf("parseImportCSV() errors = %v, want %v", result.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestDecodeCSV(t *testing.T) {
+	text := "employee_id,work_date,store_id,start_time,end_time,備考\n1,2024-03-01,1,09:00,18:00,早番\n"
+	sjis, _, err := transform.String(japanese.ShiftJIS.NewEncoder(), text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		enc   CSVEncoding
+	}{
+		{"BOM付きUTF-8", "\ufeff" + text, CSVEncodingUTF8},
+		{"BOMなしUTF-8", text, CSVEncodingUTF8},
+		{"Shift_JIS", sjis, CSVEncodingShiftJIS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(decodeCSV(strings.NewReader(tt.input), tt.enc)); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != text {
+				t.Errorf("decodeCSV() = %q, want %q", buf.String(), text)
+			}
+		})
+	}
+}
+
+func TestGroupImportRows(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	rows := []importRow{
+		{Line: 2, EmployeeID: 1, WorkDate: day(1), StoreID: 1, StartTime: "09:00", EndTime: "13:00", BreakType: model.BreakTypeMeal, BreakStart: "12:00", BreakEnd: "12:30"},
+		{Line: 3, EmployeeID: 2, WorkDate: day(1), StoreID: 1, StartTime: "09:00", EndTime: "18:00"},
+		{Line: 4, EmployeeID: 1, WorkDate: day(1), StoreID: 2, StartTime: "14:00", EndTime: "18:00"},
+	}
+
+	groups := groupImportRows(rows)
+	if len(groups) != 2 {
+		t.Fatalf("groupImportRows() = %d groups, want 2", len(groups))
+	}
+	edit := groups[0].edit()
+	wantSegments := []model.WorkSegmentResponse{
+		{StoreID: 1, StartTime: "09:00", EndTime: "13:00"},
+		{StoreID: 2, StartTime: "14:00", EndTime: "18:00"},
+	}
+	if !reflect.DeepEqual(edit.Segments, wantSegments) {
+		t.Errorf("edit().Segments = %v, want %v", edit.Segments, wantSegments)
+	}
+	wantBreaks := []model.AttendanceBreakResponse{{BreakType: model.BreakTypeMeal, StartTime: "12:00", EndTime: "12:30"}}
+	if !reflect.DeepEqual(edit.Breaks, wantBreaks) {
+		t.Errorf("edit().Breaks = %v, want %v", edit.Breaks, wantBreaks)
+	}
+}
+
+func TestValidateImportedAttendance(t *testing.T) {
+	at := func(d, h int) time.Time { return time.Date(2024, 3, d, h, 0, 0, 0, time.UTC) }
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name       string
+		attendance *model.Attendance
+		wantErr    bool
+	}{
+		{"通常の勤務", &model.Attendance{
+			Segments: []model.WorkSegment{{StartTime: at(1, 9), EndTime: ptr(at(1, 18))}},
+			Breaks:   []model.AttendanceBreak{{StartTime: at(1, 12), EndTime: ptr(at(1, 13))}},
+		}, false},
+		{"日付をまたぐ勤務", &model.Attendance{
+			Segments: []model.WorkSegment{{StartTime: at(1, 22), EndTime: ptr(at(2, 6))}},
+		}, false},
+		// 終了が開始より前の時刻は翌日として解釈されるため、長すぎる勤務になる
+		{"時刻の順序の誤り", &model.Attendance{
+			Segments: []model.WorkSegment{{StartTime: at(1, 18), EndTime: ptr(at(2, 9))}},
+		}, true},
+		{"勤務時間外の休憩", &model.Attendance{
+			Segments: []model.WorkSegment{{StartTime: at(1, 9), EndTime: ptr(at(1, 13))}},
+			Breaks:   []model.AttendanceBreak{{StartTime: at(1, 14), EndTime: ptr(at(1, 15))}},
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateImportedAttendance(tt.attendance, 12*time.Hour)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateImportedAttendance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOverlappingLines(t *testing.T) {
+	at := func(d, h int) time.Time { return time.Date(2024, 3, d, h, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name  string
+		spans []importSpan
+		want  map[int]string
+	}{
+		{"重なりなし", []importSpan{
+			{Line: 2, WorkDate: "2024-03-01", Start: at(1, 9), End: at(1, 18)},
+			{Line: 3, WorkDate: "2024-03-02", Start: at(2, 9), End: at(2, 18)},
+			{WorkDate: "2024-03-03", Start: at(3, 9), End: at(3, 18)},
+		}, map[int]string{}},
+		{"前日の夜勤と重なる", []importSpan{
+			{Line: 2, WorkDate: "2024-03-02", Start: at(2, 5), End: at(2, 12)},
+			{WorkDate: "2024-03-01", Start: at(1, 22), End: at(2, 6)},
+		}, map[int]string{2: "2024-03-01"}},
+		{"取り込む勤怠どうしが重なる", []importSpan{
+			{Line: 2, WorkDate: "2024-03-01", Start: at(1, 20), End: at(2, 4)},
+			{Line: 5, WorkDate: "2024-03-02", Start: at(2, 3), End: at(2, 10)},
+		}, map[int]string{2: "2024-03-02", 5: "2024-03-01"}},
+		{"終了と開始が同じ時刻", []importSpan{
+			{Line: 2, WorkDate: "2024-03-01", Start: at(1, 22), End: at(2, 6)},
+			{Line: 3, WorkDate: "2024-03-02", Start: at(2, 6), End: at(2, 12)},
+		}, map[int]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overlappingLines(tt.spans); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("overlappingLines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
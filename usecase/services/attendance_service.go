@@ -3,6 +3,8 @@ package services
 import (
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
 	"time"
 
 	model "github.com/techyoichiro/jobreco-api/domain/models"
@@ -11,121 +13,304 @@ import (
 )
 
 type AttendanceService struct {
-	repo repositories.AttendanceRepository
+	repo       repositories.AttendanceRepository
+	storeRepo  repositories.StoreRepository
+	periodRepo repositories.PeriodRepository
+	empRepo    repositories.EmployeeRepository
+	maxShift   time.Duration // 1回の勤務として扱う最大の長さ
 }
 
-func NewAttendanceService(repo repositories.AttendanceRepository) *AttendanceService {
-	return &AttendanceService{repo: repo}
+func NewAttendanceService(repo repositories.AttendanceRepository, storeRepo repositories.StoreRepository, periodRepo repositories.PeriodRepository, empRepo repositories.EmployeeRepository) *AttendanceService {
+	return &AttendanceService{repo: repo, storeRepo: storeRepo, periodRepo: periodRepo, empRepo: empRepo, maxShift: maxShiftLength()}
 }
 
-// 出勤
-func (s *AttendanceService) ClockIn(employeeID uint, storeID uint) error {
-	now := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
-	workDate := now.Format("2006-01-02")
-
-	// 打刻日の勤怠記録があるか確認
-	attendance, err := s.repo.FindAttendance(employeeID, workDate)
-	// 新規作成
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			attendance = &model.Attendance{
-				EmployeeID: employeeID,
-				WorkDate:   now,
-				StartTime1: &now,
-				StoreID1:   storeID,
-				StatusID:   1, // 出勤
-			}
-			if err := s.repo.CreateAttendance(attendance); err != nil {
-				return err
-			}
-		} else {
-			return err
-		}
+// 1回の勤務として扱う最大の長さ（環境変数 MAX_SHIFT_HOURS で変更可能、既定は16時間）
+func maxShiftLength() time.Duration {
+	if hours, err := strconv.Atoi(os.Getenv("MAX_SHIFT_HOURS")); err == nil && hours > 0 {
+		return time.Duration(hours) * time.Hour
 	}
+	return 16 * time.Hour
+}
 
-	return nil
+// 最新の勤怠と、退勤していない勤務（打刻対象）を取得する
+// 日付をまたぐ勤務にも対応するため、勤務日ではなく未退勤かどうかで判定する
+// 最後の勤務区間の開始から最大の長さを超えている場合は退勤漏れとみなし打刻対象にしない
+func (s *AttendanceService) findOpenAttendance(employeeID uint, now time.Time) (latest *model.Attendance, open *model.Attendance, err error) {
+	latest, err = s.repo.FindLatestAttendance(employeeID)
+	if err != nil || latest == nil {
+		return nil, nil, err
+	}
+	if latest.StatusID == model.StatusClockedOut {
+		return latest, nil, nil
+	}
+	segment := latest.LastSegment()
+	if segment == nil || now.Sub(segment.StartTime) > s.maxShift {
+		return latest, nil, nil
+	}
+	return latest, latest, nil
 }
 
-// 退勤
-func (s *AttendanceService) ClockOut(employeeID uint, storeID uint) error {
-	now := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
-	workDate := now.Format("2006-01-02")
-
-	attendance, err := s.repo.FindAttendance(employeeID, workDate)
-	if err != nil {
-		// 見つからなかった場合は、前日の日付を求めて再検索
-		// (あるいは「最終の未完了レコード」を探す方式に切り替える、など)
-		yesterday := now.AddDate(0, 0, -1).Format("2006-01-02")
-		attendance, err = s.repo.FindAttendance(employeeID, yesterday)
-		if err != nil {
-			return err
-		}
-		if attendance == nil {
-			return fmt.Errorf("退勤対象の勤怠が見つかりません")
+// 勤怠を保存し、打刻として監査ログに記録する（before が nil の場合は新規作成）
+// 締め済みの月の勤怠には打刻できない
+func (s *AttendanceService) saveAttendance(attendance *model.Attendance, before auditSnapshot) error {
+	if err := ensureAttendanceOpen(s.periodRepo, attendance); err != nil {
+		return err
+	}
+
+	// 監査ログの対象のIDは保存時に設定する
+	employeeID := attendance.EmployeeID
+	log, err := buildAudit(model.AuditLog{
+		ActorID:    &employeeID,
+		EmployeeID: employeeID,
+		Entity:     model.AuditEntityAttendance,
+		Source:     model.AuditSourcePunch,
+	}, before, attendance)
+	if err != nil {
+		return err
+	}
+
+	if before == nil {
+		return s.repo.CreateAttendance(attendance, log)
+	}
+	return s.repo.UpdateAttendance(attendance, log)
+}
+
+// 打刻中の勤怠の店舗のタイムゾーンでの打刻時刻
+// 勤怠の表示（attendanceLocation）と同じく最初の勤務区間の店舗を基準にする
+func (s *AttendanceService) punchTime(attendance *model.Attendance, now time.Time) (time.Time, error) {
+	if len(attendance.Segments) == 0 {
+		return now, nil
+	}
+	store, err := findStore(s.storeRepo, attendance.Segments[0].StoreID)
+	if err != nil {
+		return now, err
+	}
+	return now.In(storeLocation(store)), nil
+}
+
+// 勤怠の現在のステータス（勤怠がなければ未出勤）
+func currentStatus(attendance *model.Attendance) model.AttendanceStatus {
+	if attendance == nil {
+		return model.StatusNotStarted
+	}
+	return attendance.StatusID
+}
+
+// 現在のステータスと可能な打刻を取得
+func (s *AttendanceService) GetAllowedActions(employeeID uint) (model.AttendanceStatus, []PunchAction, error) {
+	now := time.Now()
+	latest, open, err := s.findOpenAttendance(employeeID, now)
+	if err != nil {
+		return model.StatusNotStarted, nil, err
+	}
+
+	status := currentStatus(open)
+	// 直近に退勤済みの場合は退勤として表示する
+	if open == nil && latest != nil && latest.StatusID == model.StatusClockedOut {
+		if segment := latest.LastSegment(); segment != nil && segment.EndTime != nil && now.Sub(*segment.EndTime) <= s.maxShift {
+			status = model.StatusClockedOut
 		}
 	}
+	return status, AllowedActions(status), nil
+}
+
+// 出勤
+func (s *AttendanceService) ClockIn(employeeID uint, storeID uint) (model.AttendanceStatus, error) {
+	if _, err := findActiveEmployee(s.empRepo, employeeID); err != nil {
+		return model.StatusNotStarted, err
+	}
+	store, err := findActiveStore(s.storeRepo, storeID)
+	if err != nil {
+		return model.StatusNotStarted, err
+	}
+	now := time.Now().In(storeLocation(store))
 
-	// リクエストのstoreIDと最新の勤怠記録のStoreIDが異なり、かつStatusIDが3以外の場合にエラーを返す
-	if attendance.StartTime2 == nil {
-		if attendance.StoreID1 != storeID && attendance.StatusID != 3 {
-			return fmt.Errorf("打刻する店舗が違います。")
-		} else {
-			attendance.EndTime1 = &now
-			attendance.StatusID = 3 // 退勤
+	// 退勤していない勤務がある場合は出勤できない
+	_, open, err := s.findOpenAttendance(employeeID, now)
+	if err != nil {
+		return model.StatusNotStarted, err
+	}
+	next, err := NextStatus(currentStatus(open), ActionClockIn)
+	if err != nil {
+		return currentStatus(open), err
+	}
+
+	// 店舗の営業日の切り替え時刻を基準に勤務日を決め、その日の勤怠記録があるか確認
+	workDate := businessDate(store, now)
+	attendance, err := s.repo.FindAttendance(employeeID, workDate.Format("2006-01-02"))
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.StatusNotStarted, err
 		}
-	} else {
-		if *attendance.StoreID2 != storeID && attendance.StatusID != 3 {
-			return fmt.Errorf("打刻する店舗が違います。")
-		} else {
-			attendance.EndTime2 = &now
-			attendance.StatusID = 3 // 退勤
+
+		// 新規作成
+		attendance = &model.Attendance{
+			EmployeeID: employeeID,
+			WorkDate:   workDate,
+			StatusID:   next,
+			Segments: []model.WorkSegment{
+				{StoreID: storeID, StartTime: now},
+			},
 		}
+		return next, s.saveAttendance(attendance, nil)
+	}
+
+	// 同じ勤務日に退勤漏れの勤怠が残っている場合は修正が必要
+	if attendance.StatusID != model.StatusClockedOut {
+		return attendance.StatusID, errors.New("前回の勤務が退勤されていません。勤怠の修正を依頼してください")
 	}
 
-	return s.repo.UpdateAttendance(attendance)
+	before, err := snapshotOf(attendance)
+	if err != nil {
+		return attendance.StatusID, err
+	}
+
+	// 退勤後の再出勤は新しい勤務区間を追加
+	attendance.StatusID = next
+	attendance.Segments = append(attendance.Segments, model.WorkSegment{
+		AttendanceID: attendance.ID,
+		StoreID:      storeID,
+		StartTime:    now,
+	})
+	return next, s.saveAttendance(attendance, before)
 }
 
-// 外出
-func (s *AttendanceService) GoOut(employeeID uint, storeID uint) error {
-	now := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
-	workDate := now.Format("2006-01-02")
+// 退勤
+func (s *AttendanceService) ClockOut(employeeID uint, storeID uint) (model.AttendanceStatus, error) {
+	if _, err := findActiveEmployee(s.empRepo, employeeID); err != nil {
+		return model.StatusNotStarted, err
+	}
+	now := time.Now()
 
-	attendance, err := s.repo.FindAttendance(employeeID, workDate)
+	_, attendance, err := s.findOpenAttendance(employeeID, now)
 	if err != nil {
-		return err
+		return model.StatusNotStarted, err
+	}
+
+	next, err := NextStatus(currentStatus(attendance), ActionClockOut)
+	if err != nil {
+		return currentStatus(attendance), err
+	}
+	if now, err = s.punchTime(attendance, now); err != nil {
+		return attendance.StatusID, err
+	}
+
+	before, err := snapshotOf(attendance)
+	if err != nil {
+		return attendance.StatusID, err
 	}
 
-	// StoreID が異なる場合にエラーを返す
-	if attendance.StoreID1 != storeID {
-		return fmt.Errorf("打刻する店舗が違います。")
+	segment := attendance.LastSegment()
+	if segment == nil {
+		return attendance.StatusID, fmt.Errorf("退勤対象の勤務区間が見つかりません")
 	}
 
-	attendance.BreakStart = &now
-	attendance.StatusID = 2 // 外出
-	return s.repo.UpdateAttendance(attendance)
+	// リクエストのstoreIDと最新の勤務区間のStoreIDが異なる場合にエラーを返す
+	if segment.StoreID != storeID {
+		return attendance.StatusID, fmt.Errorf("打刻する店舗が違います。")
+	}
+	segment.EndTime = &now
+	attendance.StatusID = next
+
+	return next, s.saveAttendance(attendance, before)
+}
+
+// 外出（休憩を追加する）
+func (s *AttendanceService) GoOut(employeeID uint, storeID uint, breakType model.BreakType) (model.AttendanceStatus, error) {
+	if _, err := findActiveEmployee(s.empRepo, employeeID); err != nil {
+		return model.StatusNotStarted, err
+	}
+	if breakType == "" {
+		breakType = model.BreakTypeErrand
+	}
+	if !breakType.IsValid() {
+		return model.StatusNotStarted, fmt.Errorf("休憩の種類が不正です: %s", breakType)
+	}
+
+	now := time.Now()
+
+	_, attendance, err := s.findOpenAttendance(employeeID, now)
+	if err != nil {
+		return model.StatusNotStarted, err
+	}
+
+	next, err := NextStatus(currentStatus(attendance), ActionGoOut)
+	if err != nil {
+		return currentStatus(attendance), err
+	}
+	if now, err = s.punchTime(attendance, now); err != nil {
+		return attendance.StatusID, err
+	}
+
+	// 勤務中の区間と StoreID が異なる場合にエラーを返す
+	segment := attendance.LastSegment()
+	if segment == nil || segment.StoreID != storeID {
+		return attendance.StatusID, fmt.Errorf("打刻する店舗が違います。")
+	}
+
+	before, err := snapshotOf(attendance)
+	if err != nil {
+		return attendance.StatusID, err
+	}
+
+	attendance.Breaks = append(attendance.Breaks, model.AttendanceBreak{
+		AttendanceID: attendance.ID,
+		BreakType:    breakType,
+		StartTime:    now,
+	})
+	attendance.StatusID = next
+	return next, s.saveAttendance(attendance, before)
 }
 
 // 戻り
-func (s *AttendanceService) Return(employeeID uint, storeID uint) error {
-	now := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
-	workDate := now.Format("2006-01-02")
+func (s *AttendanceService) Return(employeeID uint, storeID uint) (model.AttendanceStatus, error) {
+	if _, err := findActiveEmployee(s.empRepo, employeeID); err != nil {
+		return model.StatusNotStarted, err
+	}
+	now := time.Now()
 
-	attendance, err := s.repo.FindAttendance(employeeID, workDate)
+	_, attendance, err := s.findOpenAttendance(employeeID, now)
 	if err != nil {
-		return err
+		return model.StatusNotStarted, err
 	}
 
-	attendance.StatusID = 4 // 休憩戻り
-	attendance.BreakEnd = &now
+	next, err := NextStatus(currentStatus(attendance), ActionReturn)
+	if err != nil {
+		return currentStatus(attendance), err
+	}
+	if now, err = s.punchTime(attendance, now); err != nil {
+		return attendance.StatusID, err
+	}
+
+	before, err := snapshotOf(attendance)
+	if err != nil {
+		return attendance.StatusID, err
+	}
 
-	// 外出時と同じ店舗に戻る場合
-	if attendance.StoreID1 == storeID {
-		return s.repo.UpdateAttendance(attendance)
-		// 外出時と別の店舗に戻る場合
-	} else {
-		attendance.EndTime1 = attendance.BreakStart
-		attendance.StartTime2 = &now
-		attendance.StoreID2 = &storeID
-		return s.repo.UpdateAttendance(attendance)
+	// 外出中の休憩を終了する
+	breakRecord := attendance.OpenBreak()
+	if breakRecord == nil {
+		return attendance.StatusID, fmt.Errorf("戻り対象の休憩が見つかりません")
 	}
+	segment := attendance.LastSegment()
+	if segment == nil {
+		return attendance.StatusID, fmt.Errorf("戻り対象の勤務区間が見つかりません")
+	}
+
+	// 外出時と別の店舗に戻る場合は、外出時刻で区間を閉じて新しい区間を開始する
+	if segment.StoreID != storeID {
+		if _, err := findActiveStore(s.storeRepo, storeID); err != nil {
+			return attendance.StatusID, err
+		}
+		segment.EndTime = &breakRecord.StartTime
+		attendance.Segments = append(attendance.Segments, model.WorkSegment{
+			AttendanceID: attendance.ID,
+			StoreID:      storeID,
+			StartTime:    now,
+		})
+	}
+
+	breakRecord.EndTime = &now
+	attendance.StatusID = next
+	return next, s.saveAttendance(attendance, before)
 }
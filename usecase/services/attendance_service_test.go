@@ -0,0 +1,85 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	repository "github.com/techyoichiro/jobreco-api/infra/database/repositories"
+)
+
+func TestAttendanceServicePunchTime(t *testing.T) {
+	db := newTestDB(t)
+	storeRepo := repository.NewStoreRepository(db)
+	service := NewAttendanceService(repository.NewAttendanceRepository(db), storeRepo, repository.NewPeriodRepository(db), repository.NewEmployeeRepository(db))
+
+	tokyo := &model.Store{Name: "東京店", Timezone: "Asia/Tokyo"}
+	newYork := &model.Store{Name: "ニューヨーク店", Timezone: "America/New_York"}
+	for _, store := range []*model.Store{tokyo, newYork} {
+		if err := storeRepo.CreateStore(store); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		attendance *model.Attendance
+		want       string
+	}{
+		{"勤務区間の店舗のタイムゾーン", &model.Attendance{Segments: []model.WorkSegment{{StoreID: newYork.ID}}}, "America/New_York"},
+		{"店舗を移動しても最初の勤務区間の店舗", &model.Attendance{Segments: []model.WorkSegment{{StoreID: tokyo.ID}, {StoreID: newYork.ID}}}, "Asia/Tokyo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.punchTime(tt.attendance, now)
+			if err != nil {
+				t.Fatalf("punchTime() error = %v", err)
+			}
+			if !got.Equal(now) || got.Location().String() != tt.want {
+				t.Errorf("punchTime() = %v, want %v in %s", got, now, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttendanceServicePunchAudit(t *testing.T) {
+	db := newTestDB(t)
+	storeRepo := repository.NewStoreRepository(db)
+	service := NewAttendanceService(repository.NewAttendanceRepository(db), storeRepo, repository.NewPeriodRepository(db), repository.NewEmployeeRepository(db))
+
+	store := &model.Store{Name: "本店"}
+	if err := storeRepo.CreateStore(store); err != nil {
+		t.Fatal(err)
+	}
+	employee := &model.Employee{Name: "山田", LoginID: "yamada", Password: "x", RoleID: int(model.RoleStaff), HourlyPay: 1200}
+	if err := db.Create(employee).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.ClockIn(employee.ID, store.ID); err != nil {
+		t.Fatalf("ClockIn() error = %v", err)
+	}
+	if _, err := service.ClockOut(employee.ID, store.ID); err != nil {
+		t.Fatalf("ClockOut() error = %v", err)
+	}
+
+	// 出勤・退勤の打刻ごとに、保存した勤怠のIDで監査ログが記録される
+	var attendance model.Attendance
+	if err := db.First(&attendance).Error; err != nil {
+		t.Fatal(err)
+	}
+	var logs []model.AuditLog
+	if err := db.Order("id").Find(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("audit logs = %+v, want 2", logs)
+	}
+	for i, want := range []model.AuditAction{model.AuditActionCreate, model.AuditActionUpdate} {
+		if logs[i].Action != want || logs[i].EntityID != attendance.ID || logs[i].Source != model.AuditSourcePunch {
+			t.Errorf("audit log %d = %+v, want %s of attendance %d", i, logs[i], want, attendance.ID)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"fmt"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// 打刻の種類
+type PunchAction string
+
+const (
+	ActionClockIn  PunchAction = "clock_in"  // 出勤
+	ActionClockOut PunchAction = "clock_out" // 退勤
+	ActionGoOut    PunchAction = "go_out"    // 外出
+	ActionReturn   PunchAction = "return"    // 戻り
+)
+
+// 打刻の表示順
+var punchActions = []PunchAction{ActionClockIn, ActionGoOut, ActionReturn, ActionClockOut}
+
+// 現在のステータスから可能な打刻と遷移先のステータス
+var attendanceTransitions = map[model.AttendanceStatus]map[PunchAction]model.AttendanceStatus{
+	model.StatusNotStarted: {
+		ActionClockIn: model.StatusWorking,
+	},
+	model.StatusWorking: {
+		ActionGoOut:    model.StatusOut,
+		ActionClockOut: model.StatusClockedOut,
+	},
+	model.StatusOut: {
+		ActionReturn: model.StatusReturned,
+	},
+	model.StatusReturned: {
+		ActionGoOut:    model.StatusOut,
+		ActionClockOut: model.StatusClockedOut,
+	},
+	model.StatusClockedOut: {
+		ActionClockIn: model.StatusWorking, // 同じ日の再出勤は新しい勤務区間とする
+	},
+}
+
+// 現在のステータスでは行えない打刻をした場合のエラー
+type TransitionError struct {
+	Current model.AttendanceStatus
+	Action  PunchAction
+	Allowed []PunchAction
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("現在のステータス（%s）では%sできません", e.Current.Label(), e.Action.Label())
+}
+
+// 打刻の表示名
+func (a PunchAction) Label() string {
+	switch a {
+	case ActionClockIn:
+		return "出勤"
+	case ActionClockOut:
+		return "退勤"
+	case ActionGoOut:
+		return "外出"
+	case ActionReturn:
+		return "戻り"
+	default:
+		return string(a)
+	}
+}
+
+// 打刻後のステータスを返す（遷移できない場合は TransitionError）
+func NextStatus(current model.AttendanceStatus, action PunchAction) (model.AttendanceStatus, error) {
+	next, ok := attendanceTransitions[current][action]
+	if !ok {
+		return current, &TransitionError{Current: current, Action: action, Allowed: AllowedActions(current)}
+	}
+	return next, nil
+}
+
+// 現在のステータスで可能な打刻を返す
+func AllowedActions(current model.AttendanceStatus) []PunchAction {
+	allowed := []PunchAction{}
+	for _, action := range punchActions {
+		if _, ok := attendanceTransitions[current][action]; ok {
+			allowed = append(allowed, action)
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+func TestNextStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		current model.AttendanceStatus
+		action  PunchAction
+		want    model.AttendanceStatus
+		wantErr bool
+	}{
+		{name: "Clock in", current: model.StatusNotStarted, action: ActionClockIn, want: model.StatusWorking},
+		{name: "Go out while working", current: model.StatusWorking, action: ActionGoOut, want: model.StatusOut},
+		{name: "Return after going out", current: model.StatusOut, action: ActionReturn, want: model.StatusReturned},
+		{name: "Go out again after return", current: model.StatusReturned, action: ActionGoOut, want: model.StatusOut},
+		{name: "Clock out after return", current: model.StatusReturned, action: ActionClockOut, want: model.StatusClockedOut},
+		{name: "Clock in again after clock out", current: model.StatusClockedOut, action: ActionClockIn, want: model.StatusWorking},
+		{name: "Clock out twice", current: model.StatusClockedOut, action: ActionClockOut, wantErr: true},
+		{name: "Go out before clock in", current: model.StatusNotStarted, action: ActionGoOut, wantErr: true},
+		{name: "Return without going out", current: model.StatusWorking, action: ActionReturn, wantErr: true},
+		{name: "Clock out while out", current: model.StatusOut, action: ActionClockOut, wantErr: true},
+		{name: "Clock in while working", current: model.StatusWorking, action: ActionClockIn, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextStatus(tt.current, tt.action)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NextStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var transitionErr *TransitionError
+				if !errors.As(err, &transitionErr) {
+					t.Fatalf("expected TransitionError, got %T", err)
+				}
+				if !reflect.DeepEqual(transitionErr.Allowed, AllowedActions(tt.current)) {
+					t.Errorf("Allowed = %v, want %v", transitionErr.Allowed, AllowedActions(tt.current))
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NextStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowedActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		current model.AttendanceStatus
+		want    []PunchAction
+	}{
+		{name: "Not started", current: model.StatusNotStarted, want: []PunchAction{ActionClockIn}},
+		{name: "Working", current: model.StatusWorking, want: []PunchAction{ActionGoOut, ActionClockOut}},
+		{name: "Out", current: model.StatusOut, want: []PunchAction{ActionReturn}},
+		{name: "Unknown status", current: model.AttendanceStatus(9), want: []PunchAction{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AllowedActions(tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AllowedActions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
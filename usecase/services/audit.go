@@ -0,0 +1,218 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+// 監査ログの内容に含めない項目（更新のたびに変わるIDや日時）
+var auditIgnoredKeys = map[string]bool{
+	"ID":           true,
+	"CreatedAt":    true,
+	"UpdatedAt":    true,
+	"DeletedAt":    true,
+	"AttendanceID": true,
+}
+
+// 変更の有無だけを記録し、値は伏せる項目
+var auditMaskedKeys = map[string]bool{
+	"Password": true,
+	"LoginID":  true,
+}
+
+const auditMask = "********"
+
+// 監査ログ用の内容（JSONに変換した値から不要な項目を除いたもの）
+type auditSnapshot map[string]any
+
+// 監査ログ用に記録する内容を作成（nil の場合は nil）
+func snapshotOf(v any) (auditSnapshot, error) {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot map[string]any
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return auditSnapshot(stripAuditKeys(snapshot).(map[string]any)), nil
+}
+
+// 記録しない項目を再帰的に取り除く
+func stripAuditKeys(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, child := range value {
+			if auditIgnoredKeys[key] {
+				delete(value, key)
+				continue
+			}
+			value[key] = stripAuditKeys(child)
+		}
+		return value
+	case []any:
+		for i, child := range value {
+			value[i] = stripAuditKeys(child)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// 項目名を「Segments.0.EndTime」の形式にした値の一覧
+func flattenSnapshot(prefix string, v any, out map[string]any) {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, child := range value {
+			flattenSnapshot(joinAuditKey(prefix, key), child, out)
+		}
+	case []any:
+		for i, child := range value {
+			flattenSnapshot(joinAuditKey(prefix, fmt.Sprint(i)), child, out)
+		}
+	default:
+		out[prefix] = value
+	}
+}
+
+func joinAuditKey(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// 項目名の最後の要素が値を伏せる項目か
+func isMaskedAuditKey(key string) bool {
+	for i := len(key) - 1; i >= 0; i-- {
+		if key[i] == '.' {
+			return auditMaskedKeys[key[i+1:]]
+		}
+	}
+	return auditMaskedKeys[key]
+}
+
+// 変更された項目の変更前・変更後
+type auditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// 変更前と変更後の内容を比べ、変更された項目を返す
+func auditChanges(before auditSnapshot, after auditSnapshot) map[string]auditChange {
+	beforeValues := map[string]any{}
+	afterValues := map[string]any{}
+	flattenSnapshot("", map[string]any(before), beforeValues)
+	flattenSnapshot("", map[string]any(after), afterValues)
+
+	keys := []string{}
+	for key := range beforeValues {
+		keys = append(keys, key)
+	}
+	for key := range afterValues {
+		if _, ok := beforeValues[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	changes := map[string]auditChange{}
+	for _, key := range keys {
+		beforeValue, afterValue := beforeValues[key], afterValues[key]
+		if reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+		if isMaskedAuditKey(key) {
+			beforeValue, afterValue = auditMask, auditMask
+		}
+		changes[key] = auditChange{Before: beforeValue, After: afterValue}
+	}
+	return changes
+}
+
+// 値を伏せた内容のJSON
+func maskedJSON(snapshot auditSnapshot) (model.RawJSON, error) {
+	if snapshot == nil {
+		return "", nil
+	}
+	masked := map[string]any{}
+	for key, value := range snapshot {
+		if auditMaskedKeys[key] {
+			value = auditMask
+		}
+		masked[key] = value
+	}
+	data, err := json.Marshal(masked)
+	if err != nil {
+		return "", err
+	}
+	return model.RawJSON(data), nil
+}
+
+// 変更前の内容と変更後の値を比べて監査ログに記録する（変更がない場合は記録しない）
+// entry には操作者・対象・経路を指定する
+func recordAudit(repo repositories.AuditRepository, entry model.AuditLog, before auditSnapshot, after any) error {
+	log, err := buildAudit(entry, before, after)
+	if err != nil || log == nil {
+		return err
+	}
+	return repo.CreateAuditLog(log)
+}
+
+// 変更前の内容と変更後の値から監査ログを作成する（変更がない場合は nil）
+func buildAudit(entry model.AuditLog, before auditSnapshot, after any) (*model.AuditLog, error) {
+	afterSnapshot, err := snapshotOf(after)
+	if err != nil {
+		return nil, err
+	}
+	changes := auditChanges(before, afterSnapshot)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	entry.Action = model.AuditActionUpdate
+	if before == nil {
+		entry.Action = model.AuditActionCreate
+	}
+	if entry.Before, err = maskedJSON(before); err != nil {
+		return nil, err
+	}
+	if entry.After, err = maskedJSON(afterSnapshot); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	entry.Changes = model.RawJSON(data)
+	return &entry, nil
+}
+
+// 操作者の従業員ID（操作者がいない場合は nil）
+func auditActor(actor Actor) *uint {
+	if actor.EmployeeID == 0 {
+		return nil
+	}
+	id := actor.EmployeeID
+	return &id
+}
+
+// 画面からの従業員の登録・更新の監査ログ
+func employeeAudit(actor Actor, employee *model.Employee) model.AuditLog {
+	return model.AuditLog{
+		ActorID:    auditActor(actor),
+		EmployeeID: employee.ID,
+		Entity:     model.AuditEntityEmployee,
+		EntityID:   employee.ID,
+		Source:     model.AuditSourceManualEdit,
+	}
+}
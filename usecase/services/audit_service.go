@@ -0,0 +1,42 @@
+package services
+
+import (
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+type AuditService struct {
+	repo    repositories.AuditRepository
+	empRepo repositories.EmployeeRepository
+}
+
+func NewAuditService(repo repositories.AuditRepository, empRepo repositories.EmployeeRepository) *AuditService {
+	return &AuditService{repo: repo, empRepo: empRepo}
+}
+
+// 監査ログを検索（店長は担当店舗の従業員のログのみ）
+func (s *AuditService) GetAuditLogs(actor Actor, filter repositories.AuditLogFilter) ([]model.AuditLog, error) {
+	if filter.EmployeeID != 0 {
+		employee, err := s.empRepo.FindEmpByEmpID(int(filter.EmployeeID))
+		if err != nil {
+			return nil, err
+		}
+		if err := actor.authorize(model.PermViewAuditLog, employee); err != nil {
+			return nil, err
+		}
+	}
+
+	switch actor.Role.Scope(model.PermViewAuditLog) {
+	case model.ScopeAll:
+	case model.ScopeStore:
+		if filter.EmployeeID == 0 {
+			if actor.StoreID == 0 {
+				return []model.AuditLog{}, nil
+			}
+			filter.StoreID = uint(actor.StoreID)
+		}
+	default:
+		return nil, ErrForbidden
+	}
+	return s.repo.FindAuditLogs(filter)
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+func TestAuditChanges(t *testing.T) {
+	start := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 4, 1, 18, 0, 0, 0, time.UTC)
+	attendance := func(end *time.Time, status model.AttendanceStatus) *model.Attendance {
+		return &model.Attendance{
+			ID:         1,
+			EmployeeID: 1,
+			WorkDate:   start,
+			StatusID:   status,
+			Segments:   []model.WorkSegment{{AttendanceID: 1, StoreID: 1, StartTime: start, EndTime: end}},
+		}
+	}
+	snapshot := func(v any) auditSnapshot {
+		s, err := snapshotOf(v)
+		if err != nil {
+			t.Fatalf("snapshotOf() error = %v", err)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name   string
+		before auditSnapshot
+		after  any
+		want   map[string]auditChange
+	}{
+		{
+			name:   "退勤",
+			before: snapshot(attendance(nil, model.StatusWorking)),
+			after:  attendance(&end, model.StatusClockedOut),
+			want: map[string]auditChange{
+				"Segments.0.EndTime": {Before: nil, After: "2024-04-01T18:00:00Z"},
+				"StatusID":           {Before: float64(model.StatusWorking), After: float64(model.StatusClockedOut)},
+			},
+		},
+		{
+			name:   "変更なし",
+			before: snapshot(attendance(&end, model.StatusClockedOut)),
+			after:  attendance(&end, model.StatusClockedOut),
+			want:   map[string]auditChange{},
+		},
+		{
+			name:   "パスワードは値を伏せる",
+			before: snapshot(&model.Employee{Name: "山田", Password: "old"}),
+			after:  &model.Employee{Name: "山田", Password: "new"},
+			want: map[string]auditChange{
+				"Password": {Before: auditMask, After: auditMask},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := auditChanges(tt.before, snapshot(tt.after)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("auditChanges() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
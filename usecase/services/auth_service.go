@@ -3,6 +3,7 @@ package services
 import (
 	"errors"
 	"log"
+	"time"
 
 	"github.com/techyoichiro/jobreco-api/crypto"
 	model "github.com/techyoichiro/jobreco-api/domain/models"
@@ -10,26 +11,47 @@ import (
 )
 
 type AuthService struct {
-	repo repositories.EmployeeRepository
+	repo        repositories.EmployeeRepository
+	storeRepo   repositories.StoreRepository
+	auditRepo   repositories.AuditRepository
+	minimumRepo repositories.MinimumWageRepository
 }
 
-func NewAuthService(repo repositories.EmployeeRepository) *AuthService {
-	return &AuthService{repo: repo}
+func NewAuthService(repo repositories.EmployeeRepository, storeRepo repositories.StoreRepository, auditRepo repositories.AuditRepository, minimumRepo repositories.MinimumWageRepository) *AuthService {
+	return &AuthService{repo: repo, storeRepo: storeRepo, auditRepo: auditRepo, minimumRepo: minimumRepo}
 }
 
-// サインアップ
-func (s *AuthService) Signup(name, loginID, password string) (*model.Employee, error) {
-	// メールアドレスの存在チェック
-	existingEmployee, err := s.repo.FindEmpByLoginID(loginID)
+// サインアップ（担当店舗を指定して登録する）
+func (s *AuthService) Signup(name, loginID, password string, storeID uint) (*model.Employee, error) {
+	// メールアドレスの存在チェック（暗号化して保存しているため復号して比較する）
+	registered, err := registeredLoginIDs(s.repo)
 	if err != nil {
 		log.Printf("Error finding employee by loginID: %v", err)
 		return nil, err
 	}
-	if existingEmployee != nil {
-		log.Printf("Employee already exists: %v", existingEmployee)
+	if registered[loginID] {
+		log.Printf("Employee already exists: %s", loginID)
 		return nil, errors.New("同一の従業員IDが既に登録されています")
 	}
 
+	if storeID == 0 {
+		return nil, errors.New("担当店舗を指定してください")
+	}
+	if _, err := findActiveStore(s.storeRepo, storeID); err != nil {
+		return nil, err
+	}
+
+	// 担当店舗の都道府県の最低賃金を時給の初期値とする
+	today := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
+	minimums, err := loadMinimumWageTable(s.minimumRepo, s.storeRepo)
+	if err != nil {
+		return nil, err
+	}
+	minimum := minimums.forStore(storeID, today)
+	if minimum == nil {
+		return nil, errors.New("最低賃金が登録されていないため、時給の初期値を決められません")
+	}
+
 	// パスワードの暗号化
 	encryptedPw, err := crypto.PasswordEncrypt(password)
 	if err != nil {
@@ -44,16 +66,26 @@ func (s *AuthService) Signup(name, loginID, password string) (*model.Employee, e
 		return nil, err
 	}
 
+	competentStoreID := int(storeID)
 	employee := &model.Employee{
-		Name:      name,
-		LoginID:   encryptedEmail,
-		Password:  encryptedPw,
-		RoleID:    1,    // 初期値には従業員権限を付与
-		HourlyPay: 1162, // 初期値には時給1162円を付与
+		Name:             name,
+		LoginID:          encryptedEmail,
+		Password:         encryptedPw,
+		RoleID:           int(model.RoleStaff), // 初期値には従業員権限を付与
+		HourlyPay:        minimum.HourlyWage,   // 初期値には最低賃金を付与
+		CompetentStoreID: &competentStoreID,
 	}
 
-	err = s.repo.CreateEmp(employee)
+	// 登録時の時給の履歴・監査ログと合わせて1つのトランザクションで登録する
+	history, err := newWageHistory(Actor{}, 0, employee.HourlyPay, today, "登録時の時給")
 	if err != nil {
+		return nil, err
+	}
+	auditLog, err := buildAudit(employeeAudit(Actor{}, employee), nil, employee)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ImportEmployees([]repositories.EmployeeImport{{Employee: employee, WageHistory: history, AuditLog: auditLog}}); err != nil {
 		log.Printf("Error creating employee: %v", err)
 		return nil, err
 	}
@@ -61,6 +93,12 @@ func (s *AuthService) Signup(name, loginID, password string) (*model.Employee, e
 	return employee, nil
 }
 
+// アクセストークンの従業員の現在の情報を取得（退職済みの場合はエラー）
+// 権限・担当店舗の変更や退職処理をトークンの有効期限を待たずに反映するため、リクエストごとに取得する
+func (s *AuthService) FindActiveEmployee(employeeID uint) (*model.Employee, error) {
+	return findActiveEmployee(s.repo, employeeID)
+}
+
 // ログイン
 func (s *AuthService) Login(loginID, password string) (*model.Employee, error) {
 	emp, err := s.repo.FindEmpByLoginID(loginID)
@@ -76,16 +114,22 @@ func (s *AuthService) Login(loginID, password string) (*model.Employee, error) {
 		return nil, errors.New("パスワードが一致しませんでした。")
 	}
 
+	// 退職処理をした従業員はログインできない
+	if !emp.IsActive() {
+		return nil, ErrEmployeeDeactivated
+	}
+
 	return emp, nil
 }
 
-// employee_id に紐づく status_id を取得
-func (s *AuthService) GetStatusByEmpID(employeeID uint) (int, error) {
-	statusID, err := s.repo.GetStatusByEmpID(employeeID)
+// ログインした従業員のアクセストークンを発行
+func (s *AuthService) IssueToken(emp *model.Employee) (string, time.Time, error) {
+	token, expiresAt, err := crypto.GenerateToken(emp.ID, emp.RoleID, int(emp.StoreID()))
 	if err != nil {
-		return 0, err
+		log.Printf("Error generating token: %v", err)
+		return "", time.Time{}, err
 	}
-	return statusID, nil
+	return token, expiresAt, nil
 }
 
 // employee_id に紐づく login_id を取得
@@ -115,6 +159,11 @@ func (s *AuthService) UpdatePassword(loginID, currentPassword, newPassword strin
 		return errors.New("現在のパスワードが一致しません")
 	}
 
+	before, err := snapshotOf(employee)
+	if err != nil {
+		return err
+	}
+
 	// 新しいパスワードの暗号化
 	encryptedPw, err := crypto.PasswordEncrypt(newPassword)
 	if err != nil {
@@ -130,6 +179,12 @@ func (s *AuthService) UpdatePassword(loginID, currentPassword, newPassword strin
 		return err
 	}
 
+	// パスワードは変更したことだけを記録する
+	if err := recordAudit(s.auditRepo, employeeAudit(Actor{EmployeeID: employee.ID}, employee), before, employee); err != nil {
+		log.Printf("Error recording audit log: %v", err)
+		return err
+	}
+
 	return nil
 }
 
@@ -143,22 +198,70 @@ func (s *AuthService) DecryptLoginID(encryptedLoginID string) (string, error) {
 	return loginID, nil
 }
 
-// アカウント設定更新
-func (s *AuthService) UpdateAccount(employeeID int, name string, hourlyPay int, competentStoreID int) error {
+// アカウント設定更新（hourlyPay が nil の場合は時給を変更しない）
+func (s *AuthService) UpdateAccount(actor Actor, employeeID int, name string, hourlyPay *int, competentStoreID int) error {
 	// ユーザー情報を取得
 	employee, err := s.repo.FindEmpByEmpID(employeeID)
 	if err != nil {
 		log.Printf("Error finding employee by ID: %v", err)
 		return err
 	}
+	if employee == nil {
+		return errors.New("従業員が見つかりません")
+	}
+	if err := actor.authorize(model.PermUpdateAccount, employee); err != nil {
+		return err
+	}
+	before, err := snapshotOf(employee)
+	if err != nil {
+		return err
+	}
+
+	// 時給の変更はオーナーのみ（今日から適用する履歴を追加）
+	today := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
+	var history *model.WageHistory
+	if hourlyPay != nil && *hourlyPay != employee.HourlyPay {
+		if err := actor.authorize(model.PermUpdateHourlyPay, employee); err != nil {
+			return err
+		}
+		if history, err = newWageHistory(actor, employee.ID, *hourlyPay, today, "アカウント設定から変更"); err != nil {
+			return err
+		}
+		employee.HourlyPay = *hourlyPay
+	}
+	storeChanged := uint(competentStoreID) != employee.StoreID()
+
+	// 担当店舗の変更は店長の担当範囲に影響するため別の権限で確認
+	if storeChanged {
+		if err := authorizeStoreAssignment(actor, employee, uint(competentStoreID)); err != nil {
+			return err
+		}
+		if _, err := findActiveStore(s.storeRepo, uint(competentStoreID)); err != nil {
+			return err
+		}
+		employee.CompetentStoreID = &competentStoreID
+	}
+
+	// 時給・担当店舗を変更した場合は、変更後の担当店舗の都道府県の最低賃金を下回らないか確認
+	if history != nil || storeChanged {
+		minimums, err := loadMinimumWageTable(s.minimumRepo, s.storeRepo)
+		if err != nil {
+			return err
+		}
+		if err := checkMinimumWage(employee.HourlyPay, minimums.forStore(employee.StoreID(), today)); err != nil {
+			return err
+		}
+	}
 
 	// 取得した employee の情報を更新
 	employee.Name = name
-	employee.HourlyPay = hourlyPay
-	employee.CompetentStoreID = competentStoreID
 
-	// 更新された employee をデータベースに保存
-	if err := s.repo.UpdateEmployee(employee); err != nil {
+	// 更新された employee を時給の履歴・監査ログと合わせて1つのトランザクションで保存
+	auditLog, err := buildAudit(employeeAudit(actor, employee), before, employee)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SaveEmployeeChange(repositories.EmployeeChange{Employee: employee, WageHistory: history, AuditLog: auditLog}); err != nil {
 		log.Printf("Error updating employee: %v", err)
 		return err
 	}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	repository "github.com/techyoichiro/jobreco-api/infra/database/repositories"
+)
+
+func TestAuthServiceSignupMinimumWage(t *testing.T) {
+	t.Setenv("ENCRYPTION_KEY", "0123456789abcdef0123456789abcdef")
+	db := newTestDB(t)
+	service := NewAuthService(repository.NewEmployeeRepository(db), repository.NewStoreRepository(db), repository.NewAuditRepository(db), repository.NewMinimumWageRepository(db))
+
+	stores := []model.Store{{Name: "横浜店", Prefecture: "神奈川県"}, {Name: "新宿店", Prefecture: "東京都"}}
+	if err := db.Create(&stores).Error; err != nil {
+		t.Fatal(err)
+	}
+	effectiveFrom := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	if err := db.Create(&[]model.MinimumWage{
+		{Prefecture: "東京都", EffectiveFrom: effectiveFrom, HourlyWage: 1226},
+		{Prefecture: "神奈川県", EffectiveFrom: effectiveFrom, HourlyWage: 1225},
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		loginID       string
+		storeID       uint
+		wantHourlyPay int
+		wantErr       bool
+	}{
+		{"担当店舗の都道府県の最低賃金", "kanagawa@example.com", stores[0].ID, 1225, false},
+		{"東京都の店舗", "tokyo@example.com", stores[1].ID, 1226, false},
+		{"登録済みのログインID", "kanagawa@example.com", stores[1].ID, 0, true},
+		{"担当店舗の指定なし", "none@example.com", 0, 0, true},
+		{"存在しない店舗", "unknown@example.com", 999, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			employee, err := service.Signup("山田太郎", tt.loginID, "password", tt.storeID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Signup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if employee.HourlyPay != tt.wantHourlyPay || employee.StoreID() != tt.storeID {
+				t.Errorf("Signup() = hourly pay %d, store %d, want %d, %d", employee.HourlyPay, employee.StoreID(), tt.wantHourlyPay, tt.storeID)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"errors"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+var ErrForbidden = errors.New("この操作を行う権限がありません")
+
+// 操作者（アクセストークンから取得した従業員）
+type Actor struct {
+	EmployeeID uint
+	Role       model.Role
+	StoreID    int // 担当店舗ID
+}
+
+// 対象の従業員に対して指定した操作を行えるか
+func (a Actor) CanAccess(p model.Permission, target *model.Employee) bool {
+	if target == nil {
+		return false
+	}
+	switch a.Role.Scope(p) {
+	case model.ScopeAll:
+		return true
+	case model.ScopeStore:
+		if target.ID == a.EmployeeID {
+			return true
+		}
+		return a.StoreID != 0 && int(target.StoreID()) == a.StoreID
+	case model.ScopeOwn:
+		return target.ID == a.EmployeeID
+	default:
+		return false
+	}
+}
+
+// 対象の従業員に対して指定した操作を行えない場合はエラーを返す
+func (a Actor) authorize(p model.Permission, target *model.Employee) error {
+	if !a.CanAccess(p, target) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// 指定した店舗に対して指定した操作を行えるか（店舗単位で管理するシフトなど）
+func (a Actor) CanAccessStore(p model.Permission, storeID uint) bool {
+	switch a.Role.Scope(p) {
+	case model.ScopeAll:
+		return true
+	case model.ScopeStore:
+		return a.StoreID != 0 && uint(a.StoreID) == storeID
+	default:
+		return false
+	}
+}
+
+// 指定した店舗に対して指定した操作を行えない場合はエラーを返す
+func (a Actor) authorizeStore(p model.Permission, storeID uint) error {
+	if !a.CanAccessStore(p, storeID) {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"testing"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+func TestActorCanAccessStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   Actor
+		storeID uint
+		want    bool
+	}{
+		{"店長は担当店舗のシフトを管理できる", Actor{Role: model.RoleStoreManager, StoreID: 1}, 1, true},
+		{"店長は他店舗のシフトを管理できない", Actor{Role: model.RoleStoreManager, StoreID: 1}, 2, false},
+		{"担当店舗のない店長は管理できない", Actor{Role: model.RoleStoreManager}, 0, false},
+		{"オーナーはすべての店舗を管理できる", Actor{Role: model.RoleOwner}, 2, true},
+		{"従業員は管理できない", Actor{Role: model.RoleStaff, StoreID: 1}, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.actor.CanAccessStore(model.PermManageShifts, tt.storeID); got != tt.want {
+				t.Errorf("CanAccessStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
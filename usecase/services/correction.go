@@ -0,0 +1,194 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// 修正申請で指定できる項目
+var correctionFieldNames = map[string][]string{
+	"segments": {"store_id", "start_time", "end_time"},
+	"breaks":   {"break_type", "start_time", "end_time"},
+}
+
+// 勤怠の現在の内容を編集用の形式（時:分の文字列）に変換
+func attendanceEdit(attendance *model.Attendance) *model.AttendanceResponse {
+	return &model.AttendanceResponse{
+		ID:       attendance.ID,
+		Segments: segmentResponses(attendance.Segments, nil),
+		Breaks:   breakResponses(attendance.Breaks),
+	}
+}
+
+// 修正する項目（segments.0.start_time など）を分解する
+func parseCorrectionField(field string) (kind string, index int, name string, err error) {
+	parts := strings.Split(field, ".")
+	if len(parts) != 3 {
+		return "", 0, "", fmt.Errorf("修正する項目が不正です: %s", field)
+	}
+	names, ok := correctionFieldNames[parts[0]]
+	if !ok {
+		return "", 0, "", fmt.Errorf("修正する項目が不正です: %s", field)
+	}
+	index, err = strconv.Atoi(parts[1])
+	if err != nil || index < 0 {
+		return "", 0, "", fmt.Errorf("修正する項目の番号が不正です: %s", field)
+	}
+	for _, n := range names {
+		if n == parts[2] {
+			return parts[0], index, parts[2], nil
+		}
+	}
+	return "", 0, "", fmt.Errorf("修正する項目が不正です: %s", field)
+}
+
+// 編集内容の項目の値を取得（存在しない勤務区間・休憩は空）
+func correctionValue(edit *model.AttendanceResponse, kind string, index int, name string) string {
+	switch kind {
+	case "segments":
+		if index >= len(edit.Segments) {
+			return ""
+		}
+		segment := edit.Segments[index]
+		switch name {
+		case "store_id":
+			if segment.StoreID == 0 {
+				return ""
+			}
+			return strconv.FormatUint(uint64(segment.StoreID), 10)
+		case "start_time":
+			return segment.StartTime
+		case "end_time":
+			return segment.EndTime
+		}
+	case "breaks":
+		if index >= len(edit.Breaks) {
+			return ""
+		}
+		breakRecord := edit.Breaks[index]
+		switch name {
+		case "break_type":
+			return string(breakRecord.BreakType)
+		case "start_time":
+			return breakRecord.StartTime
+		case "end_time":
+			return breakRecord.EndTime
+		}
+	}
+	return ""
+}
+
+// 編集内容の項目に値を設定（最後の次の番号を指定した場合は勤務区間・休憩を追加する）
+func setCorrectionValue(edit *model.AttendanceResponse, kind string, index int, name string, value string) error {
+	switch kind {
+	case "segments":
+		if index == len(edit.Segments) {
+			edit.Segments = append(edit.Segments, model.WorkSegmentResponse{})
+		}
+		if index > len(edit.Segments) {
+			return fmt.Errorf("勤務区間%dを追加する前に勤務区間%dを入力してください", index+1, len(edit.Segments)+1)
+		}
+		segment := &edit.Segments[index]
+		switch name {
+		case "store_id":
+			storeID := uint64(0)
+			if value != "" {
+				parsed, err := strconv.ParseUint(value, 10, 32)
+				if err != nil {
+					return fmt.Errorf("店舗IDが不正です: %s", value)
+				}
+				storeID = parsed
+			}
+			segment.StoreID = uint(storeID)
+		case "start_time":
+			segment.StartTime = value
+		case "end_time":
+			segment.EndTime = value
+		}
+	case "breaks":
+		if index == len(edit.Breaks) {
+			edit.Breaks = append(edit.Breaks, model.AttendanceBreakResponse{})
+		}
+		if index > len(edit.Breaks) {
+			return fmt.Errorf("休憩%dを追加する前に休憩%dを入力してください", index+1, len(edit.Breaks)+1)
+		}
+		breakRecord := &edit.Breaks[index]
+		switch name {
+		case "break_type":
+			breakRecord.BreakType = model.BreakType(value)
+		case "start_time":
+			breakRecord.StartTime = value
+		case "end_time":
+			breakRecord.EndTime = value
+		}
+	}
+	return nil
+}
+
+// 現在の内容と編集後の内容を比べ、変更された項目を返す
+func diffAttendanceEdit(current *model.AttendanceResponse, edited *model.AttendanceResponse) []model.AttendanceCorrectionItem {
+	items := []model.AttendanceCorrectionItem{}
+	for _, kind := range []string{"segments", "breaks"} {
+		count := max(len(current.Segments), len(edited.Segments))
+		if kind == "breaks" {
+			count = max(len(current.Breaks), len(edited.Breaks))
+		}
+		for i := 0; i < count; i++ {
+			for _, name := range correctionFieldNames[kind] {
+				oldValue := correctionValue(current, kind, i, name)
+				newValue := correctionValue(edited, kind, i, name)
+				if oldValue == newValue {
+					continue
+				}
+				items = append(items, model.AttendanceCorrectionItem{
+					Field:    fmt.Sprintf("%s.%d.%s", kind, i, name),
+					OldValue: oldValue,
+					NewValue: newValue,
+				})
+			}
+		}
+	}
+	return items
+}
+
+// 修正する項目を現在の内容に適用した編集内容を返す
+// 申請時の値と現在の値が異なる場合は、申請後に勤怠が変更されたものとしてエラーにする
+// 開始時刻が空になった勤務区間・休憩は削除する
+func applyCorrectionItems(current *model.AttendanceResponse, items []model.AttendanceCorrectionItem) (*model.AttendanceResponse, error) {
+	edit := &model.AttendanceResponse{
+		ID:       current.ID,
+		Segments: append([]model.WorkSegmentResponse{}, current.Segments...),
+		Breaks:   append([]model.AttendanceBreakResponse{}, current.Breaks...),
+	}
+	for _, item := range items {
+		kind, index, name, err := parseCorrectionField(item.Field)
+		if err != nil {
+			return nil, err
+		}
+		if correctionValue(edit, kind, index, name) != item.OldValue {
+			return nil, fmt.Errorf("申請後に勤怠が変更されています: %s", item.Field)
+		}
+		if err := setCorrectionValue(edit, kind, index, name, item.NewValue); err != nil {
+			return nil, err
+		}
+	}
+
+	segments := edit.Segments[:0]
+	for _, segment := range edit.Segments {
+		if segment.StartTime != "" {
+			segments = append(segments, segment)
+		}
+	}
+	breaks := edit.Breaks[:0]
+	for _, breakRecord := range edit.Breaks {
+		if breakRecord.StartTime != "" {
+			breaks = append(breaks, breakRecord)
+		}
+	}
+	edit.Segments = segments
+	edit.Breaks = breaks
+	return edit, nil
+}
@@ -0,0 +1,281 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+var ErrCorrectionNotFound = errors.New("勤怠の修正申請が見つかりません")
+
+type CorrectionService struct {
+	repo        repositories.CorrectionRepository
+	summaryRepo repositories.SummaryRepository
+	empRepo     repositories.EmployeeRepository
+	storeRepo   repositories.StoreRepository
+	periodRepo  repositories.PeriodRepository
+}
+
+func NewCorrectionService(repo repositories.CorrectionRepository, summaryRepo repositories.SummaryRepository, empRepo repositories.EmployeeRepository, storeRepo repositories.StoreRepository, periodRepo repositories.PeriodRepository) *CorrectionService {
+	return &CorrectionService{repo: repo, summaryRepo: summaryRepo, empRepo: empRepo, storeRepo: storeRepo, periodRepo: periodRepo}
+}
+
+// 修正する項目を指定して勤怠の修正を申請
+func (s *CorrectionService) RequestCorrection(actor Actor, attendanceID uint, reason string, items []model.AttendanceCorrectionItem) (*model.AttendanceCorrection, error) {
+	attendance, err := s.summaryRepo.GetAttendanceByID(attendanceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEmployee(actor, model.PermEditAttendance, attendance.EmployeeID); err != nil {
+		return nil, err
+	}
+
+	// 申請時の値は申請者の入力ではなく現在の勤怠から記録する
+	current := attendanceEdit(attendance)
+	changed := []model.AttendanceCorrectionItem{}
+	for _, item := range items {
+		kind, index, name, err := parseCorrectionField(item.Field)
+		if err != nil {
+			return nil, err
+		}
+		item.OldValue = correctionValue(current, kind, index, name)
+		if item.OldValue != item.NewValue {
+			changed = append(changed, model.AttendanceCorrectionItem{Field: item.Field, OldValue: item.OldValue, NewValue: item.NewValue})
+		}
+	}
+	return s.createCorrection(actor, attendance, reason, changed)
+}
+
+// 編集後の勤怠の内容から、変更された項目の修正を申請
+func (s *CorrectionService) RequestAttendanceEdit(actor Actor, edited *model.AttendanceResponse, reason string) (*model.AttendanceCorrection, error) {
+	attendance, err := s.summaryRepo.GetAttendanceByID(edited.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEmployee(actor, model.PermEditAttendance, attendance.EmployeeID); err != nil {
+		return nil, err
+	}
+	return s.createCorrection(actor, attendance, reason, diffAttendanceEdit(attendanceEdit(attendance), edited))
+}
+
+// 修正申請を検証して作成
+func (s *CorrectionService) createCorrection(actor Actor, attendance *model.Attendance, reason string, items []model.AttendanceCorrectionItem) (*model.AttendanceCorrection, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.New("修正の理由を入力してください")
+	}
+	if len(items) == 0 {
+		return nil, errors.New("修正する項目がありません")
+	}
+
+	pending, err := s.repo.HasPendingCorrection(attendance.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, errors.New("この勤怠には承認待ちの修正申請があります")
+	}
+
+	// 承認時に反映できない内容は申請の時点でエラーにする
+	edit, err := applyCorrectionItems(attendanceEdit(attendance), items)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := buildAttendanceEdit(s.storeRepo, attendance, edit)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureAttendanceOpen(s.periodRepo, attendance, updated); err != nil {
+		return nil, err
+	}
+
+	correction := &model.AttendanceCorrection{
+		AttendanceID: attendance.ID,
+		EmployeeID:   attendance.EmployeeID,
+		RequesterID:  actor.EmployeeID,
+		Reason:       reason,
+		Status:       model.CorrectionPending,
+		Items:        items,
+	}
+	if err := s.repo.CreateCorrection(correction); err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+// 自分が申請した、または自分の勤怠に対する修正申請を取得
+func (s *CorrectionService) GetMyCorrections(actor Actor) ([]model.AttendanceCorrection, error) {
+	return s.repo.GetEmployeeCorrections(actor.EmployeeID)
+}
+
+// 承認できる修正申請を取得（店長は担当店舗の従業員、オーナーは全従業員）
+func (s *CorrectionService) GetReviewableCorrections(actor Actor, status model.CorrectionStatus) ([]model.AttendanceCorrection, error) {
+	switch actor.Role.Scope(model.PermApproveCorrection) {
+	case model.ScopeAll:
+		return s.repo.GetCorrections(0, status)
+	case model.ScopeStore:
+		if actor.StoreID == 0 {
+			return []model.AttendanceCorrection{}, nil
+		}
+		return s.repo.GetCorrections(uint(actor.StoreID), status)
+	default:
+		return nil, ErrForbidden
+	}
+}
+
+// 修正申請を取得（申請者・勤怠の従業員・承認できる従業員のみ）
+func (s *CorrectionService) GetCorrection(actor Actor, correctionID uint) (*model.AttendanceCorrection, error) {
+	correction, err := s.findCorrection(correctionID)
+	if err != nil {
+		return nil, err
+	}
+	if correction.RequesterID == actor.EmployeeID || correction.EmployeeID == actor.EmployeeID {
+		return correction, nil
+	}
+	if err := s.authorizeEmployee(actor, model.PermApproveCorrection, correction.EmployeeID); err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+// 修正申請を取り下げ（申請者のみ）
+func (s *CorrectionService) CancelCorrection(actor Actor, correctionID uint) (*model.AttendanceCorrection, error) {
+	correction, err := s.findCorrection(correctionID)
+	if err != nil {
+		return nil, err
+	}
+	if correction.RequesterID != actor.EmployeeID {
+		return nil, ErrForbidden
+	}
+	if !correction.IsPending() {
+		return nil, errors.New("承認待ちの申請ではありません")
+	}
+
+	correction.Status = model.CorrectionCancelled
+	if err := s.repo.UpdateCorrection(correction); err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+// 修正申請を承認し、勤怠に反映する
+func (s *CorrectionService) ApproveCorrection(actor Actor, correctionID uint, note string) (*model.AttendanceCorrection, error) {
+	correction, err := s.findReviewableCorrection(actor, correctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	attendance, err := s.summaryRepo.GetAttendanceByID(correction.AttendanceID)
+	if err != nil {
+		return nil, err
+	}
+	edit, err := applyCorrectionItems(attendanceEdit(attendance), correction.Items)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := buildAttendanceEdit(s.storeRepo, attendance, edit)
+	if err != nil {
+		return nil, err
+	}
+	// 申請後に締められた月の勤怠は承認しても反映できない
+	if err := ensureAttendanceOpen(s.periodRepo, attendance, updated); err != nil {
+		return nil, err
+	}
+	before, err := snapshotOf(attendance)
+	if err != nil {
+		return nil, err
+	}
+
+	// 保存後の勤怠（ステータスは退勤済みになる場合のみ変わる）
+	after := *attendance
+	after.Segments = updated.Segments
+	after.Breaks = updated.Breaks
+	if updated.StatusID != 0 {
+		after.StatusID = updated.StatusID
+	}
+	log, err := buildAudit(model.AuditLog{
+		ActorID:    auditActor(actor),
+		EmployeeID: correction.EmployeeID,
+		Entity:     model.AuditEntityAttendance,
+		EntityID:   correction.AttendanceID,
+		Source:     model.AuditSourceManualEdit,
+		Note:       fmt.Sprintf("修正申請 #%d", correction.ID),
+	}, before, &after)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewCorrection(correction, actor, model.CorrectionApproved, note)
+	if err := s.repo.ApproveCorrection(correction, &after, log); err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+// 修正申請を却下
+func (s *CorrectionService) RejectCorrection(actor Actor, correctionID uint, note string) (*model.AttendanceCorrection, error) {
+	correction, err := s.findReviewableCorrection(actor, correctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewCorrection(correction, actor, model.CorrectionRejected, note)
+	if err := s.repo.UpdateCorrection(correction); err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+// 承認・却下の結果を記録
+func reviewCorrection(correction *model.AttendanceCorrection, actor Actor, status model.CorrectionStatus, note string) {
+	now := time.Now()
+	reviewerID := actor.EmployeeID
+	correction.Status = status
+	correction.ReviewedByID = &reviewerID
+	correction.ReviewedAt = &now
+	correction.ReviewNote = note
+}
+
+// 修正申請IDから修正申請を取得（存在しない場合はエラー）
+func (s *CorrectionService) findCorrection(correctionID uint) (*model.AttendanceCorrection, error) {
+	correction, err := s.repo.FindCorrectionByID(correctionID)
+	if err != nil {
+		return nil, err
+	}
+	if correction == nil {
+		return nil, ErrCorrectionNotFound
+	}
+	return correction, nil
+}
+
+// 承認・却下する修正申請を取得
+// 承認できない従業員の申請・承認待ちでない申請はエラーにし、自分の勤怠の申請はオーナーのみ承認できる
+func (s *CorrectionService) findReviewableCorrection(actor Actor, correctionID uint) (*model.AttendanceCorrection, error) {
+	correction, err := s.findCorrection(correctionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEmployee(actor, model.PermApproveCorrection, correction.EmployeeID); err != nil {
+		return nil, err
+	}
+	if correction.EmployeeID == actor.EmployeeID && actor.Role.Scope(model.PermApproveCorrection) != model.ScopeAll {
+		return nil, ErrForbidden
+	}
+	if !correction.IsPending() {
+		return nil, errors.New("承認待ちの申請ではありません")
+	}
+	return correction, nil
+}
+
+// 操作者が対象従業員に対して指定した操作を行えるか確認
+func (s *CorrectionService) authorizeEmployee(actor Actor, p model.Permission, employeeID uint) error {
+	employee, err := s.empRepo.FindEmpByEmpID(int(employeeID))
+	if err != nil {
+		return err
+	}
+	return actor.authorize(p, employee)
+}
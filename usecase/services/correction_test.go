@@ -0,0 +1,169 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	repository "github.com/techyoichiro/jobreco-api/infra/database/repositories"
+)
+
+// テスト用の勤怠の編集内容（9:00-18:00 店舗1、休憩12:00-13:00）
+func testAttendanceEdit() *model.AttendanceResponse {
+	return &model.AttendanceResponse{
+		ID:       1,
+		Segments: []model.WorkSegmentResponse{{StoreID: 1, StartTime: "09:00", EndTime: "18:00"}},
+		Breaks:   []model.AttendanceBreakResponse{{BreakType: model.BreakTypeMeal, StartTime: "12:00", EndTime: "13:00"}},
+	}
+}
+
+func TestDiffAttendanceEdit(t *testing.T) {
+	edited := testAttendanceEdit()
+	edited.Segments[0].EndTime = "19:00"
+	edited.Segments = append(edited.Segments, model.WorkSegmentResponse{StoreID: 2, StartTime: "20:00", EndTime: "22:00"})
+	edited.Breaks = nil
+
+	want := []model.AttendanceCorrectionItem{
+		{Field: "segments.0.end_time", OldValue: "18:00", NewValue: "19:00"},
+		{Field: "segments.1.store_id", OldValue: "", NewValue: "2"},
+		{Field: "segments.1.start_time", OldValue: "", NewValue: "20:00"},
+		{Field: "segments.1.end_time", OldValue: "", NewValue: "22:00"},
+		{Field: "breaks.0.break_type", OldValue: "meal", NewValue: ""},
+		{Field: "breaks.0.start_time", OldValue: "12:00", NewValue: ""},
+		{Field: "breaks.0.end_time", OldValue: "13:00", NewValue: ""},
+	}
+	if got := diffAttendanceEdit(testAttendanceEdit(), edited); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffAttendanceEdit() = %+v, want %+v", got, want)
+	}
+}
+
+func TestApplyCorrectionItems(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []model.AttendanceCorrectionItem
+		want    *model.AttendanceResponse
+		wantErr bool
+	}{
+		{
+			name:  "退勤時刻の修正",
+			items: []model.AttendanceCorrectionItem{{Field: "segments.0.end_time", OldValue: "18:00", NewValue: "19:00"}},
+			want: &model.AttendanceResponse{
+				ID:       1,
+				Segments: []model.WorkSegmentResponse{{StoreID: 1, StartTime: "09:00", EndTime: "19:00"}},
+				Breaks:   []model.AttendanceBreakResponse{{BreakType: model.BreakTypeMeal, StartTime: "12:00", EndTime: "13:00"}},
+			},
+		},
+		{
+			name: "休憩の追加と削除",
+			items: []model.AttendanceCorrectionItem{
+				{Field: "breaks.0.start_time", OldValue: "12:00", NewValue: ""},
+				{Field: "breaks.1.break_type", OldValue: "", NewValue: "rest"},
+				{Field: "breaks.1.start_time", OldValue: "", NewValue: "15:00"},
+				{Field: "breaks.1.end_time", OldValue: "", NewValue: "15:15"},
+			},
+			want: &model.AttendanceResponse{
+				ID:       1,
+				Segments: []model.WorkSegmentResponse{{StoreID: 1, StartTime: "09:00", EndTime: "18:00"}},
+				Breaks:   []model.AttendanceBreakResponse{{BreakType: model.BreakTypeRest, StartTime: "15:00", EndTime: "15:15"}},
+			},
+		},
+		{
+			name:    "申請後に勤怠が変更されている",
+			items:   []model.AttendanceCorrectionItem{{Field: "segments.0.end_time", OldValue: "17:00", NewValue: "19:00"}},
+			wantErr: true,
+		},
+		{
+			name:    "番号を飛ばした追加",
+			items:   []model.AttendanceCorrectionItem{{Field: "segments.2.start_time", OldValue: "", NewValue: "20:00"}},
+			wantErr: true,
+		},
+		{
+			name:    "存在しない項目",
+			items:   []model.AttendanceCorrectionItem{{Field: "segments.0.hourly_pay", OldValue: "", NewValue: "2000"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := testAttendanceEdit()
+			got, err := applyCorrectionItems(current, tt.items)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyCorrectionItems() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applyCorrectionItems() = %+v, want %+v", got, tt.want)
+			}
+			// 現在の内容は変更しない
+			if !reflect.DeepEqual(current, testAttendanceEdit()) {
+				t.Errorf("applyCorrectionItems() modified current: %+v", current)
+			}
+		})
+	}
+}
+
+func TestApproveCorrection(t *testing.T) {
+	db := newTestDB(t)
+	storeRepo := repository.NewStoreRepository(db)
+	service := NewCorrectionService(repository.NewCorrectionRepository(db), repository.NewSummaryRepository(db), repository.NewEmployeeRepository(db), storeRepo, repository.NewPeriodRepository(db))
+
+	store := &model.Store{Name: "本店"}
+	if err := storeRepo.CreateStore(store); err != nil {
+		t.Fatal(err)
+	}
+	storeID := int(store.ID)
+	employee := &model.Employee{Name: "山田", LoginID: "yamada", Password: "x", RoleID: int(model.RoleStaff), HourlyPay: 1200, CompetentStoreID: &storeID}
+	if err := db.Create(employee).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// 退勤漏れの勤怠
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	attendance := &model.Attendance{
+		EmployeeID: employee.ID,
+		WorkDate:   time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		StatusID:   model.StatusWorking,
+		Segments:   []model.WorkSegment{{StoreID: store.ID, StartTime: time.Date(2024, 4, 1, 9, 0, 0, 0, loc)}},
+	}
+	if err := db.Create(attendance).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	staff := Actor{EmployeeID: employee.ID, Role: model.RoleStaff, StoreID: storeID}
+	correction, err := service.RequestCorrection(staff, attendance.ID, "退勤の打刻漏れ", []model.AttendanceCorrectionItem{{Field: "segments.0.end_time", NewValue: "18:00"}})
+	if err != nil {
+		t.Fatalf("RequestCorrection() error = %v", err)
+	}
+	if _, err := service.ApproveCorrection(Actor{EmployeeID: 99, Role: model.RoleOwner}, correction.ID, "確認しました"); err != nil {
+		t.Fatalf("ApproveCorrection() error = %v", err)
+	}
+
+	// 勤怠・監査ログ・申請の状態がまとめて保存される
+	got, err := repository.NewSummaryRepository(db).GetAttendanceByID(attendance.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.StatusID != model.StatusClockedOut || len(got.Segments) != 1 || got.Segments[0].EndTime == nil || !got.Segments[0].EndTime.Equal(time.Date(2024, 4, 1, 18, 0, 0, 0, loc)) {
+		t.Errorf("attendance after approval = %+v, want clocked out at 18:00", got)
+	}
+	var logs []model.AuditLog
+	if err := db.Find(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || logs[0].EntityID != attendance.ID || logs[0].Source != model.AuditSourceManualEdit {
+		t.Errorf("audit logs = %+v, want one manual edit of the attendance", logs)
+	}
+	saved, err := service.findCorrection(correction.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Status != model.CorrectionApproved || saved.ReviewNote != "確認しました" {
+		t.Errorf("correction after approval = %+v, want approved", saved)
+	}
+
+	// 承認済みの申請は再度承認できない
+	if _, err := service.ApproveCorrection(Actor{EmployeeID: 99, Role: model.RoleOwner}, correction.ID, ""); err == nil {
+		t.Errorf("ApproveCorrection() on approved correction error = nil")
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// 従業員の一括登録で必須の列
+var employeeImportRequiredColumns = []string{"name", "login_id", "competent_store_id"}
+
+// 一括登録する従業員（Line はCSVの行番号、JSONの場合は配列の何件目か）
+type EmployeeInput struct {
+	Line             int    `json:"-"`
+	Name             string `json:"name"`
+	LoginID          string `json:"login_id"`
+	RoleID           int    `json:"role_id"`            // 省略した場合は従業員
+	HourlyPay        int    `json:"hourly_pay"`         // 省略した場合は担当店舗の最低賃金
+	CompetentStoreID int    `json:"competent_store_id"` // 担当店舗
+}
+
+// 登録した（dry-run の場合は登録する）従業員
+type OnboardedEmployee struct {
+	Line            int    `json:"line"`
+	ID              uint   `json:"id,omitempty"`
+	Name            string `json:"name"`
+	InitialPassword string `json:"initial_password,omitempty"` // 登録時にのみ返す
+}
+
+// 従業員の一括登録の結果（エラーが1件でもあれば何も登録しない）
+type EmployeeImportResult struct {
+	DryRun    bool                `json:"dry_run"`
+	Employees []OnboardedEmployee `json:"employees"`
+	Errors    []ImportLineError   `json:"errors"`
+}
+
+// 行番号を付けたエラーを追加
+func (r *EmployeeImportResult) addError(line int, err error) {
+	r.Errors = append(r.Errors, ImportLineError{Line: line, Message: err.Error()})
+}
+
+// 一括登録する従業員のCSVを読み込む（形式が不正な行は行ごとのエラーとして result に追加する）
+func parseEmployeeCSV(r io.Reader, result *EmployeeImportResult) ([]EmployeeInput, error) {
+	inputs := []EmployeeInput{}
+	err := readImportCSV(r, employeeImportRequiredColumns, result.addError, func(line int, value func(string) string) error {
+		input := EmployeeInput{Line: line, Name: value("name"), LoginID: value("login_id")}
+		for _, field := range []struct {
+			column string
+			label  string
+			dest   *int
+		}{
+			{"role_id", "権限", &input.RoleID},
+			{"hourly_pay", "時給", &input.HourlyPay},
+			{"competent_store_id", "担当店舗", &input.CompetentStoreID},
+		} {
+			if value(field.column) == "" {
+				continue
+			}
+			n, err := strconv.Atoi(value(field.column))
+			if err != nil {
+				return fmt.Errorf("%sが不正です: %s", field.label, value(field.column))
+			}
+			*field.dest = n
+		}
+		inputs = append(inputs, input)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
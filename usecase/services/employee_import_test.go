@@ -0,0 +1,135 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/techyoichiro/jobreco-api/crypto"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	repository "github.com/techyoichiro/jobreco-api/infra/database/repositories"
+)
+
+func TestParseEmployeeCSV(t *testing.T) {
+	tests := []struct {
+		name       string
+		csv        string
+		want       []EmployeeInput
+		wantErrors []ImportLineError
+	}{
+		{"正常", "name,login_id,role_id,hourly_pay,competent_store_id\n山田 太郎,taro@example.com,2,1200,1\n鈴木 花子,hanako@example.com,,,2\n", []EmployeeInput{
+			{Line: 2, Name: "山田 太郎", LoginID: "taro@example.com", RoleID: 2, HourlyPay: 1200, CompetentStoreID: 1},
+			{Line: 3, Name: "鈴木 花子", LoginID: "hanako@example.com", CompetentStoreID: 2},
+		}, nil},
+		{"任意の列の省略", "login_id,name,competent_store_id\ntaro@example.com,山田 太郎,1\n", []EmployeeInput{
+			{Line: 2, Name: "山田 太郎", LoginID: "taro@example.com", CompetentStoreID: 1},
+		}, nil},
+		{"必須の列がない", "name,login_id\n山田 太郎,taro@example.com\n", []EmployeeInput{}, []ImportLineError{
+			{1, "見出しに competent_store_id の列がありません"},
+		}},
+		{"数値の誤り", "name,login_id,hourly_pay,competent_store_id\n山田 太郎,taro@example.com,千円,1\n鈴木 花子,hanako@example.com,1100,本店\n", []EmployeeInput{}, []ImportLineError{
+			{2, "時給が不正です: 千円"},
+			{3, "担当店舗が不正です: 本店"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &EmployeeImportResult{}
+			got, err := parseEmployeeCSV(strings.NewReader(tt.csv), result)
+			if err != nil {
+				t.Fatalf("parseEmployeeCSV() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseEmployeeCSV() = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(result.Errors, tt.wantErrors) {
+				t.Errorf("parseEmployeeCSV() errors = %v, want %v", result.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestNewOnboardedEmployee(t *testing.T) {
+	owner := Actor{EmployeeID: 1, Role: model.RoleOwner}
+	manager := Actor{EmployeeID: 2, Role: model.RoleStoreManager, StoreID: 1}
+	minimum := &model.MinimumWage{Prefecture: "神奈川県", HourlyWage: 1162}
+
+	tests := []struct {
+		name          string
+		actor         Actor
+		input         EmployeeInput
+		minimum       *model.MinimumWage
+		wantRole      int
+		wantHourlyPay int
+		wantErr       bool
+	}{
+		{"既定の権限と時給", owner, EmployeeInput{Name: " 山田 太郎 ", LoginID: "taro@example.com", CompetentStoreID: 2}, minimum, int(model.RoleStaff), 1162, false},
+		{"権限と時給の指定", owner, EmployeeInput{Name: "山田 太郎", LoginID: "taro@example.com", RoleID: 2, HourlyPay: 1300, CompetentStoreID: 2}, minimum, int(model.RoleStoreManager), 1300, false},
+		{"店長が担当店舗の従業員を登録", manager, EmployeeInput{Name: "山田 太郎", LoginID: "taro@example.com", CompetentStoreID: 1}, minimum, int(model.RoleStaff), 1162, false},
+		{"店長が他店舗の従業員を登録", manager, EmployeeInput{Name: "山田 太郎", LoginID: "taro@example.com", CompetentStoreID: 2}, minimum, 0, 0, true},
+		{"店長が店長を登録", manager, EmployeeInput{Name: "山田 太郎", LoginID: "taro@example.com", RoleID: 2, CompetentStoreID: 1}, minimum, 0, 0, true},
+		{"店長がオーナーを登録", manager, EmployeeInput{Name: "山田 太郎", LoginID: "taro@example.com", RoleID: 3, CompetentStoreID: 1}, minimum, 0, 0, true},
+		{"存在しない権限", owner, EmployeeInput{Name: "山田 太郎", LoginID: "taro@example.com", RoleID: 9, CompetentStoreID: 1}, minimum, 0, 0, true},
+		{"氏名なし", owner, EmployeeInput{Name: " ", LoginID: "taro@example.com", CompetentStoreID: 1}, minimum, 0, 0, true},
+		{"ログインIDなし", owner, EmployeeInput{Name: "山田 太郎", CompetentStoreID: 1}, minimum, 0, 0, true},
+		{"負の時給", owner, EmployeeInput{Name: "山田 太郎", LoginID: "taro@example.com", HourlyPay: -1, CompetentStoreID: 1}, minimum, 0, 0, true},
+		{"最低賃金を下回る時給", owner, EmployeeInput{Name: "山田 太郎", LoginID: "taro@example.com", HourlyPay: 1100, CompetentStoreID: 1}, minimum, 0, 0, true},
+		{"最低賃金の登録なしで時給を指定", owner, EmployeeInput{Name: "山田 太郎", LoginID: "taro@example.com", HourlyPay: 1100, CompetentStoreID: 1}, nil, int(model.RoleStaff), 1100, false},
+		{"最低賃金の登録なしで時給を省略", owner, EmployeeInput{Name: "山田 太郎", LoginID: "taro@example.com", CompetentStoreID: 1}, nil, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newOnboardedEmployee(tt.actor, tt.input, tt.minimum)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newOnboardedEmployee() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Name != strings.TrimSpace(tt.input.Name) || got.RoleID != tt.wantRole || got.HourlyPay != tt.wantHourlyPay {
+				t.Errorf("newOnboardedEmployee() = %+v, want role %d hourly pay %d", got, tt.wantRole, tt.wantHourlyPay)
+			}
+		})
+	}
+}
+
+func TestOnboardEmployeesLoginID(t *testing.T) {
+	t.Setenv("ENCRYPTION_KEY", "0123456789abcdef0123456789abcdef")
+	db := newTestDB(t)
+	storeRepo := repository.NewStoreRepository(db)
+	service := NewEmployeeService(repository.NewEmployeeRepository(db), storeRepo, repository.NewAttendanceRepository(db), repository.NewMinimumWageRepository(db))
+
+	store := &model.Store{Name: "本店"}
+	if err := storeRepo.CreateStore(store); err != nil {
+		t.Fatal(err)
+	}
+	// ログインIDは暗号化して登録されている
+	loginID, err := crypto.EncryptEmail("taro@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&model.Employee{Name: "山田 太郎", LoginID: loginID, Password: "x", RoleID: int(model.RoleStaff), HourlyPay: 1200}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	storeID := int(store.ID)
+	inputs := []EmployeeInput{
+		{Line: 2, Name: "山田 太郎", LoginID: "taro@example.com", CompetentStoreID: storeID},
+		{Line: 3, Name: "鈴木 次郎", LoginID: "jiro@example.com", CompetentStoreID: storeID},
+		{Line: 4, Name: "鈴木 次郎", LoginID: "jiro@example.com", CompetentStoreID: storeID},
+	}
+	result, err := service.OnboardEmployees(Actor{EmployeeID: 1, Role: model.RoleOwner}, inputs, true)
+	if err != nil {
+		t.Fatalf("OnboardEmployees() error = %v", err)
+	}
+
+	want := []ImportLineError{
+		{Line: 2, Message: "このログインIDは既に登録されています"},
+		{Line: 4, Message: "3行目と同じログインIDです"},
+	}
+	if !reflect.DeepEqual(result.Errors, want) {
+		t.Errorf("Errors = %+v, want %+v", result.Errors, want)
+	}
+}
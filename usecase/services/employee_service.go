@@ -0,0 +1,401 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/techyoichiro/jobreco-api/crypto"
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+var (
+	ErrEmployeeNotFound    = errors.New("従業員が見つかりません")
+	ErrEmployeeDeactivated = errors.New("退職済みの従業員です")
+)
+
+// 一括登録で発行する初期パスワードの長さ
+const initialPasswordLength = 12
+
+type EmployeeService struct {
+	repo           repositories.EmployeeRepository
+	storeRepo      repositories.StoreRepository
+	attendanceRepo repositories.AttendanceRepository
+	minimumRepo    repositories.MinimumWageRepository
+}
+
+func NewEmployeeService(repo repositories.EmployeeRepository, storeRepo repositories.StoreRepository, attendanceRepo repositories.AttendanceRepository, minimumRepo repositories.MinimumWageRepository) *EmployeeService {
+	return &EmployeeService{repo: repo, storeRepo: storeRepo, attendanceRepo: attendanceRepo, minimumRepo: minimumRepo}
+}
+
+// 勤怠記録の保存期間（環境変数 ATTENDANCE_RETENTION_YEARS で変更可能）
+// 労働基準法の保存期間（5年、経過措置により当分の間は3年）に合わせ、既定は5年
+func retentionYears() int {
+	if years, err := strconv.Atoi(os.Getenv("ATTENDANCE_RETENTION_YEARS")); err == nil && years > 0 {
+		return years
+	}
+	return 5
+}
+
+// 在籍中の従業員を取得（退職済みの場合はエラー）
+func findActiveEmployee(repo repositories.EmployeeRepository, employeeID uint) (*model.Employee, error) {
+	employee, err := repo.FindEmpByEmpID(int(employeeID))
+	if err != nil {
+		return nil, err
+	}
+	if employee == nil {
+		return nil, ErrEmployeeNotFound
+	}
+	if !employee.IsActive() {
+		return nil, ErrEmployeeDeactivated
+	}
+	return employee, nil
+}
+
+// 従業員を一括登録し、初期パスワードを発行する
+// エラーが1件でもあれば何も登録せず、dryRun の場合は検証結果だけを返す
+func (s *EmployeeService) OnboardEmployees(actor Actor, inputs []EmployeeInput, dryRun bool) (*EmployeeImportResult, error) {
+	result := &EmployeeImportResult{DryRun: dryRun, Employees: []OnboardedEmployee{}, Errors: []ImportLineError{}}
+	return s.onboard(actor, inputs, result)
+}
+
+// CSVから従業員を一括登録する
+func (s *EmployeeService) ImportEmployeesCSV(actor Actor, r io.Reader, enc CSVEncoding, dryRun bool) (*EmployeeImportResult, error) {
+	result := &EmployeeImportResult{DryRun: dryRun, Employees: []OnboardedEmployee{}, Errors: []ImportLineError{}}
+	inputs, err := parseEmployeeCSV(decodeCSV(r, enc), result)
+	if err != nil {
+		return nil, err
+	}
+	return s.onboard(actor, inputs, result)
+}
+
+// 従業員の内容を検証し、エラーがなく dry-run でなければ登録する
+func (s *EmployeeService) onboard(actor Actor, inputs []EmployeeInput, result *EmployeeImportResult) (*EmployeeImportResult, error) {
+	if len(inputs) == 0 && len(result.Errors) == 0 {
+		result.addError(1, errors.New("登録する従業員がありません"))
+		return result, nil
+	}
+
+	today := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
+	minimums, err := loadMinimumWageTable(s.minimumRepo, s.storeRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	registered, err := registeredLoginIDs(s.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	stores := map[int]error{}
+	loginIDs := map[string]int{}
+	employees := make([]*model.Employee, 0, len(inputs))
+	for _, input := range inputs {
+		employee, err := newOnboardedEmployee(actor, input, minimums.forStore(uint(input.CompetentStoreID), today))
+		if err != nil {
+			result.addError(input.Line, err)
+			continue
+		}
+
+		storeErr, ok := stores[input.CompetentStoreID]
+		if !ok {
+			_, storeErr = findActiveStore(s.storeRepo, uint(input.CompetentStoreID))
+			if storeErr != nil && !errors.Is(storeErr, ErrStoreNotFound) && !errors.Is(storeErr, ErrStoreArchived) {
+				return nil, storeErr
+			}
+			stores[input.CompetentStoreID] = storeErr
+		}
+		if storeErr != nil {
+			result.addError(input.Line, fmt.Errorf("担当店舗%d: %w", input.CompetentStoreID, storeErr))
+			continue
+		}
+
+		if registered[employee.LoginID] {
+			result.addError(input.Line, errors.New("このログインIDは既に登録されています"))
+			continue
+		}
+		if line, ok := loginIDs[employee.LoginID]; ok {
+			result.addError(input.Line, fmt.Errorf("%d行目と同じログインIDです", line))
+			continue
+		}
+		loginIDs[employee.LoginID] = input.Line
+
+		employees = append(employees, employee)
+		result.Employees = append(result.Employees, OnboardedEmployee{Line: input.Line, Name: employee.Name})
+	}
+
+	sort.SliceStable(result.Errors, func(i, j int) bool { return result.Errors[i].Line < result.Errors[j].Line })
+	if len(result.Errors) > 0 || result.DryRun {
+		return result, nil
+	}
+
+	imports := make([]repositories.EmployeeImport, 0, len(employees))
+	for i, employee := range employees {
+		password, err := crypto.GeneratePassword(initialPasswordLength)
+		if err != nil {
+			return nil, err
+		}
+		if employee.Password, err = crypto.PasswordEncrypt(password); err != nil {
+			return nil, err
+		}
+		if employee.LoginID, err = crypto.EncryptEmail(employee.LoginID); err != nil {
+			return nil, err
+		}
+		result.Employees[i].InitialPassword = password
+
+		history, err := newWageHistory(actor, 0, employee.HourlyPay, today, "登録時の時給")
+		if err != nil {
+			return nil, err
+		}
+		entry := employeeAudit(actor, employee)
+		entry.Source = model.AuditSourceImport
+		log, err := buildAudit(entry, nil, employee)
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, repositories.EmployeeImport{Employee: employee, WageHistory: history, AuditLog: log})
+	}
+
+	if err := s.repo.ImportEmployees(imports); err != nil {
+		return nil, err
+	}
+	for i, employee := range employees {
+		result.Employees[i].ID = employee.ID
+	}
+	return result, nil
+}
+
+// 登録済みのログインID（ログインIDは暗号化して保存しているため、復号して比較する）
+func registeredLoginIDs(repo repositories.EmployeeRepository) (map[string]bool, error) {
+	encrypted, err := repo.GetLoginIDs()
+	if err != nil {
+		return nil, err
+	}
+	loginIDs := make(map[string]bool, len(encrypted))
+	for _, value := range encrypted {
+		// 復号できない値は暗号化される前に登録されたログインIDとして扱う
+		if loginID, err := crypto.DecryptEmail(value); err == nil && loginID != "" {
+			value = loginID
+		}
+		loginIDs[value] = true
+	}
+	return loginIDs, nil
+}
+
+// 一括登録する従業員の内容を検証し、登録する従業員を作成（店舗の存在は別に確認する）
+// minimum は担当店舗の都道府県の最低賃金で、時給を省略した場合の初期値にもなる
+func newOnboardedEmployee(actor Actor, input EmployeeInput, minimum *model.MinimumWage) (*model.Employee, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, errors.New("氏名を入力してください")
+	}
+	if utf8.RuneCountInString(name) > 100 {
+		return nil, errors.New("氏名は100文字以内で入力してください")
+	}
+	loginID := strings.TrimSpace(input.LoginID)
+	if loginID == "" {
+		return nil, errors.New("ログインIDを入力してください")
+	}
+
+	role := model.Role(input.RoleID)
+	if role == 0 {
+		role = model.RoleStaff
+	}
+	if !role.IsValid() {
+		return nil, fmt.Errorf("権限が不正です: %d", input.RoleID)
+	}
+	// オーナー以外は自分と同じ権限も付与できない（店長が店長を増やせないようにする）
+	if actor.Role != model.RoleOwner && role >= actor.Role {
+		return nil, errors.New("自分と同じか上位の権限は付与できません")
+	}
+
+	hourlyPay := input.HourlyPay
+	if hourlyPay < 0 {
+		return nil, errors.New("時給は1円以上で入力してください")
+	}
+	if hourlyPay == 0 {
+		if minimum == nil {
+			return nil, errors.New("最低賃金が登録されていないため、時給を入力してください")
+		}
+		hourlyPay = minimum.HourlyWage
+	}
+	if err := checkMinimumWage(hourlyPay, minimum); err != nil {
+		return nil, err
+	}
+
+	employee := &model.Employee{
+		Name:             name,
+		LoginID:          loginID,
+		RoleID:           int(role),
+		HourlyPay:        hourlyPay,
+		CompetentStoreID: &input.CompetentStoreID,
+	}
+	if err := actor.authorize(model.PermManageEmployees, employee); err != nil {
+		return nil, fmt.Errorf("担当店舗%dの従業員を登録する権限がありません", input.CompetentStoreID)
+	}
+	return employee, nil
+}
+
+// 担当店舗を変更できるか確認
+// 担当店舗は店長の担当範囲を決めるため、自分自身の担当店舗を変更できるのはオーナーのみとし、
+// 店長は担当店舗の自分より下位の従業員を、自分の担当店舗に割り当てることだけができる
+func authorizeStoreAssignment(actor Actor, employee *model.Employee, storeID uint) error {
+	if actor.Role != model.RoleOwner {
+		if employee.ID == actor.EmployeeID {
+			return fmt.Errorf("%w（自分の担当店舗は変更できません）", ErrForbidden)
+		}
+		if model.Role(employee.RoleID) >= actor.Role {
+			return ErrForbidden
+		}
+	}
+	if err := actor.authorize(model.PermAssignStore, employee); err != nil {
+		return err
+	}
+	return actor.authorizeStore(model.PermAssignStore, storeID)
+}
+
+// 従業員の担当店舗を変更
+func (s *EmployeeService) AssignStore(actor Actor, employeeID uint, storeID uint) (*model.Employee, error) {
+	employee, err := s.repo.FindEmpByEmpID(int(employeeID))
+	if err != nil {
+		return nil, err
+	}
+	if employee == nil {
+		return nil, ErrEmployeeNotFound
+	}
+	if err := authorizeStoreAssignment(actor, employee, storeID); err != nil {
+		return nil, err
+	}
+	if employee.StoreID() == storeID {
+		return employee, nil
+	}
+	if _, err := findActiveStore(s.storeRepo, storeID); err != nil {
+		return nil, err
+	}
+
+	// 変更後の担当店舗の都道府県の最低賃金を下回らないか確認
+	today := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
+	minimums, err := loadMinimumWageTable(s.minimumRepo, s.storeRepo)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMinimumWage(employee.HourlyPay, minimums.forStore(storeID, today)); err != nil {
+		return nil, err
+	}
+
+	before, err := snapshotOf(employee)
+	if err != nil {
+		return nil, err
+	}
+	competentStoreID := int(storeID)
+	employee.CompetentStoreID = &competentStoreID
+	log, err := buildAudit(employeeAudit(actor, employee), before, employee)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveEmployeeChange(repositories.EmployeeChange{Employee: employee, AuditLog: log}); err != nil {
+		return nil, err
+	}
+	return employee, nil
+}
+
+// 退職・在籍に戻す操作の対象の従業員を取得
+func (s *EmployeeService) findManagedEmployee(actor Actor, employeeID uint) (*model.Employee, error) {
+	employee, err := s.repo.FindEmpByEmpID(int(employeeID))
+	if err != nil {
+		return nil, err
+	}
+	if employee == nil {
+		return nil, ErrEmployeeNotFound
+	}
+	if err := actor.authorize(model.PermManageEmployees, employee); err != nil {
+		return nil, err
+	}
+	if employee.ID == actor.EmployeeID {
+		return nil, errors.New("自分自身の退職処理はできません")
+	}
+	if actor.Role != model.RoleOwner && model.Role(employee.RoleID) >= actor.Role {
+		return nil, ErrForbidden
+	}
+	return employee, nil
+}
+
+// 退職処理（ログイン・打刻を停止し、勤怠記録は保存期限まで残す）
+func (s *EmployeeService) DeactivateEmployee(actor Actor, employeeID uint, reason string) (*model.Employee, error) {
+	employee, err := s.findManagedEmployee(actor, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if !employee.IsActive() {
+		return nil, errors.New("既に退職処理済みの従業員です")
+	}
+
+	// 退勤していない勤務が残っている場合は先に勤怠を修正する
+	latest, err := s.attendanceRepo.FindLatestAttendance(employee.ID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.StatusID != model.StatusClockedOut {
+		return nil, errors.New("退勤していない勤務があります。勤怠を修正してから退職処理を行ってください")
+	}
+
+	before, err := snapshotOf(employee)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
+	retentionUntil := time.Date(now.Year()+retentionYears(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	employee.DeactivatedAt = &now
+	employee.DeactivatedByID = auditActor(actor)
+	employee.DeactivationReason = strings.TrimSpace(reason)
+	employee.RetentionUntil = &retentionUntil
+	entry := employeeAudit(actor, employee)
+	entry.Note = "退職処理"
+	log, err := buildAudit(entry, before, employee)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateEmploymentStatus(employee, log); err != nil {
+		return nil, err
+	}
+	return employee, nil
+}
+
+// 退職処理を取り消し、在籍中に戻す
+func (s *EmployeeService) ReactivateEmployee(actor Actor, employeeID uint) (*model.Employee, error) {
+	employee, err := s.findManagedEmployee(actor, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if employee.IsActive() {
+		return nil, errors.New("在籍中の従業員です")
+	}
+
+	before, err := snapshotOf(employee)
+	if err != nil {
+		return nil, err
+	}
+
+	employee.DeactivatedAt = nil
+	employee.DeactivatedByID = nil
+	employee.DeactivationReason = ""
+	employee.RetentionUntil = nil
+	entry := employeeAudit(actor, employee)
+	entry.Note = "退職処理の取り消し"
+	log, err := buildAudit(entry, before, employee)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateEmploymentStatus(employee, log); err != nil {
+		return nil, err
+	}
+	return employee, nil
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	repository "github.com/techyoichiro/jobreco-api/infra/database/repositories"
+)
+
+func TestAssignStore(t *testing.T) {
+	db := newTestDB(t)
+	empRepo := repository.NewEmployeeRepository(db)
+	storeRepo := repository.NewStoreRepository(db)
+	employeeService := NewEmployeeService(empRepo, storeRepo, repository.NewAttendanceRepository(db), repository.NewMinimumWageRepository(db))
+	authService := NewAuthService(empRepo, storeRepo, repository.NewAuditRepository(db), repository.NewMinimumWageRepository(db))
+
+	stores := []model.Store{{Name: "本店"}, {Name: "支店"}}
+	if err := db.Create(&stores).Error; err != nil {
+		t.Fatal(err)
+	}
+	own, other := stores[0].ID, stores[1].ID
+	newEmployee := func(loginID string, role model.Role, storeID uint) *model.Employee {
+		competentStoreID := int(storeID)
+		employee := &model.Employee{Name: loginID, LoginID: loginID, Password: "x", RoleID: int(role), HourlyPay: 1300, CompetentStoreID: &competentStoreID}
+		if err := db.Create(employee).Error; err != nil {
+			t.Fatal(err)
+		}
+		return employee
+	}
+	manager := newEmployee("manager", model.RoleStoreManager, own)
+	staff := newEmployee("staff", model.RoleStaff, own)
+	otherStaff := newEmployee("other", model.RoleStaff, other)
+	otherManager := newEmployee("other-manager", model.RoleStoreManager, own)
+	managerActor := Actor{EmployeeID: manager.ID, Role: model.RoleStoreManager, StoreID: int(own)}
+	owner := Actor{EmployeeID: 100, Role: model.RoleOwner}
+
+	tests := []struct {
+		name     string
+		actor    Actor
+		employee *model.Employee
+		storeID  uint
+		wantErr  bool
+	}{
+		{"店長が自分の担当店舗を変更", managerActor, manager, other, true},
+		{"店長が担当店舗の従業員を担当店舗に割り当て", managerActor, staff, own, false},
+		{"店長が担当店舗の従業員を他店舗に移す", managerActor, staff, other, true},
+		{"店長が他店舗の従業員を担当店舗に移す", managerActor, otherStaff, own, true},
+		{"店長が同じ店舗の店長を移す", managerActor, otherManager, own, true},
+		{"オーナーが従業員を他店舗に移す", owner, staff, other, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			employee, err := employeeService.AssignStore(tt.actor, tt.employee.ID, tt.storeID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AssignStore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrForbidden) {
+				t.Errorf("AssignStore() error = %v, want ErrForbidden", err)
+			}
+			if !tt.wantErr && employee.StoreID() != tt.storeID {
+				t.Errorf("AssignStore() store = %d, want %d", employee.StoreID(), tt.storeID)
+			}
+		})
+	}
+
+	// アカウント設定からも店長は自分の担当店舗を変更できない
+	if err := authService.UpdateAccount(managerActor, int(manager.ID), manager.Name, nil, int(other)); !errors.Is(err, ErrForbidden) {
+		t.Errorf("UpdateAccount() error = %v, want ErrForbidden", err)
+	}
+	if got, err := empRepo.FindEmpByEmpID(int(manager.ID)); err != nil || got.StoreID() != own {
+		t.Errorf("manager store = %v, %v, want %d", got, err, own)
+	}
+}
+
+func TestDeactivateEmployee(t *testing.T) {
+	db := newTestDB(t)
+	service := NewEmployeeService(repository.NewEmployeeRepository(db), repository.NewStoreRepository(db), repository.NewAttendanceRepository(db), repository.NewMinimumWageRepository(db))
+
+	employee := &model.Employee{Name: "山田", LoginID: "yamada", Password: "x", RoleID: int(model.RoleStaff), HourlyPay: 1200}
+	if err := db.Create(employee).Error; err != nil {
+		t.Fatal(err)
+	}
+	owner := Actor{EmployeeID: 100, Role: model.RoleOwner}
+
+	// 退職処理・取り消しと監査ログが合わせて保存される
+	steps := []struct {
+		name       string
+		run        func() (*model.Employee, error)
+		wantActive bool
+		wantNote   string
+	}{
+		{"退職処理", func() (*model.Employee, error) {
+			return service.DeactivateEmployee(owner, employee.ID, "一身上の都合")
+		}, false, "退職処理"},
+		{"退職処理の取り消し", func() (*model.Employee, error) { return service.ReactivateEmployee(owner, employee.ID) }, true, "退職処理の取り消し"},
+	}
+	for i, step := range steps {
+		if _, err := step.run(); err != nil {
+			t.Fatalf("%s error = %v", step.name, err)
+		}
+		var saved model.Employee
+		if err := db.First(&saved, employee.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		if saved.IsActive() != step.wantActive {
+			t.Errorf("%s: active = %v, want %v", step.name, saved.IsActive(), step.wantActive)
+		}
+		var logs []model.AuditLog
+		if err := db.Order("id").Find(&logs).Error; err != nil {
+			t.Fatal(err)
+		}
+		if len(logs) != i+1 || logs[i].EntityID != employee.ID || logs[i].Note != step.wantNote {
+			t.Errorf("%s: audit logs = %+v, want %q recorded", step.name, logs, step.wantNote)
+		}
+	}
+}
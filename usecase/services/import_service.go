@@ -0,0 +1,265 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+type ImportService struct {
+	summaryRepo repositories.SummaryRepository
+	empRepo     repositories.EmployeeRepository
+	storeRepo   repositories.StoreRepository
+	periodRepo  repositories.PeriodRepository
+	maxShift    time.Duration
+}
+
+func NewImportService(summaryRepo repositories.SummaryRepository, empRepo repositories.EmployeeRepository, storeRepo repositories.StoreRepository, periodRepo repositories.PeriodRepository) *ImportService {
+	return &ImportService{summaryRepo: summaryRepo, empRepo: empRepo, storeRepo: storeRepo, periodRepo: periodRepo, maxShift: maxShiftLength()}
+}
+
+// 過去の勤怠をCSVから取り込む
+// 従業員・勤務日ごとに既存の勤怠があれば置き換え、なければ作成する
+// エラーが1件でもあれば何も保存せず、dryRun の場合は検証結果だけを返す
+func (s *ImportService) ImportAttendanceCSV(actor Actor, r io.Reader, enc CSVEncoding, dryRun bool) (*ImportResult, error) {
+	result := &ImportResult{DryRun: dryRun, Errors: []ImportLineError{}}
+	rows, err := parseImportCSV(decodeCSV(r, enc), result)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err = s.checkRows(actor, rows, result)
+	if err != nil {
+		return nil, err
+	}
+
+	// 従業員ごとに既存の勤怠と合わせて検証する
+	groups := groupImportRows(rows)
+	byEmployee := map[uint][]*importGroup{}
+	employeeIDs := []uint{}
+	for _, group := range groups {
+		if _, ok := byEmployee[group.EmployeeID]; !ok {
+			employeeIDs = append(employeeIDs, group.EmployeeID)
+		}
+		byEmployee[group.EmployeeID] = append(byEmployee[group.EmployeeID], group)
+	}
+
+	imports := []repositories.AttendanceImport{}
+	for _, employeeID := range employeeIDs {
+		planned, err := s.planEmployee(actor, employeeID, byEmployee[employeeID], result)
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, planned...)
+	}
+
+	sort.SliceStable(result.Errors, func(i, j int) bool { return result.Errors[i].Line < result.Errors[j].Line })
+	if len(result.Errors) > 0 || dryRun {
+		return result, nil
+	}
+	if err := s.summaryRepo.ImportAttendances(imports); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 行ごとに従業員・店舗の存在と権限、勤務日が未来でないかを確認し、問題のない行を返す
+func (s *ImportService) checkRows(actor Actor, rows []importRow, result *ImportResult) ([]importRow, error) {
+	employees := map[uint]*model.Employee{}
+	stores := map[uint]*model.Store{}
+	valid := make([]importRow, 0, len(rows))
+	for _, row := range rows {
+		employee, ok := employees[row.EmployeeID]
+		if !ok {
+			var err error
+			if employee, err = s.empRepo.FindEmpByEmpID(int(row.EmployeeID)); err != nil {
+				return nil, err
+			}
+			employees[row.EmployeeID] = employee
+		}
+		if employee == nil {
+			result.addError(row.Line, fmt.Errorf("従業員が見つかりません: %d", row.EmployeeID))
+			continue
+		}
+		if err := actor.authorize(model.PermImportAttendance, employee); err != nil {
+			result.addError(row.Line, fmt.Errorf("従業員%dの勤怠を取り込む権限がありません", row.EmployeeID))
+			continue
+		}
+
+		store, ok := stores[row.StoreID]
+		if !ok {
+			var err error
+			store, err = findStore(s.storeRepo, row.StoreID)
+			if err != nil && !errors.Is(err, ErrStoreNotFound) {
+				return nil, err
+			}
+			stores[row.StoreID] = store
+		}
+		if store == nil {
+			result.addError(row.Line, fmt.Errorf("店舗が見つかりません: %d", row.StoreID))
+			continue
+		}
+		if row.WorkDate.Format("2006-01-02") > businessDate(store, time.Now()).Format("2006-01-02") {
+			result.addError(row.Line, errors.New("未来の勤務日は取り込めません"))
+			continue
+		}
+		valid = append(valid, row)
+	}
+	return valid, nil
+}
+
+// 勤怠の時間帯（重なりの確認用）
+type importSpan struct {
+	Line     int // 取り込む行（既存の勤怠の場合は0）
+	WorkDate string
+	Start    time.Time
+	End      time.Time
+}
+
+// 従業員の取り込む勤怠を検証し、保存する内容を作成する
+func (s *ImportService) planEmployee(actor Actor, employeeID uint, groups []*importGroup, result *ImportResult) ([]repositories.AttendanceImport, error) {
+	from, to := groups[0].WorkDate, groups[0].WorkDate
+	for _, group := range groups {
+		if group.WorkDate.Before(from) {
+			from = group.WorkDate
+		}
+		if group.WorkDate.After(to) {
+			to = group.WorkDate
+		}
+	}
+
+	// 前後の日の勤務とも重なりを確認するため1日広く取得する
+	existing, err := s.summaryRepo.GetAttendanceBetween(employeeID, from.AddDate(0, 0, -1), to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	existingByDate := map[string]*model.Attendance{}
+	for i := range existing {
+		existingByDate[existing[i].WorkDate.Format("2006-01-02")] = &existing[i]
+	}
+
+	type planned struct {
+		line   int
+		before *model.Attendance
+		after  *model.Attendance
+	}
+	plans := []planned{}
+	spans := []importSpan{}
+	imported := map[string]bool{}
+	for _, group := range groups {
+		line := group.Rows[0].Line
+		date := group.WorkDate.Format("2006-01-02")
+		imported[date] = true
+
+		before := existingByDate[date]
+		current := &model.Attendance{WorkDate: group.WorkDate}
+		if before != nil {
+			current.ID = before.ID
+		}
+		attendance, err := buildAttendanceEdit(s.storeRepo, current, group.edit())
+		if err != nil {
+			result.addError(line, err)
+			continue
+		}
+		attendance.EmployeeID = employeeID
+		attendance.WorkDate = group.WorkDate
+		if err := validateImportedAttendance(attendance, s.maxShift); err != nil {
+			result.addError(line, err)
+			continue
+		}
+
+		// 締められた月の勤怠は置き換えも作成もできない
+		attendances := []*model.Attendance{attendance}
+		if before != nil {
+			attendances = append(attendances, before)
+		}
+		if err := ensureAttendanceOpen(s.periodRepo, attendances...); err != nil {
+			if !errors.Is(err, ErrPeriodClosed) {
+				return nil, err
+			}
+			result.addError(line, err)
+			continue
+		}
+
+		start, end := attendanceSpan(attendance)
+		spans = append(spans, importSpan{Line: line, WorkDate: date, Start: start, End: end})
+		plans = append(plans, planned{line: line, before: before, after: attendance})
+	}
+
+	// 取り込まない勤務日の既存の勤怠と、取り込む勤怠どうしの重なりを確認する
+	for i := range existing {
+		date := existing[i].WorkDate.Format("2006-01-02")
+		if imported[date] || len(existing[i].Segments) == 0 {
+			continue
+		}
+		start, end := attendanceSpan(&existing[i])
+		spans = append(spans, importSpan{WorkDate: date, Start: start, End: end})
+	}
+	overlapped := overlappingLines(spans)
+	for _, span := range spans {
+		if other, ok := overlapped[span.Line]; ok && span.Line != 0 {
+			result.addError(span.Line, fmt.Errorf("%sの勤務と時間が重なっています", other))
+		}
+	}
+
+	imports := make([]repositories.AttendanceImport, 0, len(plans))
+	for _, plan := range plans {
+		if _, ok := overlapped[plan.line]; ok {
+			continue
+		}
+
+		var before auditSnapshot
+		if plan.before != nil {
+			if before, err = snapshotOf(plan.before); err != nil {
+				return nil, err
+			}
+		}
+		log, err := buildAudit(model.AuditLog{
+			ActorID:    auditActor(actor),
+			EmployeeID: employeeID,
+			Entity:     model.AuditEntityAttendance,
+			EntityID:   plan.after.ID,
+			Source:     model.AuditSourceImport,
+		}, before, plan.after)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case plan.before == nil:
+			result.Created++
+		case log == nil:
+			// 既存の勤怠と同じ内容のため保存しない
+			result.Unchanged++
+			continue
+		default:
+			result.Updated++
+		}
+		imports = append(imports, repositories.AttendanceImport{Attendance: plan.after, AuditLog: log})
+	}
+	return imports, nil
+}
+
+// 時間が重なっている取り込む行と、重なっている相手の勤務日
+func overlappingLines(spans []importSpan) map[int]string {
+	sorted := append([]importSpan(nil), spans...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	overlapped := map[int]string{}
+	for i := range sorted {
+		for j := i + 1; j < len(sorted) && sorted[j].Start.Before(sorted[i].End); j++ {
+			if sorted[i].Line != 0 {
+				overlapped[sorted[i].Line] = sorted[j].WorkDate
+			}
+			if sorted[j].Line != 0 {
+				overlapped[sorted[j].Line] = sorted[i].WorkDate
+			}
+		}
+	}
+	return overlapped
+}
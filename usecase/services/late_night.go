@@ -0,0 +1,85 @@
+package services
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// 深夜時間帯（22:00〜翌5:00）
+const (
+	lateNightStartHour = 22
+	lateNightEndHour   = 5
+)
+
+// 時間帯
+type timeRange struct {
+	start time.Time
+	end   time.Time
+}
+
+// 深夜時間帯の勤務時間を計算（休憩と重なる部分は除く）
+// 勤務区間が日付をまたぐ場合や5時前に始まる場合も、時間帯ごとの重なりで計算する
+func calculateLateNight(attendance model.Attendance, loc *time.Location) time.Duration {
+	var lateNight time.Duration
+	for _, segment := range attendance.Segments {
+		// 勤務中の区間は集計しない
+		if segment.EndTime == nil {
+			continue
+		}
+
+		start := segment.StartTime.Truncate(roundTo)
+		end := segment.EndTime.Truncate(roundTo)
+		for _, window := range lateNightWindows(start, end, loc) {
+			// 勤務区間と深夜時間帯が重なる部分
+			worked := timeRange{start: laterOf(start, window.start), end: earlierOf(end, window.end)}
+			if !worked.end.After(worked.start) {
+				continue
+			}
+			lateNight += worked.end.Sub(worked.start)
+
+			// 重なる部分に含まれる休憩を差し引く
+			for _, breakRecord := range attendance.Breaks {
+				if breakRecord.EndTime == nil {
+					continue
+				}
+				lateNight -= overlap(worked.start, worked.end, breakRecord.StartTime.Truncate(roundTo), breakRecord.EndTime.Truncate(roundTo))
+			}
+		}
+	}
+	return lateNight
+}
+
+// 期間と重なる可能性のある深夜時間帯を列挙（開始日の前日22時から）
+func lateNightWindows(start, end time.Time, loc *time.Location) []timeRange {
+	local := start.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, loc)
+
+	var windows []timeRange
+	for {
+		window := timeRange{
+			start: time.Date(day.Year(), day.Month(), day.Day(), lateNightStartHour, 0, 0, 0, loc),
+			end:   time.Date(day.Year(), day.Month(), day.Day()+1, lateNightEndHour, 0, 0, 0, loc),
+		}
+		if !window.start.Before(end) {
+			break
+		}
+		windows = append(windows, window)
+		day = day.AddDate(0, 0, 1)
+	}
+	return windows
+}
+
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlierOf(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
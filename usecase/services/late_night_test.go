@@ -0,0 +1,104 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+func TestCalculateLateNight(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 4, day, hour, minute, 0, 0, jst)
+	}
+	closed := func(start, end time.Time) model.WorkSegment {
+		return model.WorkSegment{StartTime: start, EndTime: &end}
+	}
+	rest := func(start, end time.Time) model.AttendanceBreak {
+		return model.AttendanceBreak{BreakType: model.BreakTypeRest, StartTime: start, EndTime: &end}
+	}
+
+	tests := []struct {
+		name     string
+		segments []model.WorkSegment
+		breaks   []model.AttendanceBreak
+		want     time.Duration
+	}{
+		{
+			name:     "日中のみ",
+			segments: []model.WorkSegment{closed(at(1, 9, 0), at(1, 18, 0))},
+			want:     0,
+		},
+		{
+			name:     "22時以降",
+			segments: []model.WorkSegment{closed(at(1, 18, 0), at(1, 23, 30))},
+			want:     90 * time.Minute,
+		},
+		{
+			name:     "日付をまたぐ",
+			segments: []model.WorkSegment{closed(at(1, 20, 0), at(2, 3, 0))},
+			want:     5 * time.Hour,
+		},
+		{
+			name:     "5時前に開始",
+			segments: []model.WorkSegment{closed(at(1, 3, 0), at(1, 9, 0))},
+			want:     2 * time.Hour,
+		},
+		{
+			name:     "翌朝5時を超える",
+			segments: []model.WorkSegment{closed(at(1, 21, 0), at(2, 8, 0))},
+			want:     7 * time.Hour,
+		},
+		{
+			name:     "深夜時間帯を2回含む",
+			segments: []model.WorkSegment{closed(at(1, 4, 0), at(1, 23, 0))},
+			want:     2 * time.Hour,
+		},
+		{
+			name:     "深夜の休憩を差し引く",
+			segments: []model.WorkSegment{closed(at(1, 21, 0), at(2, 6, 0))},
+			breaks:   []model.AttendanceBreak{rest(at(2, 1, 0), at(2, 1, 45))},
+			want:     6*time.Hour + 15*time.Minute,
+		},
+		{
+			name:     "深夜時間帯にまたがる休憩は重なる部分のみ差し引く",
+			segments: []model.WorkSegment{closed(at(1, 18, 0), at(1, 23, 0))},
+			breaks:   []model.AttendanceBreak{rest(at(1, 21, 30), at(1, 22, 30))},
+			want:     30 * time.Minute,
+		},
+		{
+			name:     "日中の休憩は差し引かない",
+			segments: []model.WorkSegment{closed(at(1, 17, 0), at(1, 23, 0))},
+			breaks:   []model.AttendanceBreak{rest(at(1, 19, 0), at(1, 20, 0))},
+			want:     time.Hour,
+		},
+		{
+			name: "複数の勤務区間",
+			segments: []model.WorkSegment{
+				closed(at(1, 20, 0), at(1, 23, 0)),
+				closed(at(1, 23, 30), at(2, 1, 0)),
+			},
+			want: 2*time.Hour + 30*time.Minute,
+		},
+		{
+			name:     "勤務中の区間は集計しない",
+			segments: []model.WorkSegment{{StartTime: at(1, 22, 0)}},
+			want:     0,
+		},
+		{
+			name:     "5分単位で切り下げ",
+			segments: []model.WorkSegment{closed(at(1, 21, 58), at(1, 22, 17))},
+			want:     15 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attendance := model.Attendance{Segments: tt.segments, Breaks: tt.breaks}
+			if got := calculateLateNight(attendance, jst); got != tt.want {
+				t.Errorf("calculateLateNight() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+var (
+	ErrMinimumWageNotFound = errors.New("最低賃金が見つかりません")
+	ErrBelowMinimumWage    = errors.New("時給が最低賃金を下回っています")
+)
+
+// 店舗の都道府県と日付から最低賃金を決めるための最低賃金の一覧
+type minimumWageTable struct {
+	wages       map[string][]model.MinimumWage // 都道府県ごと（適用開始日順）
+	prefectures map[uint]string                // 店舗ごとの都道府県
+}
+
+// 登録されている最低賃金と店舗の都道府県を取得
+func loadMinimumWageTable(repo repositories.MinimumWageRepository, storeRepo repositories.StoreRepository) (minimumWageTable, error) {
+	wages, err := repo.GetMinimumWages("")
+	if err != nil {
+		return minimumWageTable{}, err
+	}
+	stores, err := storeRepo.GetAllStores(true)
+	if err != nil {
+		return minimumWageTable{}, err
+	}
+	return newMinimumWageTable(wages, stores), nil
+}
+
+func newMinimumWageTable(wages []model.MinimumWage, stores []model.Store) minimumWageTable {
+	table := minimumWageTable{wages: map[string][]model.MinimumWage{}, prefectures: map[uint]string{}}
+	for _, wage := range wages {
+		table.wages[wage.Prefecture] = append(table.wages[wage.Prefecture], wage)
+	}
+	for _, store := range stores {
+		table.prefectures[store.ID] = store.Prefecture
+	}
+	return table
+}
+
+// 都道府県で指定した日に適用される最低賃金（適用開始前の場合は nil）
+func (t minimumWageTable) rateOn(prefecture string, date time.Time) *model.MinimumWage {
+	day := date.Format("2006-01-02")
+	var applied *model.MinimumWage
+	for i, wage := range t.wages[prefecture] {
+		if wage.EffectiveFrom.Format("2006-01-02") > day {
+			break
+		}
+		applied = &t.wages[prefecture][i]
+	}
+	return applied
+}
+
+// 店舗で指定した日に適用される最低賃金
+// 店舗の都道府県が未設定の場合（店舗が決まっていない場合を含む）は、下回ることのないよう全都道府県で最も高い最低賃金とする
+func (t minimumWageTable) forStore(storeID uint, date time.Time) *model.MinimumWage {
+	if prefecture := t.prefectures[storeID]; prefecture != "" {
+		return t.rateOn(prefecture, date)
+	}
+
+	var highest *model.MinimumWage
+	for prefecture := range t.wages {
+		if wage := t.rateOn(prefecture, date); wage != nil && (highest == nil || wage.HourlyWage > highest.HourlyWage) {
+			highest = wage
+		}
+	}
+	return highest
+}
+
+// 時給が最低賃金以上か確認（最低賃金が登録されていない場合は確認しない）
+func checkMinimumWage(hourlyPay int, minimum *model.MinimumWage) error {
+	if minimum == nil || hourlyPay >= minimum.HourlyWage {
+		return nil
+	}
+	return fmt.Errorf("%w（%sの最低賃金 %d円）", ErrBelowMinimumWage, minimum.Prefecture, minimum.HourlyWage)
+}
+
+// 1か月分の勤務のうち最低賃金に満たない賃金の合計（円）
+// 割増賃金は最低賃金の対象外のため、その日の平均時給（基本給 ÷ 実労働時間）を勤務した店舗の最低賃金と比べる
+func minimumWageShortfall(work *monthlyWork, wages wageTable, rules wageRules, minimums minimumWageTable) int {
+	var shortfall float64
+	for _, day := range work.Days {
+		if day.Worked <= 0 {
+			continue
+		}
+		rate := rules.dailyPay(day.Attendance, wages.rateOn(day.Attendance.WorkDate)) / day.Worked.Hours()
+
+		// 複数の店舗で勤務した日は最も高い最低賃金と比べる
+		minimum := 0
+		for _, segment := range day.Attendance.Segments {
+			if wage := minimums.forStore(segment.StoreID, day.Attendance.WorkDate); wage != nil && wage.HourlyWage > minimum {
+				minimum = wage.HourlyWage
+			}
+		}
+		if rate < float64(minimum) {
+			shortfall += payFor(day.Worked, float64(minimum)-rate, 1)
+		}
+	}
+	return roundYen(shortfall)
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// 東京都・神奈川県の最低賃金と、都道府県を設定した店舗・未設定の店舗
+func testMinimumWageTable() minimumWageTable {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	stores := []model.Store{{Prefecture: "神奈川県"}, {Prefecture: "東京都"}, {}}
+	for i := range stores {
+		stores[i].ID = uint(i + 1)
+	}
+	return newMinimumWageTable([]model.MinimumWage{
+		{Prefecture: "東京都", EffectiveFrom: date(2023, 10, 1), HourlyWage: 1113},
+		{Prefecture: "東京都", EffectiveFrom: date(2024, 10, 1), HourlyWage: 1163},
+		{Prefecture: "神奈川県", EffectiveFrom: date(2023, 10, 1), HourlyWage: 1112},
+		{Prefecture: "神奈川県", EffectiveFrom: date(2024, 10, 1), HourlyWage: 1162},
+	}, stores)
+}
+
+func TestMinimumWageForStore(t *testing.T) {
+	table := testMinimumWageTable()
+
+	tests := []struct {
+		name    string
+		storeID uint
+		date    time.Time
+		want    int
+	}{
+		{"改定前", 1, time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC), 1112},
+		{"改定日から新しい最低賃金", 1, time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), 1162},
+		{"店舗の都道府県で判定", 2, time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), 1163},
+		{"都道府県が未設定の店舗は最も高い最低賃金", 3, time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), 1163},
+		{"店舗なしは最も高い最低賃金", 0, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), 1113},
+		{"適用開始前", 1, time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := 0
+			if wage := table.forStore(tt.storeID, tt.date); wage != nil {
+				got = wage.HourlyWage
+			}
+			if got != tt.want {
+				t.Errorf("forStore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMinimumWage(t *testing.T) {
+	minimum := &model.MinimumWage{Prefecture: "神奈川県", HourlyWage: 1162}
+
+	tests := []struct {
+		name      string
+		hourlyPay int
+		minimum   *model.MinimumWage
+		wantErr   bool
+	}{
+		{"最低賃金と同額", 1162, minimum, false},
+		{"最低賃金を下回る", 1161, minimum, true},
+		{"最低賃金の登録なし", 900, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMinimumWage(tt.hourlyPay, tt.minimum)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkMinimumWage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrBelowMinimumWage) {
+				t.Errorf("checkMinimumWage() error = %v, want ErrBelowMinimumWage", err)
+			}
+		})
+	}
+}
+
+func TestMinimumWageShortfall(t *testing.T) {
+	table := testMinimumWageTable()
+	flat := func(pay int) wageTable {
+		return wageTable{current: pay}
+	}
+	// 東京都の店舗（店舗ID 2）で勤務した日
+	inTokyo := func(day workDay) workDay {
+		day.Attendance.Segments[0].StoreID = 2
+		return day
+	}
+
+	tests := []struct {
+		name  string
+		work  *monthlyWork
+		wages wageTable
+		rules wageRules
+		want  int
+	}{
+		{"最低賃金以上", testMonthlyWork(testWorkDay(1, 9, 8*time.Hour)), flat(1112), wageRules{}, 0},
+		{"最低賃金に満たない時間分", testMonthlyWork(testWorkDay(1, 9, 8*time.Hour), testWorkDay(2, 9, 4*time.Hour)), flat(1100), wageRules{}, 12 * 12},
+		{"勤務した店舗の都道府県で判定", testMonthlyWork(inTokyo(testWorkDay(1, 9, 8*time.Hour))), flat(1112), wageRules{}, 8},
+		{"賃金ルールの加算を含めた平均時給で判定", testMonthlyWork(testWorkDay(1, 9, 4*time.Hour)), flat(1100),
+			wageRules{rules: []model.WageRule{{StartTime: "09:00", EndTime: "11:00", Addition: 24}}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := minimumWageShortfall(tt.work, tt.wages, tt.rules, table); got != tt.want {
+				t.Errorf("minimumWageShortfall() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+// 勤務日ごとの集計
+type workDay struct {
+	Attendance model.Attendance
+	Worked     time.Duration // 実労働時間
+	LateNight  time.Duration // 深夜時間
+	Holiday    bool          // 法定休日の勤務
+	Overtime   dailyOvertime // 法定時間外労働
+}
+
+// 1か月分の勤務の集計
+type monthlyWork struct {
+	Year           int
+	Month          int
+	Days           []workDay
+	Worked         time.Duration // 実労働時間の合計
+	LateNight      time.Duration // 深夜時間の合計
+	DailyOvertime  time.Duration // 1日8時間を超えた時間の合計
+	WeeklyOvertime time.Duration // 週40時間を超えた時間の合計
+	HolidayWork    time.Duration // 法定休日の労働時間の合計
+}
+
+// 法定時間外労働の合計
+func (m *monthlyWork) Overtime() time.Duration {
+	return m.DailyOvertime + m.WeeklyOvertime
+}
+
+// 月末日
+func (m *monthlyWork) LastDay() time.Time {
+	return time.Date(m.Year, time.Month(m.Month)+1, 0, 0, 0, 0, 0, time.UTC)
+}
+
+// 勤務日ごとに法定休日の勤務かを判定（dates は勤務日順）
+// 法定休日は従業員ごとの週1日の休日のため、曜日を定めていない場合は週の7日すべてに勤務があるときに限り、
+// 週の最後の日を法定休日の勤務とする（休みのない日が1日でもあれば、その日が法定休日）
+func legalHolidays(dates []time.Time, weekStart time.Weekday, legalHoliday *time.Weekday) []bool {
+	holidays := make([]bool, len(dates))
+	if legalHoliday != nil {
+		for i, date := range dates {
+			holidays[i] = date.Weekday() == *legalHoliday
+		}
+		return holidays
+	}
+
+	workedDays := map[string]map[string]bool{}
+	for _, date := range dates {
+		week := startOfWeek(date, weekStart).Format("2006-01-02")
+		if workedDays[week] == nil {
+			workedDays[week] = map[string]bool{}
+		}
+		workedDays[week][date.Format("2006-01-02")] = true
+	}
+	for i, date := range dates {
+		week := startOfWeek(date, weekStart)
+		holidays[i] = len(workedDays[week.Format("2006-01-02")]) == 7 && date.Format("2006-01-02") == week.AddDate(0, 0, 6).Format("2006-01-02")
+	}
+	return holidays
+}
+
+// 従業員に適用される法定休日の曜日（担当店舗で定めていない場合は nil）
+func storeLegalHoliday(storeRepo repositories.StoreRepository, employee *model.Employee) (*time.Weekday, error) {
+	store, err := storeRepo.FindStoreByID(employee.StoreID())
+	if err != nil {
+		return nil, err
+	}
+	if store == nil || store.LegalHoliday == nil {
+		return nil, nil
+	}
+	weekday := time.Weekday(*store.LegalHoliday)
+	return &weekday, nil
+}
+
+// 指定した月の勤務を集計
+// 週40時間の判定のため月初を含む週の開始日から、法定休日の判定のため月末を含む週の最終日まで勤怠を取得する
+func loadMonthlyWork(repo repositories.SummaryRepository, storeRepo repositories.StoreRepository, employee *model.Employee, year int, month int) (*monthlyWork, error) {
+	weekStart := weekStartDay()
+	monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, -1)
+
+	attendances, err := repo.GetAttendanceBetween(employee.ID, startOfWeek(monthStart, weekStart), startOfWeek(monthEnd, weekStart).AddDate(0, 0, 6))
+	if err != nil {
+		return nil, err
+	}
+	legalHoliday, err := storeLegalHoliday(storeRepo, employee)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := storeLocations(storeRepo)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeMonth(attendances, year, month, weekStart, legalHoliday, locations), nil
+}
+
+// 勤怠（勤務日順）から指定した月の勤務を集計
+func summarizeMonth(attendances []model.Attendance, year int, month int, weekStart time.Weekday, legalHoliday *time.Weekday, locations map[uint]*time.Location) *monthlyWork {
+	dates := make([]time.Time, len(attendances))
+	for i, attendance := range attendances {
+		dates[i] = attendance.WorkDate
+	}
+	holidays := legalHolidays(dates, weekStart, legalHoliday)
+
+	days := make([]dailyWork, len(attendances))
+	for i, attendance := range attendances {
+		days[i] = dailyWork{
+			Date:    attendance.WorkDate,
+			Worked:  calculateWorkDuration(attendance),
+			Holiday: holidays[i],
+		}
+	}
+	overtimes := calculateStatutoryOvertime(days, weekStart)
+
+	work := &monthlyWork{Year: year, Month: month}
+	for i, attendance := range attendances {
+		// 前月・翌月分は週の集計にのみ使う
+		if attendance.WorkDate.Year() != year || int(attendance.WorkDate.Month()) != month {
+			continue
+		}
+
+		day := workDay{
+			Attendance: attendance,
+			Worked:     days[i].Worked,
+			LateNight:  calculateLateNight(attendance, attendanceLocation(attendance, locations)),
+			Holiday:    days[i].Holiday,
+			Overtime:   overtimes[i],
+		}
+		work.Days = append(work.Days, day)
+
+		work.Worked += day.Worked
+		work.LateNight += day.LateNight
+		work.DailyOvertime += day.Overtime.Daily
+		work.WeeklyOvertime += day.Overtime.Weekly
+		if day.Holiday {
+			work.HolidayWork += day.Worked
+		}
+	}
+	return work
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+func TestLegalHolidays(t *testing.T) {
+	// 2024-04-07 は日曜日（週の起算曜日）
+	days := func(from, to int) []time.Time {
+		var dates []time.Time
+		for d := from; d <= to; d++ {
+			dates = append(dates, time.Date(2024, 4, d, 0, 0, 0, 0, time.UTC))
+		}
+		return dates
+	}
+	weekday := func(d time.Weekday) *time.Weekday { return &d }
+
+	tests := []struct {
+		name         string
+		dates        []time.Time
+		legalHoliday *time.Weekday
+		want         []bool
+	}{
+		{"日曜日も通常の勤務日", days(7, 12), nil, []bool{false, false, false, false, false, false}},
+		{"週7日勤務した場合は週の最後の日", days(7, 13), nil, []bool{false, false, false, false, false, false, true}},
+		{"週をまたいで7日勤務しても休みがあれば対象外", days(10, 16), nil, []bool{false, false, false, false, false, false, false}},
+		{"店舗で定めた曜日", days(7, 9), weekday(time.Monday), []bool{false, true, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := legalHolidays(tt.dates, time.Sunday, tt.legalHoliday); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("legalHolidays() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeMonthSundayWork(t *testing.T) {
+	// 日曜日（2024-04-07）から金曜日まで8時間ずつ勤務し、土曜日は休み
+	var attendances []model.Attendance
+	for d := 7; d <= 12; d++ {
+		start := time.Date(2024, 4, d, 9, 0, 0, 0, time.UTC)
+		end := start.Add(8 * time.Hour)
+		attendances = append(attendances, model.Attendance{
+			WorkDate: time.Date(2024, 4, d, 0, 0, 0, 0, time.UTC),
+			Segments: []model.WorkSegment{{StoreID: 1, StartTime: start, EndTime: &end}},
+		})
+	}
+
+	work := summarizeMonth(attendances, 2024, 4, time.Sunday, nil, map[uint]*time.Location{1: time.UTC})
+	if work.HolidayWork != 0 {
+		t.Errorf("HolidayWork = %v, want 0", work.HolidayWork)
+	}
+	if work.Days[0].Holiday {
+		t.Errorf("Days[0].Holiday = true, want false")
+	}
+	// 日曜日の勤務も週40時間に積み上げ、金曜日の8時間が週の時間外になる
+	if work.WeeklyOvertime != 8*time.Hour {
+		t.Errorf("WeeklyOvertime = %v, want 8h", work.WeeklyOvertime)
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 法定労働時間
+const (
+	dailyWorkLimit       = 8 * time.Hour  // 1日の法定労働時間
+	weeklyWorkLimit      = 40 * time.Hour // 1週の法定労働時間
+	monthlyOvertimeLimit = 60 * time.Hour // 割増率が上がる1か月の時間外労働
+)
+
+// 1日の実労働時間
+type dailyWork struct {
+	Date    time.Time
+	Worked  time.Duration
+	Holiday bool // 法定休日の勤務（時間外労働ではなく休日労働として扱う）
+}
+
+// 1日の法定時間外労働
+type dailyOvertime struct {
+	Daily  time.Duration // 1日8時間を超えた時間
+	Weekly time.Duration // 週40時間を超えた時間（1日8時間を超えた分は含まない）
+}
+
+// 法定時間外労働の合計
+func (o dailyOvertime) Total() time.Duration {
+	return o.Daily + o.Weekly
+}
+
+// 週の起算曜日（環境変数 OVERTIME_WEEK_START で変更可能、既定は日曜日）
+// 曜日名（sunday など）または 0（日曜日）〜6（土曜日）で指定する
+func weekStartDay() time.Weekday {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv("OVERTIME_WEEK_START")))
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 6 {
+		return time.Weekday(n)
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if value == strings.ToLower(day.String()) {
+			return day
+		}
+	}
+	return time.Sunday
+}
+
+// 日付が属する週の開始日
+func startOfWeek(date time.Time, weekStart time.Weekday) time.Time {
+	offset := (int(date.Weekday()) - int(weekStart) + 7) % 7
+	return time.Date(date.Year(), date.Month(), date.Day()-offset, 0, 0, 0, 0, date.Location())
+}
+
+// 日ごとの法定時間外労働を計算（days は勤務日順）
+// 週40時間の判定には、1日8時間を超えた分を除いた時間を積み上げる
+// 法定休日の勤務は休日労働のため、時間外労働にも週の積み上げにも含めない
+func calculateStatutoryOvertime(days []dailyWork, weekStart time.Weekday) []dailyOvertime {
+	overtimes := make([]dailyOvertime, len(days))
+
+	var week time.Time
+	var weekWorked time.Duration
+	for i, day := range days {
+		// 週が変わったら積み上げをリセット
+		if start := startOfWeek(day.Date, weekStart); !start.Equal(week) {
+			week = start
+			weekWorked = 0
+		}
+
+		if day.Holiday {
+			continue
+		}
+
+		regular := day.Worked
+		if regular < 0 {
+			regular = 0
+		}
+		if regular > dailyWorkLimit {
+			overtimes[i].Daily = regular - dailyWorkLimit
+			regular = dailyWorkLimit
+		}
+
+		// 週の積み上げが40時間を超えた部分
+		if exceeded := weekWorked + regular - weeklyWorkLimit; exceeded > 0 {
+			overtimes[i].Weekly = min(exceeded, regular)
+		}
+		weekWorked += regular
+	}
+	return overtimes
+}
+
+// 1か月の時間外労働を60時間以内と60時間超に分ける
+func splitMonthlyOvertime(total time.Duration) (upTo60 time.Duration, over60 time.Duration) {
+	if total <= monthlyOvertimeLimit {
+		return total, 0
+	}
+	return monthlyOvertimeLimit, total - monthlyOvertimeLimit
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCalculateStatutoryOvertime(t *testing.T) {
+	// 2024-04-07 は日曜日
+	day := func(d int, hours float64) dailyWork {
+		return dailyWork{
+			Date:   time.Date(2024, 4, d, 0, 0, 0, 0, time.UTC),
+			Worked: time.Duration(hours * float64(time.Hour)),
+		}
+	}
+	h := func(hours float64) time.Duration {
+		return time.Duration(hours * float64(time.Hour))
+	}
+
+	tests := []struct {
+		name      string
+		days      []dailyWork
+		weekStart time.Weekday
+		want      []dailyOvertime
+	}{
+		{
+			name: "8時間以内",
+			days: []dailyWork{day(8, 8)},
+			want: []dailyOvertime{{}},
+		},
+		{
+			name: "1日8時間超",
+			days: []dailyWork{day(8, 10.5)},
+			want: []dailyOvertime{{Daily: h(2.5)}},
+		},
+		{
+			name: "週40時間超は超えた日に計上",
+			days: []dailyWork{day(7, 8), day(8, 8), day(9, 8), day(10, 8), day(11, 6), day(12, 5)},
+			want: []dailyOvertime{{}, {}, {}, {}, {}, {Weekly: h(3)}},
+		},
+		{
+			name: "1日8時間超の分は週の積み上げに含めない",
+			days: []dailyWork{day(7, 10), day(8, 10), day(9, 10), day(10, 10), day(11, 10)},
+			want: []dailyOvertime{{Daily: h(2)}, {Daily: h(2)}, {Daily: h(2)}, {Daily: h(2)}, {Daily: h(2)}},
+		},
+		{
+			name: "40時間到達後の勤務はすべて週の時間外",
+			days: []dailyWork{day(7, 8), day(8, 8), day(9, 8), day(10, 8), day(11, 8), day(12, 9)},
+			want: []dailyOvertime{{}, {}, {}, {}, {}, {Daily: h(1), Weekly: h(8)}},
+		},
+		{
+			name: "週が変わると積み上げをリセット",
+			days: []dailyWork{day(8, 8), day(9, 8), day(10, 8), day(11, 8), day(12, 8), day(14, 8)},
+			want: []dailyOvertime{{}, {}, {}, {}, {}, {}},
+		},
+		{
+			name: "法定休日の勤務は時間外労働に含めない",
+			days: []dailyWork{
+				{Date: time.Date(2024, 4, 7, 0, 0, 0, 0, time.UTC), Worked: h(10), Holiday: true},
+				day(8, 8), day(9, 8), day(10, 8), day(11, 8), day(12, 8),
+			},
+			want: []dailyOvertime{{}, {}, {}, {}, {}, {}},
+		},
+		{
+			name:      "週の起算曜日を月曜日に変更",
+			days:      []dailyWork{day(7, 8), day(8, 8), day(9, 8), day(10, 8), day(11, 8), day(12, 8)},
+			weekStart: time.Monday,
+			want:      []dailyOvertime{{}, {}, {}, {}, {}, {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateStatutoryOvertime(tt.days, tt.weekStart)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("calculateStatutoryOvertime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitMonthlyOvertime(t *testing.T) {
+	tests := []struct {
+		total      time.Duration
+		wantUpTo60 time.Duration
+		wantOver60 time.Duration
+	}{
+		{45 * time.Hour, 45 * time.Hour, 0},
+		{60 * time.Hour, 60 * time.Hour, 0},
+		{72*time.Hour + 30*time.Minute, 60 * time.Hour, 12*time.Hour + 30*time.Minute},
+	}
+
+	for _, tt := range tests {
+		upTo60, over60 := splitMonthlyOvertime(tt.total)
+		if upTo60 != tt.wantUpTo60 || over60 != tt.wantOver60 {
+			t.Errorf("splitMonthlyOvertime(%v) = (%v, %v), want (%v, %v)", tt.total, upTo60, over60, tt.wantUpTo60, tt.wantOver60)
+		}
+	}
+}
+
+func TestWeekStartDay(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Weekday
+	}{
+		{"", time.Sunday},
+		{"monday", time.Monday},
+		{"Saturday", time.Saturday},
+		{"3", time.Wednesday},
+		{"invalid", time.Sunday},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("OVERTIME_WEEK_START", tt.value)
+		if got := weekStartDay(); got != tt.want {
+			t.Errorf("weekStartDay(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
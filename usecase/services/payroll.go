@@ -0,0 +1,70 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// 割増賃金率
+const (
+	lateNightPremiumRate      = 0.25 // 深夜労働
+	overtimePremiumRate       = 0.25 // 時間外労働（月60時間以内）
+	overtimeOver60PremiumRate = 0.50 // 時間外労働（月60時間超）
+	holidayPremiumRate        = 0.35 // 法定休日の労働
+)
+
+// 時間に時給と率を掛けた金額（円未満を含む）
+func payFor(d time.Duration, hourlyPay float64, rate float64) float64 {
+	return d.Hours() * hourlyPay * rate
+}
+
+// 円未満を四捨五入
+func roundYen(amount float64) int {
+	return int(math.Round(amount))
+}
+
+// 1か月分の勤務から給与を計算
+// 勤務日ごとにその日に適用される時給と賃金ルールで計算し、項目ごとに合計してから円未満を四捨五入する
+// 割増賃金は基本給に上乗せする分を、その日の平均時給（基本給 ÷ 実労働時間）を基準に計算する
+func calculatePayroll(work *monthlyWork, wages wageTable, rules wageRules, allowances int) model.PayrollRun {
+	var basePay, lateNightPremium, overtimePremium, holidayPremium float64
+	var overtimeSoFar time.Duration
+	for _, day := range work.Days {
+		dailyPay := rules.dailyPay(day.Attendance, wages.rateOn(day.Attendance.WorkDate))
+		rate := float64(wages.rateOn(day.Attendance.WorkDate))
+		if day.Worked > 0 {
+			rate = dailyPay / day.Worked.Hours()
+		}
+
+		basePay += dailyPay
+		lateNightPremium += payFor(day.LateNight, rate, lateNightPremiumRate)
+		if day.Holiday {
+			holidayPremium += payFor(day.Worked, rate, holidayPremiumRate)
+		}
+
+		// 月の時間外労働の累計が60時間を超えた部分は割増率を上げる
+		overtime := day.Overtime.Total()
+		upTo60 := min(overtime, max(monthlyOvertimeLimit-overtimeSoFar, 0))
+		overtimeSoFar += overtime
+		overtimePremium += payFor(upTo60, rate, overtimePremiumRate) + payFor(overtime-upTo60, rate, overtimeOver60PremiumRate)
+	}
+
+	upTo60, over60 := splitMonthlyOvertime(work.Overtime())
+	run := model.PayrollRun{
+		HourlyPay:           wages.rateOn(work.LastDay()),
+		WorkHours:           work.Worked.Hours(),
+		LateNightHours:      work.LateNight.Hours(),
+		OvertimeHours:       upTo60.Hours(),
+		OvertimeOver60Hours: over60.Hours(),
+		HolidayHours:        work.HolidayWork.Hours(),
+		BasePay:             roundYen(basePay),
+		LateNightPremium:    roundYen(lateNightPremium),
+		OvertimePremium:     roundYen(overtimePremium),
+		HolidayPremium:      roundYen(holidayPremium),
+		Allowances:          allowances,
+	}
+	run.GrossPay = run.BasePay + run.LateNightPremium + run.OvertimePremium + run.HolidayPremium + run.Allowances
+	return run
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+var ErrPayrollNotFound = errors.New("給与計算の結果が見つかりません")
+
+type PayrollService struct {
+	repo        repositories.PayrollRepository
+	summaryRepo repositories.SummaryRepository
+	empRepo     repositories.EmployeeRepository
+	storeRepo   repositories.StoreRepository
+	wageRepo    repositories.WageRepository
+	ruleRepo    repositories.WageRuleRepository
+	holidayRepo repositories.HolidayRepository
+	periodRepo  repositories.PeriodRepository
+	minimumRepo repositories.MinimumWageRepository
+}
+
+func NewPayrollService(repo repositories.PayrollRepository, summaryRepo repositories.SummaryRepository, empRepo repositories.EmployeeRepository, storeRepo repositories.StoreRepository, wageRepo repositories.WageRepository, ruleRepo repositories.WageRuleRepository, holidayRepo repositories.HolidayRepository, periodRepo repositories.PeriodRepository, minimumRepo repositories.MinimumWageRepository) *PayrollService {
+	return &PayrollService{repo: repo, summaryRepo: summaryRepo, empRepo: empRepo, storeRepo: storeRepo, wageRepo: wageRepo, ruleRepo: ruleRepo, holidayRepo: holidayRepo, periodRepo: periodRepo, minimumRepo: minimumRepo}
+}
+
+// 操作者が対象従業員に対して指定した操作を行えるか確認し、従業員を返す
+func (s *PayrollService) authorizeEmployee(actor Actor, p model.Permission, employeeID uint) (*model.Employee, error) {
+	employee, err := s.empRepo.FindEmpByEmpID(int(employeeID))
+	if err != nil {
+		return nil, err
+	}
+	if err := actor.authorize(p, employee); err != nil {
+		return nil, err
+	}
+	return employee, nil
+}
+
+// 指定した月の最新の給与計算の結果を取得
+func (s *PayrollService) GetPayroll(actor Actor, employeeID uint, year int, month int) (*model.PayrollRun, error) {
+	if _, err := s.authorizeEmployee(actor, model.PermViewPayroll, employeeID); err != nil {
+		return nil, err
+	}
+
+	run, err := s.repo.FindLatestPayrollRun(employeeID, year, month)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, ErrPayrollNotFound
+	}
+	return run, nil
+}
+
+// 指定した月の給与を計算して保存
+func (s *PayrollService) RunPayroll(actor Actor, employeeID uint, year int, month int, allowances int, deductions []model.PayrollDeduction) (*model.PayrollRun, error) {
+	if month < 1 || month > 12 {
+		return nil, fmt.Errorf("月が不正です: %d", month)
+	}
+	if allowances < 0 {
+		return nil, errors.New("手当は0以上で入力してください")
+	}
+	deductions, err := validateDeductions(deductions)
+	if err != nil {
+		return nil, err
+	}
+
+	employee, err := s.authorizeEmployee(actor, model.PermRunPayroll, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 給与は担当店舗の締めに従い、締め済みの月は再計算しない
+	if err := ensurePeriodOpen(s.periodRepo, employee.StoreID(), time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		return nil, err
+	}
+
+	run, work, err := s.calculate(employee, year, month, allowances)
+	if err != nil {
+		return nil, err
+	}
+	// 担当店舗以外で勤務した場合も、勤務したいずれかの店舗で締め済みの月は再計算しない
+	attendances := make([]*model.Attendance, len(work.Days))
+	for i := range work.Days {
+		attendances[i] = &work.Days[i].Attendance
+	}
+	if err := ensureAttendanceOpen(s.periodRepo, attendances...); err != nil {
+		return nil, err
+	}
+	if err := applyDeductions(run, deductions); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreatePayrollRun(run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// 給与明細に出力する内容
+type Payslip struct {
+	Employee  model.Employee
+	StoreName string
+	WorkDays  int
+	Run       *model.PayrollRun
+}
+
+// 従業員の給与明細を取得（給与計算を実行済みの月のみ）
+func (s *PayrollService) GetPayslip(actor Actor, employeeID uint, year int, month int) (*Payslip, error) {
+	employee, err := s.authorizeEmployee(actor, model.PermViewPayroll, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.repo.FindLatestPayrollRun(employeeID, year, month)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, ErrPayrollNotFound
+	}
+
+	names, err := storeNames(s.storeRepo)
+	if err != nil {
+		return nil, err
+	}
+	return s.payslip(employee, run, names)
+}
+
+// 店舗の従業員の給与明細を取得（給与計算を実行していない従業員は含めない）
+func (s *PayrollService) GetStorePayslips(actor Actor, storeID uint, year int, month int) ([]Payslip, error) {
+	if err := actor.authorizeStore(model.PermViewPayroll, storeID); err != nil {
+		return nil, err
+	}
+	if _, err := findStore(s.storeRepo, storeID); err != nil {
+		return nil, err
+	}
+	employees, err := s.empRepo.GetEmployeesByStore(storeID)
+	if err != nil {
+		return nil, err
+	}
+	names, err := storeNames(s.storeRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	payslips := []Payslip{}
+	for i := range employees {
+		run, err := s.repo.FindLatestPayrollRun(employees[i].ID, year, month)
+		if err != nil {
+			return nil, err
+		}
+		if run == nil {
+			continue
+		}
+		payslip, err := s.payslip(&employees[i], run, names)
+		if err != nil {
+			return nil, err
+		}
+		payslips = append(payslips, *payslip)
+	}
+	if len(payslips) == 0 {
+		return nil, ErrPayrollNotFound
+	}
+	return payslips, nil
+}
+
+// 給与計算の結果に出勤日数と担当店舗を合わせて給与明細にする
+func (s *PayrollService) payslip(employee *model.Employee, run *model.PayrollRun, names map[uint]string) (*Payslip, error) {
+	work, err := loadMonthlyWork(s.summaryRepo, s.storeRepo, employee, run.Year, run.Month)
+	if err != nil {
+		return nil, err
+	}
+	workDays := 0
+	for _, day := range work.Days {
+		if day.Worked > 0 {
+			workDays++
+		}
+	}
+
+	return &Payslip{
+		Employee:  *employee,
+		StoreName: names[employee.StoreID()],
+		WorkDays:  workDays,
+		Run:       run,
+	}, nil
+}
+
+// 店舗の従業員全員の給与を取得（保存した計算結果があればその結果、なければ現時点の勤怠から計算する）
+func (s *PayrollService) GetStorePayrolls(actor Actor, storeID uint, year int, month int) (map[uint]*model.PayrollRun, error) {
+	if err := actor.authorizeStore(model.PermViewPayroll, storeID); err != nil {
+		return nil, err
+	}
+	employees, err := s.empRepo.GetEmployeesByStore(storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make(map[uint]*model.PayrollRun, len(employees))
+	for i := range employees {
+		run, err := s.repo.FindLatestPayrollRun(employees[i].ID, year, month)
+		if err != nil {
+			return nil, err
+		}
+		if run == nil {
+			if run, _, err = s.calculate(&employees[i], year, month, 0); err != nil {
+				return nil, err
+			}
+		}
+		runs[employees[i].ID] = run
+	}
+	return runs, nil
+}
+
+// 従業員の1か月分の給与を計算し、集計した勤務とともに返す（保存はしない）
+func (s *PayrollService) calculate(employee *model.Employee, year int, month int, allowances int) (*model.PayrollRun, *monthlyWork, error) {
+	work, err := loadMonthlyWork(s.summaryRepo, s.storeRepo, employee, year, month)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wages, err := loadWageTable(s.wageRepo, employee)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rules, err := loadWageRules(s.ruleRepo, s.holidayRepo, s.storeRepo, employee.ID, time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), work.LastDay())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 最低賃金を下回る場合も計算は行い、不足額を記録して確認できるようにする
+	minimums, err := loadMinimumWageTable(s.minimumRepo, s.storeRepo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	run := calculatePayroll(work, wages, rules, allowances)
+	run.MinimumWageShortfall = minimumWageShortfall(work, wages, rules, minimums)
+	run.EmployeeID = employee.ID
+	run.Year = year
+	run.Month = month
+	run.NetPay = run.GrossPay
+	return &run, work, nil
+}
+
+// 控除の項目を検証（項目名の前後の空白は除く）
+func validateDeductions(deductions []model.PayrollDeduction) ([]model.PayrollDeduction, error) {
+	valid := make([]model.PayrollDeduction, 0, len(deductions))
+	for _, deduction := range deductions {
+		name := strings.TrimSpace(deduction.Name)
+		if name == "" {
+			return nil, errors.New("控除の項目名を入力してください")
+		}
+		if deduction.Amount < 0 {
+			return nil, fmt.Errorf("控除額は0以上で入力してください: %s", name)
+		}
+		valid = append(valid, model.PayrollDeduction{Name: name, Amount: deduction.Amount})
+	}
+	return valid, nil
+}
+
+// 控除を給与計算の結果に反映し、差引支給額を計算する
+func applyDeductions(run *model.PayrollRun, deductions []model.PayrollDeduction) error {
+	total := 0
+	for _, deduction := range deductions {
+		total += deduction.Amount
+	}
+	if total > run.GrossPay {
+		return errors.New("控除額の合計が総支給額を超えています")
+	}
+
+	run.Deductions = deductions
+	run.TotalDeductions = total
+	run.NetPay = run.GrossPay - total
+	return nil
+}
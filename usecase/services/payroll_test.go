@@ -0,0 +1,186 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	repository "github.com/techyoichiro/jobreco-api/infra/database/repositories"
+)
+
+// 勤務日から1か月分の集計を作る
+func testMonthlyWork(days ...workDay) *monthlyWork {
+	work := &monthlyWork{Year: 2024, Month: 4, Days: days}
+	for _, day := range days {
+		work.Worked += day.Worked
+		work.LateNight += day.LateNight
+		work.DailyOvertime += day.Overtime.Daily
+		work.WeeklyOvertime += day.Overtime.Weekly
+		if day.Holiday {
+			work.HolidayWork += day.Worked
+		}
+	}
+	return work
+}
+
+// 指定した日の start 時から d だけ勤務した日
+func testWorkDay(day int, start int, d time.Duration) workDay {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	startTime := time.Date(2024, 4, day, start, 0, 0, 0, jst)
+	endTime := startTime.Add(d)
+	return workDay{
+		Attendance: model.Attendance{
+			WorkDate: time.Date(2024, 4, day, 0, 0, 0, 0, time.UTC),
+			Segments: []model.WorkSegment{{StoreID: 1, StartTime: startTime, EndTime: &endTime}},
+		},
+		Worked: d,
+	}
+}
+
+func TestCalculatePayroll(t *testing.T) {
+	flat := func(pay int) wageTable {
+		return wageTable{current: pay}
+	}
+	with := func(day workDay, apply func(*workDay)) workDay {
+		apply(&day)
+		return day
+	}
+
+	tests := []struct {
+		name       string
+		work       *monthlyWork
+		wages      wageTable
+		rules      wageRules
+		allowances int
+		wantBase   int
+		wantLate   int
+		wantOver   int
+		wantHol    int
+		wantGross  int
+	}{
+		{
+			name:      "割増なし",
+			work:      testMonthlyWork(testWorkDay(1, 9, 8*time.Hour)),
+			wages:     flat(1200),
+			wantBase:  9600,
+			wantGross: 9600,
+		},
+		{
+			name: "深夜・時間外・休日・手当",
+			work: testMonthlyWork(
+				with(testWorkDay(1, 17, 10*time.Hour), func(d *workDay) {
+					d.LateNight = 5 * time.Hour
+					d.Overtime = dailyOvertime{Daily: 2 * time.Hour}
+				}),
+				with(testWorkDay(7, 9, 6*time.Hour), func(d *workDay) { d.Holiday = true }),
+			),
+			wages:      flat(1000),
+			allowances: 5000,
+			wantBase:   16000,
+			wantLate:   1250,
+			wantOver:   500,
+			wantHol:    2100,
+			wantGross:  24850,
+		},
+		{
+			name: "月60時間を超えた時間外は50%",
+			work: testMonthlyWork(
+				with(testWorkDay(1, 9, 8*time.Hour), func(d *workDay) { d.Overtime = dailyOvertime{Daily: 50 * time.Hour} }),
+				with(testWorkDay(2, 9, 8*time.Hour), func(d *workDay) { d.Overtime = dailyOvertime{Weekly: 20 * time.Hour} }),
+			),
+			wages:     flat(1000),
+			wantBase:  16000,
+			wantOver:  60*250 + 10*500,
+			wantGross: 16000 + 60*250 + 10*500,
+		},
+		{
+			name: "勤務日ごとに適用されていた時給で計算",
+			work: testMonthlyWork(
+				testWorkDay(10, 9, 8*time.Hour),
+				with(testWorkDay(20, 9, 8*time.Hour), func(d *workDay) { d.LateNight = 2 * time.Hour }),
+			),
+			wages: wageTable{histories: []model.WageHistory{
+				{HourlyPay: 1000, EffectiveFrom: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				{HourlyPay: 1100, EffectiveFrom: time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)},
+			}},
+			wantBase:  8*1000 + 8*1100,
+			wantLate:  550,
+			wantGross: 8*1000 + 8*1100 + 550,
+		},
+		{
+			name: "賃金ルールの時給で計算し、割増はその日の平均時給を基準にする",
+			work: testMonthlyWork(
+				with(testWorkDay(1, 9, 4*time.Hour), func(d *workDay) { d.Overtime = dailyOvertime{Daily: time.Hour} }),
+			),
+			wages:     flat(1000),
+			rules:     wageRules{rules: []model.WageRule{{StartTime: "11:00", EndTime: "13:00", Addition: 200}}},
+			wantBase:  4400,
+			wantOver:  275,
+			wantGross: 4675,
+		},
+		{
+			name: "円未満は四捨五入",
+			work: testMonthlyWork(
+				with(testWorkDay(1, 22, 10*time.Minute), func(d *workDay) { d.LateNight = 10 * time.Minute }),
+			),
+			wages:     flat(1163),
+			wantBase:  194,
+			wantLate:  48,
+			wantGross: 242,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := calculatePayroll(tt.work, tt.wages, tt.rules, tt.allowances)
+			if run.BasePay != tt.wantBase || run.LateNightPremium != tt.wantLate || run.OvertimePremium != tt.wantOver ||
+				run.HolidayPremium != tt.wantHol || run.GrossPay != tt.wantGross {
+				t.Errorf("calculatePayroll() = base %d, late %d, over %d, holiday %d, gross %d; want %d, %d, %d, %d, %d",
+					run.BasePay, run.LateNightPremium, run.OvertimePremium, run.HolidayPremium, run.GrossPay,
+					tt.wantBase, tt.wantLate, tt.wantOver, tt.wantHol, tt.wantGross)
+			}
+		})
+	}
+}
+
+func TestPayrollServiceRunPayrollPeriodClosed(t *testing.T) {
+	db := newTestDB(t)
+	service := NewPayrollService(repository.NewPayrollRepository(db), repository.NewSummaryRepository(db), repository.NewEmployeeRepository(db), repository.NewStoreRepository(db),
+		repository.NewWageRepository(db), repository.NewWageRuleRepository(db), repository.NewHolidayRepository(db), repository.NewPeriodRepository(db), repository.NewMinimumWageRepository(db))
+	owner := Actor{EmployeeID: 100, Role: model.RoleOwner}
+
+	stores := []model.Store{{Name: "本店"}, {Name: "支店"}}
+	if err := db.Create(&stores).Error; err != nil {
+		t.Fatal(err)
+	}
+	competentStoreID := int(stores[0].ID)
+	employee := &model.Employee{Name: "山田", LoginID: "yamada", Password: "x", RoleID: int(model.RoleStaff), HourlyPay: 1200, CompetentStoreID: &competentStoreID}
+	if err := db.Create(employee).Error; err != nil {
+		t.Fatal(err)
+	}
+	// 担当店舗ではない支店で勤務した日
+	loc := time.FixedZone("Asia/Tokyo", 9*60*60)
+	start := time.Date(2024, 4, 10, 9, 0, 0, 0, loc)
+	end := start.Add(8 * time.Hour)
+	if err := db.Create(&model.Attendance{
+		EmployeeID: employee.ID,
+		WorkDate:   time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC),
+		StatusID:   model.StatusClockedOut,
+		Segments:   []model.WorkSegment{{StoreID: stores[1].ID, StartTime: start, EndTime: &end}},
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.RunPayroll(owner, employee.ID, 2024, 4, 0, nil); err != nil {
+		t.Fatalf("RunPayroll() before close error = %v", err)
+	}
+
+	// 勤務した支店の月を締めると再計算できない
+	if err := db.Create(&model.PeriodClose{StoreID: stores[1].ID, Year: 2024, Month: 4, ClosedByID: owner.EmployeeID, ClosedAt: time.Now()}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.RunPayroll(owner, employee.ID, 2024, 4, 0, nil); !errors.Is(err, ErrPeriodClosed) {
+		t.Errorf("RunPayroll() after close error = %v, want ErrPeriodClosed", err)
+	}
+}
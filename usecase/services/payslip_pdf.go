@@ -0,0 +1,144 @@
+package services
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// 給与明細の1項目
+type payslipRow struct {
+	Label string
+	Value string
+}
+
+// 給与明細の区分（勤怠・支給・控除・差引支給額）
+type payslipSection struct {
+	Title string
+	Rows  []payslipRow
+}
+
+// 給与明細の日本語フォント（TrueType形式、環境変数 PAYSLIP_FONT_PATH で指定）
+func payslipFont() ([]byte, error) {
+	path := os.Getenv("PAYSLIP_FONT_PATH")
+	if path == "" {
+		return nil, errors.New("給与明細のフォントが設定されていません（PAYSLIP_FONT_PATH）")
+	}
+	font, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("給与明細のフォントを読み込めません: %w", err)
+	}
+	return font, nil
+}
+
+// 給与明細のファイル名
+func PayslipFilename(payslip Payslip) string {
+	return fmt.Sprintf("payslip_%d_%04d%02d.pdf", payslip.Employee.ID, payslip.Run.Year, payslip.Run.Month)
+}
+
+// 給与明細をPDFで出力
+func WritePayslipPDF(w io.Writer, payslip Payslip) error {
+	font, err := payslipFont()
+	if err != nil {
+		return err
+	}
+	return renderPayslip(w, font, payslip)
+}
+
+// 複数の給与明細をPDFにしてzipにまとめて出力
+func WritePayslipsZip(w io.Writer, payslips []Payslip) error {
+	font, err := payslipFont()
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	for _, payslip := range payslips {
+		file, err := archive.Create(PayslipFilename(payslip))
+		if err != nil {
+			return err
+		}
+		if err := renderPayslip(file, font, payslip); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// 給与明細を1ページのPDFに描画
+func renderPayslip(w io.Writer, font []byte, payslip Payslip) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes("jp", "", font)
+	pdf.SetMargins(20, 20, 20)
+	pdf.AddPage()
+
+	run := payslip.Run
+	pdf.SetFont("jp", "", 18)
+	pdf.CellFormat(0, 12, fmt.Sprintf("給与明細書 %d年%d月分", run.Year, run.Month), "", 1, "C", false, 0, "")
+	pdf.SetFont("jp", "", 11)
+	pdf.CellFormat(0, 7, payslip.StoreName, "", 1, "R", false, 0, "")
+	pdf.CellFormat(0, 7, fmt.Sprintf("従業員ID %d　%s 様", payslip.Employee.ID, payslip.Employee.Name), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	for _, section := range payslipSections(payslip) {
+		pdf.SetFillColor(230, 230, 230)
+		pdf.CellFormat(170, 8, section.Title, "1", 1, "L", true, 0, "")
+		for _, row := range section.Rows {
+			pdf.CellFormat(100, 7, row.Label, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(70, 7, row.Value, "1", 1, "R", false, 0, "")
+		}
+		pdf.Ln(4)
+	}
+
+	return pdf.Output(w)
+}
+
+// 給与明細に出力する項目
+func payslipSections(payslip Payslip) []payslipSection {
+	run := payslip.Run
+	deductions := make([]payslipRow, 0, len(run.Deductions)+1)
+	for _, deduction := range run.Deductions {
+		deductions = append(deductions, payslipRow{deduction.Name, yenText(deduction.Amount)})
+	}
+	deductions = append(deductions, payslipRow{"控除合計", yenText(run.TotalDeductions)})
+
+	return []payslipSection{
+		{"勤怠", []payslipRow{
+			{"出勤日数", fmt.Sprintf("%d日", payslip.WorkDays)},
+			{"労働時間", hoursText(run.WorkHours) + "時間"},
+			{"深夜時間", hoursText(run.LateNightHours) + "時間"},
+			{"時間外（月60時間以内）", hoursText(run.OvertimeHours) + "時間"},
+			{"時間外（月60時間超）", hoursText(run.OvertimeOver60Hours) + "時間"},
+			{"法定休日労働", hoursText(run.HolidayHours) + "時間"},
+			{"時給", yenText(run.HourlyPay)},
+		}},
+		{"支給", []payslipRow{
+			{"基本給", yenText(run.BasePay)},
+			{"深夜割増", yenText(run.LateNightPremium)},
+			{"時間外割増", yenText(run.OvertimePremium)},
+			{"休日割増", yenText(run.HolidayPremium)},
+			{"手当", yenText(run.Allowances)},
+			{"総支給額", yenText(run.GrossPay)},
+		}},
+		{"控除", deductions},
+		{"差引支給額", []payslipRow{{"差引支給額", yenText(run.NetPay)}}},
+	}
+}
+
+// 金額を3桁区切りで出力
+func yenText(amount int) string {
+	digits := strconv.Itoa(amount)
+	sign := ""
+	if amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return sign + digits + "円"
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+func TestApplyDeductions(t *testing.T) {
+	tests := []struct {
+		name       string
+		deductions []model.PayrollDeduction
+		wantTotal  int
+		wantNet    int
+		wantErr    bool
+	}{
+		{"控除なし", nil, 0, 100000, false},
+		{"複数の控除", []model.PayrollDeduction{{Name: "源泉所得税", Amount: 2000}, {Name: "雇用保険料", Amount: 600}}, 2600, 97400, false},
+		{"総支給額と同額", []model.PayrollDeduction{{Name: "その他", Amount: 100000}}, 100000, 0, false},
+		{"総支給額を超える", []model.PayrollDeduction{{Name: "その他", Amount: 100001}}, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := &model.PayrollRun{GrossPay: 100000}
+			err := applyDeductions(run, tt.deductions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyDeductions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if run.TotalDeductions != tt.wantTotal || run.NetPay != tt.wantNet {
+				t.Errorf("TotalDeductions = %d, NetPay = %d, want %d, %d", run.TotalDeductions, run.NetPay, tt.wantTotal, tt.wantNet)
+			}
+		})
+	}
+}
+
+func TestValidateDeductions(t *testing.T) {
+	tests := []struct {
+		name       string
+		deductions []model.PayrollDeduction
+		wantErr    bool
+	}{
+		{"項目名と金額", []model.PayrollDeduction{{Name: " 住民税 ", Amount: 3000}}, false},
+		{"項目名が空", []model.PayrollDeduction{{Name: " ", Amount: 3000}}, true},
+		{"金額が負", []model.PayrollDeduction{{Name: "住民税", Amount: -1}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateDeductions(tt.deductions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateDeductions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got[0].Name != "住民税" {
+				t.Errorf("Name = %q, want 住民税", got[0].Name)
+			}
+		})
+	}
+}
+
+func TestYenText(t *testing.T) {
+	tests := []struct {
+		amount int
+		want   string
+	}{
+		{0, "0円"},
+		{999, "999円"},
+		{1000, "1,000円"},
+		{1234567, "1,234,567円"},
+		{-15300, "-15,300円"},
+	}
+	for _, tt := range tests {
+		if got := yenText(tt.amount); got != tt.want {
+			t.Errorf("yenText(%d) = %q, want %q", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestPayslipSections(t *testing.T) {
+	payslip := Payslip{
+		WorkDays: 12,
+		Run: &model.PayrollRun{
+			GrossPay:        120000,
+			Deductions:      []model.PayrollDeduction{{Name: "源泉所得税", Amount: 1500}},
+			TotalDeductions: 1500,
+			NetPay:          118500,
+		},
+	}
+
+	sections := payslipSections(payslip)
+	if len(sections) != 4 {
+		t.Fatalf("sections = %d, want 4", len(sections))
+	}
+	if got := sections[0].Rows[0]; got.Value != "12日" {
+		t.Errorf("出勤日数 = %v", got)
+	}
+	deductions := sections[2].Rows
+	if len(deductions) != 2 || deductions[0] != (payslipRow{"源泉所得税", "1,500円"}) || deductions[1] != (payslipRow{"控除合計", "1,500円"}) {
+		t.Errorf("控除 = %v", deductions)
+	}
+	if got := sections[3].Rows[0].Value; got != "118,500円" {
+		t.Errorf("差引支給額 = %q", got)
+	}
+}
+
+// PDFの描画は日本語フォントを指定した環境でのみ確認する
+func TestWritePayslipPDF(t *testing.T) {
+	if os.Getenv("PAYSLIP_FONT_PATH") == "" {
+		t.Skip("PAYSLIP_FONT_PATH is not set")
+	}
+
+	payslip := Payslip{
+		Employee:  model.Employee{Name: "山田太郎"},
+		StoreName: "本店",
+		Run:       &model.PayrollRun{Year: 2024, Month: 4, GrossPay: 1000, NetPay: 1000},
+	}
+	var buf bytes.Buffer
+	if err := WritePayslipPDF(&buf, payslip); err != nil {
+		t.Fatalf("WritePayslipPDF() error = %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Errorf("PDFではありません")
+	}
+}
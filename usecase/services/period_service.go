@@ -0,0 +1,153 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+var ErrPeriodClosed = errors.New("締め済みの期間の勤怠・給与は変更できません")
+
+type PeriodService struct {
+	repo      repositories.PeriodRepository
+	storeRepo repositories.StoreRepository
+}
+
+func NewPeriodService(repo repositories.PeriodRepository, storeRepo repositories.StoreRepository) *PeriodService {
+	return &PeriodService{repo: repo, storeRepo: storeRepo}
+}
+
+// 指定した年の締めの履歴を取得（storeID が0の場合は操作者が締められるすべての店舗）
+func (s *PeriodService) GetPeriodCloses(actor Actor, storeID uint, year int) ([]model.PeriodClose, error) {
+	if storeID != 0 {
+		if err := actor.authorizeStore(model.PermClosePeriod, storeID); err != nil {
+			return nil, err
+		}
+		return s.repo.GetPeriodCloses(storeID, year)
+	}
+
+	switch actor.Role.Scope(model.PermClosePeriod) {
+	case model.ScopeAll:
+		return s.repo.GetPeriodCloses(0, year)
+	case model.ScopeStore:
+		if actor.StoreID == 0 {
+			return []model.PeriodClose{}, nil
+		}
+		return s.repo.GetPeriodCloses(uint(actor.StoreID), year)
+	default:
+		return nil, ErrForbidden
+	}
+}
+
+// 店舗の月を締める（終了した月のみ）
+func (s *PeriodService) ClosePeriod(actor Actor, storeID uint, year int, month int) (*model.PeriodClose, error) {
+	if month < 1 || month > 12 {
+		return nil, fmt.Errorf("月が不正です: %d", month)
+	}
+	if err := actor.authorizeStore(model.PermClosePeriod, storeID); err != nil {
+		return nil, err
+	}
+	store, err := findStore(s.storeRepo, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 営業日の切り替え前の深夜の勤務も前月に含まれるため、店舗の勤務日で判定する
+	now := time.Now()
+	nextMonth := time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, storeLocation(store))
+	if businessDate(store, now).Before(nextMonth) {
+		return nil, errors.New("終了していない月は締められません")
+	}
+
+	current, err := s.repo.FindActivePeriodClose(storeID, year, month)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		return nil, errors.New("この月は既に締め済みです")
+	}
+
+	period := &model.PeriodClose{
+		StoreID:    storeID,
+		Year:       year,
+		Month:      month,
+		ClosedByID: actor.EmployeeID,
+		ClosedAt:   now,
+	}
+	if err := s.repo.CreatePeriodClose(period); err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+// 締めた月を再開する（再開の理由を記録する）
+func (s *PeriodService) ReopenPeriod(actor Actor, storeID uint, year int, month int, reason string) (*model.PeriodClose, error) {
+	if err := actor.authorizeStore(model.PermReopenPeriod, storeID); err != nil {
+		return nil, err
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.New("再開の理由を入力してください")
+	}
+
+	period, err := s.repo.FindActivePeriodClose(storeID, year, month)
+	if err != nil {
+		return nil, err
+	}
+	if period == nil {
+		return nil, errors.New("この月は締められていません")
+	}
+
+	now := time.Now()
+	reopenedBy := actor.EmployeeID
+	period.ReopenedByID = &reopenedBy
+	period.ReopenedAt = &now
+	period.ReopenReason = reason
+	if err := s.repo.UpdatePeriodClose(period); err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+// 店舗の勤務日を含む月が締められている場合はエラーを返す
+func ensurePeriodOpen(repo repositories.PeriodRepository, storeID uint, workDate time.Time) error {
+	if storeID == 0 {
+		return nil
+	}
+	period, err := repo.FindActivePeriodClose(storeID, workDate.Year(), int(workDate.Month()))
+	if err != nil {
+		return err
+	}
+	if period != nil {
+		return fmt.Errorf("%w（店舗ID %d、%d年%d月）", ErrPeriodClosed, storeID, period.Year, period.Month)
+	}
+	return nil
+}
+
+// from から to までの各月のいずれかが締められている場合はエラーを返す
+func ensurePeriodsOpenSince(repo repositories.PeriodRepository, storeID uint, from time.Time, to time.Time) error {
+	month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for ; !month.After(last); month = month.AddDate(0, 1, 0) {
+		if err := ensurePeriodOpen(repo, storeID, month); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 勤怠の勤務区間のいずれかの店舗で、勤務日の月が締められている場合はエラーを返す
+func ensureAttendanceOpen(repo repositories.PeriodRepository, attendances ...*model.Attendance) error {
+	for _, attendance := range attendances {
+		for _, segment := range attendance.Segments {
+			if err := ensurePeriodOpen(repo, segment.StoreID, attendance.WorkDate); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// 締めた店舗・月だけを保持する PeriodRepository
+type closedPeriods map[[3]int]bool
+
+func (c closedPeriods) CreatePeriodClose(period *model.PeriodClose) error {
+	c[[3]int{int(period.StoreID), period.Year, period.Month}] = true
+	return nil
+}
+
+func (c closedPeriods) FindActivePeriodClose(storeID uint, year int, month int) (*model.PeriodClose, error) {
+	if !c[[3]int{int(storeID), year, month}] {
+		return nil, nil
+	}
+	return &model.PeriodClose{StoreID: storeID, Year: year, Month: month}, nil
+}
+
+func (c closedPeriods) GetPeriodCloses(storeID uint, year int) ([]model.PeriodClose, error) {
+	return nil, nil
+}
+
+func (c closedPeriods) UpdatePeriodClose(period *model.PeriodClose) error {
+	return nil
+}
+
+func TestEnsureAttendanceOpen(t *testing.T) {
+	repo := closedPeriods{{1, 2024, 3}: true}
+	attendance := func(workDate time.Time, storeIDs ...uint) *model.Attendance {
+		a := &model.Attendance{WorkDate: workDate}
+		for _, storeID := range storeIDs {
+			a.Segments = append(a.Segments, model.WorkSegment{StoreID: storeID})
+		}
+		return a
+	}
+
+	tests := []struct {
+		name        string
+		attendances []*model.Attendance
+		wantClosed  bool
+	}{
+		{"締めていない月", []*model.Attendance{attendance(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), 1)}, false},
+		{"締めていない店舗", []*model.Attendance{attendance(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), 2)}, false},
+		{"締めた店舗・月", []*model.Attendance{attendance(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), 1)}, true},
+		{"後半の区間が締めた店舗", []*model.Attendance{attendance(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), 2, 1)}, true},
+		{"修正後の勤怠が締めた月", []*model.Attendance{
+			attendance(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), 2),
+			attendance(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), 1),
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ensureAttendanceOpen(repo, tt.attendances...)
+			if got := errors.Is(err, ErrPeriodClosed); got != tt.wantClosed {
+				t.Errorf("ensureAttendanceOpen() error = %v, wantClosed %v", err, tt.wantClosed)
+			}
+		})
+	}
+}
+
+func TestEnsurePeriodsOpenSince(t *testing.T) {
+	repo := closedPeriods{{1, 2024, 3}: true}
+	now := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		storeID    uint
+		from       time.Time
+		wantClosed bool
+	}{
+		{"締めた月より後から適用", 1, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), false},
+		{"締めた月から適用", 1, time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), true},
+		{"締めた月より前から適用", 1, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"年をまたいで適用", 1, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), true},
+		{"担当店舗なし", 0, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ensurePeriodsOpenSince(repo, tt.storeID, tt.from, now)
+			if got := errors.Is(err, ErrPeriodClosed); got != tt.wantClosed {
+				t.Errorf("ensurePeriodsOpenSince() error = %v, wantClosed %v", err, tt.wantClosed)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// シフトと実績の差異として扱わない許容範囲
+type reconcileTolerance struct {
+	Late       time.Duration // 遅刻
+	EarlyLeave time.Duration // 早退
+}
+
+// 店舗で設定できる許容範囲の上限（分）
+const maxReconcileTolerance = 60
+
+// 店舗で設定していない場合の許容範囲（環境変数 RECONCILE_LATE_MINUTES・RECONCILE_EARLY_LEAVE_MINUTES で変更可能、既定は5分）
+func reconcileTolerances() reconcileTolerance {
+	return reconcileTolerance{
+		Late:       envMinutes("RECONCILE_LATE_MINUTES", 5),
+		EarlyLeave: envMinutes("RECONCILE_EARLY_LEAVE_MINUTES", 5),
+	}
+}
+
+// 環境変数の分数を取得（未設定・不正な場合は既定値）
+func envMinutes(key string, fallback int) time.Duration {
+	if minutes, err := strconv.Atoi(os.Getenv(key)); err == nil && minutes >= 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return time.Duration(fallback) * time.Minute
+}
+
+// 店舗の許容範囲（店舗で設定していない項目は既定値）
+func (t reconcileTolerance) forStore(store *model.Store) reconcileTolerance {
+	if store.LateTolerance != nil {
+		t.Late = time.Duration(*store.LateTolerance) * time.Minute
+	}
+	if store.EarlyLeaveTolerance != nil {
+		t.EarlyLeave = time.Duration(*store.EarlyLeaveTolerance) * time.Minute
+	}
+	return t
+}
+
+// 1日分のシフト（開始時刻順）と勤怠を突き合わせて差異を返す
+// 終わっていないシフトは欠勤・早退として扱わない（勤務時間中に出勤していない場合も、終了までは欠勤にしない）
+func reconcileDay(shifts []model.Shift, attendance *model.Attendance, now time.Time, tolerance reconcileTolerance) []model.Deviation {
+	deviations := []model.Deviation{}
+	worked := attendance != nil && len(attendance.Segments) > 0
+
+	if len(shifts) == 0 {
+		if worked {
+			deviations = append(deviations, model.Deviation{Type: model.DeviationUnscheduled, Minutes: minutesOf(calculateWorkDuration(*attendance))})
+		}
+		return deviations
+	}
+
+	plannedStart := shifts[0].StartTime
+	plannedEnd := shifts[0].EndTime
+	var planned time.Duration
+	for _, shift := range shifts {
+		if shift.EndTime.After(plannedEnd) {
+			plannedEnd = shift.EndTime
+		}
+		planned += shift.EndTime.Sub(shift.StartTime)
+	}
+
+	if !worked {
+		if now.After(plannedEnd) {
+			deviations = append(deviations, model.Deviation{Type: model.DeviationNoShow, Minutes: minutesOf(planned)})
+		}
+		return deviations
+	}
+
+	if late := attendance.Segments[0].StartTime.Sub(plannedStart); late > tolerance.Late {
+		deviations = append(deviations, model.Deviation{Type: model.DeviationLate, Minutes: minutesOf(late)})
+	}
+	if actualEnd := attendance.LastSegment().EndTime; actualEnd != nil {
+		if early := plannedEnd.Sub(*actualEnd); early > tolerance.EarlyLeave {
+			deviations = append(deviations, model.Deviation{Type: model.DeviationEarlyLeave, Minutes: minutesOf(early)})
+		}
+	}
+	return deviations
+}
+
+// 分単位（切り捨て）
+func minutesOf(d time.Duration) int {
+	return int(d / time.Minute)
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+type ReconciliationService struct {
+	summaryRepo repositories.SummaryRepository
+	shiftRepo   repositories.ShiftRepository
+	empRepo     repositories.EmployeeRepository
+	storeRepo   repositories.StoreRepository
+	tolerance   reconcileTolerance // 店舗で設定していない場合の許容範囲
+}
+
+func NewReconciliationService(summaryRepo repositories.SummaryRepository, shiftRepo repositories.ShiftRepository, empRepo repositories.EmployeeRepository, storeRepo repositories.StoreRepository) *ReconciliationService {
+	return &ReconciliationService{summaryRepo: summaryRepo, shiftRepo: shiftRepo, empRepo: empRepo, storeRepo: storeRepo, tolerance: reconcileTolerances()}
+}
+
+// 指定した月の公開済みのシフトと勤怠を勤務日ごとに突き合わせる
+func (s *ReconciliationService) GetReconciliation(actor Actor, employeeID uint, year int, month int) ([]model.ReconciliationDay, error) {
+	employee, err := s.empRepo.FindEmpByEmpID(int(employeeID))
+	if err != nil {
+		return nil, err
+	}
+	if err := actor.authorize(model.PermViewSummary, employee); err != nil {
+		return nil, err
+	}
+
+	monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, -1)
+	attendances, err := s.summaryRepo.GetAttendanceBetween(employeeID, monthStart, monthEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// 営業日の切り替え時刻によって前後の日付に入るシフトがあるため前後1日を含めて取得する
+	shifts, err := s.shiftRepo.GetEmployeeShifts(employeeID, monthStart.AddDate(0, 0, -1), monthEnd.AddDate(0, 0, 2), true)
+	if err != nil {
+		return nil, err
+	}
+	stores, err := storesByID(s.storeRepo)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := storeLocations(s.storeRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 勤務日ごとにまとめる
+	attendanceByDate := map[string]*model.Attendance{}
+	for i := range attendances {
+		attendanceByDate[attendances[i].WorkDate.Format("2006-01-02")] = &attendances[i]
+	}
+	shiftsByDate := map[string][]model.Shift{}
+	for _, shift := range shifts {
+		store, ok := stores[shift.StoreID]
+		if !ok {
+			store = &model.Store{}
+		}
+		date := businessDate(store, shift.StartTime)
+		if date.Year() != year || int(date.Month()) != month {
+			continue
+		}
+		key := date.Format("2006-01-02")
+		shiftsByDate[key] = append(shiftsByDate[key], shift)
+	}
+
+	dates := []string{}
+	for date := range attendanceByDate {
+		dates = append(dates, date)
+	}
+	for date := range shiftsByDate {
+		if _, ok := attendanceByDate[date]; !ok {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+
+	now := time.Now()
+	days := make([]model.ReconciliationDay, 0, len(dates))
+	for _, date := range dates {
+		workDate, _ := time.Parse("2006-01-02", date)
+		dayShifts := shiftsByDate[date]
+		attendance := attendanceByDate[date]
+
+		// 許容範囲はシフトの店舗の設定に従う
+		tolerance := s.tolerance
+		if len(dayShifts) > 0 {
+			if store, ok := stores[dayShifts[0].StoreID]; ok {
+				tolerance = tolerance.forStore(store)
+			}
+		}
+
+		day := model.ReconciliationDay{
+			WorkDate:   formatDate(&workDate),
+			Deviations: reconcileDay(dayShifts, attendance, now, tolerance),
+		}
+		if len(dayShifts) > 0 {
+			loc := locationOf(locations, dayShifts[0].StoreID)
+			plannedStart := dayShifts[0].StartTime.In(loc)
+			plannedEnd := dayShifts[0].EndTime.In(loc)
+			for _, shift := range dayShifts[1:] {
+				if shift.EndTime.After(plannedEnd) {
+					plannedEnd = shift.EndTime.In(loc)
+				}
+			}
+			day.PlannedStart = formatTime(&plannedStart)
+			day.PlannedEnd = formatTime(&plannedEnd)
+		}
+		if attendance != nil && len(attendance.Segments) > 0 {
+			loc := attendanceLocation(*attendance, locations)
+			actualStart := attendance.Segments[0].StartTime.In(loc)
+			day.ActualStart = formatTime(&actualStart)
+			if end := attendance.LastSegment().EndTime; end != nil {
+				actualEnd := end.In(loc)
+				day.ActualEnd = formatTime(&actualEnd)
+			}
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
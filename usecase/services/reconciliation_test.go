@@ -0,0 +1,129 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+func TestReconcileDay(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 4, 1, hour, minute, 0, 0, jst)
+	}
+	shift := func(start, end time.Time) model.Shift {
+		return model.Shift{StartTime: start, EndTime: end}
+	}
+	worked := func(start time.Time, end *time.Time) *model.Attendance {
+		return &model.Attendance{Segments: []model.WorkSegment{{StartTime: start, EndTime: end}}}
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tolerance := reconcileTolerance{Late: 5 * time.Minute, EarlyLeave: 5 * time.Minute}
+	afterDay := at(23, 0)
+
+	tests := []struct {
+		name       string
+		shifts     []model.Shift
+		attendance *model.Attendance
+		now        time.Time
+		want       []model.Deviation
+	}{
+		{
+			name:       "予定どおり",
+			shifts:     []model.Shift{shift(at(9, 0), at(17, 0))},
+			attendance: worked(at(8, 55), ptr(at(17, 0))),
+			now:        afterDay,
+			want:       []model.Deviation{},
+		},
+		{
+			name:       "許容範囲内の遅刻・早退",
+			shifts:     []model.Shift{shift(at(9, 0), at(17, 0))},
+			attendance: worked(at(9, 5), ptr(at(16, 55))),
+			now:        afterDay,
+			want:       []model.Deviation{},
+		},
+		{
+			name:       "遅刻と早退",
+			shifts:     []model.Shift{shift(at(9, 0), at(17, 0))},
+			attendance: worked(at(9, 20), ptr(at(16, 30))),
+			now:        afterDay,
+			want: []model.Deviation{
+				{Type: model.DeviationLate, Minutes: 20},
+				{Type: model.DeviationEarlyLeave, Minutes: 30},
+			},
+		},
+		{
+			name:       "勤務中は早退として扱わない",
+			shifts:     []model.Shift{shift(at(9, 0), at(17, 0))},
+			attendance: worked(at(9, 0), nil),
+			now:        at(12, 0),
+			want:       []model.Deviation{},
+		},
+		{
+			name:   "欠勤",
+			shifts: []model.Shift{shift(at(9, 0), at(13, 0)), shift(at(14, 0), at(18, 0))},
+			now:    afterDay,
+			want:   []model.Deviation{{Type: model.DeviationNoShow, Minutes: 480}},
+		},
+		{
+			name:   "開始前のシフトは欠勤にしない",
+			shifts: []model.Shift{shift(at(9, 0), at(17, 0))},
+			now:    at(8, 0),
+			want:   []model.Deviation{},
+		},
+		{
+			name:   "シフトの時間中は未出勤でも欠勤にしない",
+			shifts: []model.Shift{shift(at(9, 0), at(17, 0))},
+			now:    at(12, 0),
+			want:   []model.Deviation{},
+		},
+		{
+			name:       "シフトのない日の勤務",
+			attendance: worked(at(10, 0), ptr(at(13, 0))),
+			now:        afterDay,
+			want:       []model.Deviation{{Type: model.DeviationUnscheduled, Minutes: 180}},
+		},
+		{
+			name:       "複数のシフトは最初の開始と最後の終了で比較",
+			shifts:     []model.Shift{shift(at(9, 0), at(13, 0)), shift(at(14, 0), at(18, 0))},
+			attendance: worked(at(9, 0), ptr(at(17, 0))),
+			now:        afterDay,
+			want:       []model.Deviation{{Type: model.DeviationEarlyLeave, Minutes: 60}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reconcileDay(tt.shifts, tt.attendance, tt.now, tolerance)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reconcileDay() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileToleranceForStore(t *testing.T) {
+	defaults := reconcileTolerance{Late: 5 * time.Minute, EarlyLeave: 5 * time.Minute}
+	minutes := func(n int) *int { return &n }
+
+	tests := []struct {
+		name  string
+		store *model.Store
+		want  reconcileTolerance
+	}{
+		{"店舗で設定していない場合は既定値", &model.Store{}, defaults},
+		{"店舗で設定した項目のみ変更", &model.Store{LateTolerance: minutes(10)}, reconcileTolerance{Late: 10 * time.Minute, EarlyLeave: 5 * time.Minute}},
+		{"許容範囲なし", &model.Store{LateTolerance: minutes(0), EarlyLeaveTolerance: minutes(0)}, reconcileTolerance{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaults.forStore(tt.store); got != tt.want {
+				t.Errorf("forStore() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
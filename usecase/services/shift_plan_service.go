@@ -0,0 +1,299 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+type ShiftPlanService struct {
+	targetRepo       repositories.StaffingTargetRepository
+	availabilityRepo repositories.AvailabilityRepository
+	shiftRepo        repositories.ShiftRepository
+	empRepo          repositories.EmployeeRepository
+	storeRepo        repositories.StoreRepository
+	wageRepo         repositories.WageRepository
+	ruleRepo         repositories.WageRuleRepository
+	holidayRepo      repositories.HolidayRepository
+}
+
+func NewShiftPlanService(targetRepo repositories.StaffingTargetRepository, availabilityRepo repositories.AvailabilityRepository, shiftRepo repositories.ShiftRepository, empRepo repositories.EmployeeRepository, storeRepo repositories.StoreRepository, wageRepo repositories.WageRepository, ruleRepo repositories.WageRuleRepository, holidayRepo repositories.HolidayRepository) *ShiftPlanService {
+	return &ShiftPlanService{
+		targetRepo:       targetRepo,
+		availabilityRepo: availabilityRepo,
+		shiftRepo:        shiftRepo,
+		empRepo:          empRepo,
+		storeRepo:        storeRepo,
+		wageRepo:         wageRepo,
+		ruleRepo:         ruleRepo,
+		holidayRepo:      holidayRepo,
+	}
+}
+
+// 店舗の必要人数を取得
+func (s *ShiftPlanService) GetStaffingTargets(actor Actor, storeID uint) ([]model.StaffingTarget, error) {
+	if err := actor.authorizeStore(model.PermManageShifts, storeID); err != nil {
+		return nil, err
+	}
+	return s.targetRepo.GetStaffingTargets(storeID)
+}
+
+// 店舗の必要人数を登録（登録済みの必要人数は置き換える）
+func (s *ShiftPlanService) UpdateStaffingTargets(actor Actor, storeID uint, targets []model.StaffingTarget) ([]model.StaffingTarget, error) {
+	if err := actor.authorizeStore(model.PermManageShifts, storeID); err != nil {
+		return nil, err
+	}
+	if _, err := findActiveStore(s.storeRepo, storeID); err != nil {
+		return nil, err
+	}
+	for i := range targets {
+		if err := validateStaffingTarget(&targets[i]); err != nil {
+			return nil, err
+		}
+		targets[i].StoreID = storeID
+	}
+
+	if err := s.targetRepo.ReplaceStaffingTargets(storeID, targets); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// 必要人数・勤務希望・人件費から指定した週のシフトを下書きとして作成する
+// 公開はしないため、店長・オーナーが確認・修正してから公開する
+func (s *ShiftPlanService) GenerateShifts(actor Actor, storeID uint, weekStart time.Time, limits ShiftPlanLimits) (*model.ShiftPlan, error) {
+	if err := actor.authorizeStore(model.PermManageShifts, storeID); err != nil {
+		return nil, err
+	}
+	limits = limits.withDefaults()
+	if limits.MaxDaily <= 0 || limits.MaxWeekly <= 0 || limits.MinShift <= 0 || limits.MinShift > limits.MaxDaily {
+		return nil, errors.New("自動作成の条件が不正です")
+	}
+	store, err := findActiveStore(s.storeRepo, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := storeLocation(store)
+	from := time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, loc)
+	days := make([]time.Time, 7)
+	for i := range days {
+		days[i] = from.AddDate(0, 0, i)
+	}
+
+	need, err := s.staffingNeed(storeID, days)
+	if err != nil {
+		return nil, err
+	}
+
+	employees, err := s.empRepo.GetEmployeesByStore(storeID)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]*planCandidate, 0, len(employees))
+	for i := range employees {
+		// 退職処理をした従業員にはシフトを割り当てない
+		if !employees[i].IsActive() {
+			continue
+		}
+		candidate, err := s.loadCandidate(&employees[i], storeID, days)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	plan := &model.ShiftPlan{Shifts: []model.Shift{}}
+	for _, planned := range planShifts(need, candidates, limits) {
+		day := days[planned.Day]
+		shift := model.Shift{
+			EmployeeID: planned.EmployeeID,
+			StoreID:    storeID,
+			StartTime:  day.Add(time.Duration(planned.StartHour) * time.Hour),
+			EndTime:    day.Add(time.Duration(planned.EndHour) * time.Hour),
+			Note:       "自動作成",
+		}
+		if err := s.shiftRepo.CreateShift(&shift); err != nil {
+			return nil, err
+		}
+		plan.Shifts = append(plan.Shifts, shift)
+		plan.EstimatedCost += planned.Cost
+	}
+	plan.Shortages = staffingShortages(need, days)
+	return plan, nil
+}
+
+// 日ごと・時ごとの不足人数（必要人数から作成済みのシフトの人数を引いたもの）
+func (s *ShiftPlanService) staffingNeed(storeID uint, days []time.Time) ([][24]int, error) {
+	targets, err := s.targetRepo.GetStaffingTargets(storeID)
+	if err != nil {
+		return nil, err
+	}
+	need := make([][24]int, len(days))
+	for i, day := range days {
+		for _, target := range targets {
+			if target.Weekday != int(day.Weekday()) {
+				continue
+			}
+			for hour := target.StartHour; hour < target.EndHour; hour++ {
+				need[i][hour] = max(need[i][hour], target.Headcount)
+			}
+		}
+	}
+
+	// 下書きを含めて作成済みのシフトで埋まっている時間帯は差し引く
+	shifts, err := s.shiftRepo.GetStoreShifts(storeID, days[0], days[len(days)-1].AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	for _, shift := range shifts {
+		forEachHour(days, shift.StartTime, shift.EndTime, func(day int, hour int) {
+			if need[day][hour] > 0 {
+				need[day][hour]--
+			}
+		})
+	}
+	return need, nil
+}
+
+// 従業員の勤務希望・作成済みのシフト・時給を自動作成用にまとめる
+func (s *ShiftPlanService) loadCandidate(employee *model.Employee, storeID uint, days []time.Time) (*planCandidate, error) {
+	from, to := days[0], days[len(days)-1].AddDate(0, 0, 1)
+	candidate := &planCandidate{
+		EmployeeID: employee.ID,
+		Status:     make([][24]model.AvailabilityStatus, len(days)),
+		Rates:      make([][24]int, len(days)),
+		DailyHours: make([]int, len(days)),
+		Assigned:   make([]bool, len(days)),
+	}
+
+	// 勤務希望を提出していない日は勤務不可として扱う
+	availabilities, err := s.availabilityRepo.GetEmployeeAvailabilities(employee.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, availability := range availabilities {
+		day := dayIndex(days, availability.Date.Format("2006-01-02"))
+		if day < 0 {
+			continue
+		}
+		start, end := 0, 24*60
+		if availability.StartTime != "" {
+			start, end = clockMinutes(availability.StartTime), clockMinutes(availability.EndTime)
+			if end <= start {
+				end = 24 * 60
+			}
+		}
+		for hour := 0; hour < 24; hour++ {
+			if hour*60 < start || (hour+1)*60 > end {
+				continue
+			}
+			candidate.Status[day][hour] = mergeAvailability(candidate.Status[day][hour], availability.Status)
+		}
+	}
+
+	// 他の店舗を含む作成済みのシフトの時間は勤務不可にし、勤務時間に含める
+	shifts, err := s.shiftRepo.GetEmployeeShifts(employee.ID, from.AddDate(0, 0, -1), to, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, shift := range shifts {
+		forEachHour(days, shift.StartTime, shift.EndTime, func(day int, hour int) {
+			candidate.Status[day][hour] = model.AvailabilityUnavailable
+		})
+		if !shift.StartTime.Before(from) {
+			if day := dayIndex(days, shift.StartTime.In(from.Location()).Format("2006-01-02")); day >= 0 {
+				hours := int(shift.EndTime.Sub(shift.StartTime) / time.Hour)
+				candidate.DailyHours[day] += hours
+				candidate.Hours += hours
+			}
+		}
+	}
+
+	wages, err := loadWageTable(s.wageRepo, employee)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := loadWageRules(s.ruleRepo, s.holidayRepo, s.storeRepo, employee.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for i, day := range days {
+		base := wages.rateOn(day)
+		for hour := 0; hour < 24; hour++ {
+			candidate.Rates[i][hour] = rules.rateAt(base, storeID, day, day.Add(time.Duration(hour)*time.Hour))
+		}
+	}
+	return candidate, nil
+}
+
+// 同じ時間帯に複数の勤務希望がある場合は勤務不可＞勤務を希望＞勤務可能の順に優先する
+func mergeAvailability(current model.AvailabilityStatus, next model.AvailabilityStatus) model.AvailabilityStatus {
+	rank := map[model.AvailabilityStatus]int{
+		model.AvailabilityAvailable:   1,
+		model.AvailabilityPreferred:   2,
+		model.AvailabilityUnavailable: 3,
+	}
+	if rank[next] > rank[current] {
+		return next
+	}
+	return current
+}
+
+// 日付（YYYY-MM-DD）が週の何日目か（週に含まれない場合は -1）
+func dayIndex(days []time.Time, date string) int {
+	for i, day := range days {
+		if day.Format("2006-01-02") == date {
+			return i
+		}
+	}
+	return -1
+}
+
+// 期間に少しでも重なる、週に含まれる1時間ごとの時間帯について処理する
+func forEachHour(days []time.Time, start time.Time, end time.Time, fn func(day int, hour int)) {
+	for i, day := range days {
+		for hour := 0; hour < 24; hour++ {
+			blockStart := day.Add(time.Duration(hour) * time.Hour)
+			if blockStart.Before(end) && blockStart.Add(time.Hour).After(start) {
+				fn(i, hour)
+			}
+		}
+	}
+}
+
+// 不足人数を連続する時間帯ごとにまとめる
+func staffingShortages(need [][24]int, days []time.Time) []model.StaffingShortage {
+	shortages := []model.StaffingShortage{}
+	for i, day := range days {
+		for hour := 0; hour < 24; hour++ {
+			if need[i][hour] == 0 {
+				continue
+			}
+			last := len(shortages) - 1
+			date := day.Format("2006-01-02")
+			if last >= 0 && shortages[last].Date == date && shortages[last].EndHour == hour && shortages[last].Missing == need[i][hour] {
+				shortages[last].EndHour++
+				continue
+			}
+			shortages = append(shortages, model.StaffingShortage{Date: date, StartHour: hour, EndHour: hour + 1, Missing: need[i][hour]})
+		}
+	}
+	return shortages
+}
+
+// 入力値の検証
+func validateStaffingTarget(target *model.StaffingTarget) error {
+	if target.Weekday < 0 || target.Weekday > 6 {
+		return errors.New("曜日は0（日曜）〜6（土曜）で入力してください")
+	}
+	if target.StartHour < 0 || target.EndHour > 24 || target.StartHour >= target.EndHour {
+		return errors.New("時間帯は0〜24時の範囲で、開始を終了より前にしてください")
+	}
+	if target.Headcount <= 0 {
+		return errors.New("必要人数は1以上で入力してください")
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+// 勤務希望を優先する度合い（勤務希望の時間帯は人件費をこの割合で評価する）
+const preferredCostRatio = 0.9
+
+// シフトの自動作成の制約（時間単位、0 は既定値）
+type ShiftPlanLimits struct {
+	MaxDaily  int // 1日の最大勤務時間
+	MaxWeekly int // 1週間の最大勤務時間
+	MinShift  int // 1回のシフトの最短の長さ
+}
+
+// 指定されていない制約に既定値（1日8時間・週40時間を超えない、最短3時間）を入れる
+func (l ShiftPlanLimits) withDefaults() ShiftPlanLimits {
+	if l.MaxDaily == 0 {
+		l.MaxDaily = int(dailyWorkLimit / time.Hour)
+	}
+	if l.MaxWeekly == 0 {
+		l.MaxWeekly = int(weeklyWorkLimit / time.Hour)
+	}
+	if l.MinShift == 0 {
+		l.MinShift = 3
+	}
+	return l
+}
+
+// 自動作成の対象の従業員
+type planCandidate struct {
+	EmployeeID uint
+	Status     [][24]model.AvailabilityStatus // 日ごと・時ごとの勤務希望（空は勤務不可）
+	Rates      [][24]int                      // 日ごと・時ごとの時給
+	DailyHours []int                          // 日ごとの割り当て済みの時間
+	Hours      int                            // 1週間の割り当て済みの時間
+	Assigned   []bool                         // 自動作成でシフトを割り当てた日
+}
+
+// 時刻に勤務できるか
+func (c *planCandidate) available(day int, hour int) bool {
+	status := c.Status[day][hour]
+	return status == model.AvailabilityAvailable || status == model.AvailabilityPreferred
+}
+
+// 自動作成したシフト（Day は週の何日目か、時刻は店舗のタイムゾーンの時）
+type plannedShift struct {
+	EmployeeID uint
+	Day        int
+	StartHour  int
+	EndHour    int
+	Cost       int
+}
+
+// 必要人数（日ごと・時ごと）を満たすようにシフトを割り当てる
+// 不足している最も早い時間帯から順に、1時間あたりの人件費が最も安い従業員を割り当てていく貪欲法で、
+// 1日に割り当てるシフトは従業員ごとに1回までとする。割り当てられなかった人数は need に残る
+func planShifts(need [][24]int, candidates []*planCandidate, limits ShiftPlanLimits) []plannedShift {
+	planned := []plannedShift{}
+	skipped := make([][24]bool, len(need))
+	for {
+		day, hour, ok := nextShortage(need, skipped)
+		if !ok {
+			return planned
+		}
+
+		var best *planCandidate
+		var bestShift plannedShift
+		bestScore := 0.0
+		for _, candidate := range candidates {
+			start, end, ok := shiftWindow(candidate, need, day, hour, limits)
+			if !ok {
+				continue
+			}
+
+			cost, covered := 0, 0
+			for h := start; h < end; h++ {
+				cost += candidate.Rates[day][h]
+				if need[day][h] > 0 {
+					covered++
+				}
+			}
+			score := float64(cost) / float64(covered)
+			if candidate.Status[day][hour] == model.AvailabilityPreferred {
+				score *= preferredCostRatio
+			}
+
+			// 同じ評価の場合は割り当てた時間の少ない従業員を優先する
+			if best == nil || score < bestScore || (score == bestScore && candidate.Hours < best.Hours) {
+				best = candidate
+				bestScore = score
+				bestShift = plannedShift{EmployeeID: candidate.EmployeeID, Day: day, StartHour: start, EndHour: end, Cost: cost}
+			}
+		}
+
+		if best == nil {
+			skipped[day][hour] = true
+			continue
+		}
+
+		for h := bestShift.StartHour; h < bestShift.EndHour; h++ {
+			if need[day][h] > 0 {
+				need[day][h]--
+			}
+		}
+		length := bestShift.EndHour - bestShift.StartHour
+		best.DailyHours[day] += length
+		best.Hours += length
+		best.Assigned[day] = true
+		planned = append(planned, bestShift)
+	}
+}
+
+// 人数が不足している最も早い時間帯（割り当てられる従業員がいなかった時間帯を除く）
+func nextShortage(need [][24]int, skipped [][24]bool) (int, int, bool) {
+	for day := range need {
+		for hour := 0; hour < 24; hour++ {
+			if need[day][hour] > 0 && !skipped[day][hour] {
+				return day, hour, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// 指定した時刻から始まる、従業員に割り当てられるシフトの時間帯
+// 人数が不足している間は延ばし、最短の長さに満たない場合は勤務できる前後の時間に広げる
+func shiftWindow(candidate *planCandidate, need [][24]int, day int, hour int, limits ShiftPlanLimits) (int, int, bool) {
+	if candidate.Assigned[day] || !candidate.available(day, hour) {
+		return 0, 0, false
+	}
+	limit := min(limits.MaxDaily-candidate.DailyHours[day], limits.MaxWeekly-candidate.Hours)
+	if limit < max(limits.MinShift, 1) {
+		return 0, 0, false
+	}
+
+	start, end := hour, hour+1
+	for end < 24 && end-start < limit && candidate.available(day, end) && need[day][end] > 0 {
+		end++
+	}
+	for end < 24 && end-start < limits.MinShift && candidate.available(day, end) {
+		end++
+	}
+	for start > 0 && end-start < limits.MinShift && candidate.available(day, start-1) {
+		start--
+	}
+	if end-start < limits.MinShift {
+		return 0, 0, false
+	}
+	return start, end, true
+}
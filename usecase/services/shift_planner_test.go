@@ -0,0 +1,131 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+func TestPlanShifts(t *testing.T) {
+	const (
+		A = model.AvailabilityAvailable
+		P = model.AvailabilityPreferred
+	)
+	// 1日分の必要人数・勤務希望・時給を作る
+	hours := func(start, end, value int) [24]int {
+		var day [24]int
+		for h := start; h < end; h++ {
+			day[h] = value
+		}
+		return day
+	}
+	candidate := func(id uint, status model.AvailabilityStatus, start, end, rate int) *planCandidate {
+		var day [24]model.AvailabilityStatus
+		for h := start; h < end; h++ {
+			day[h] = status
+		}
+		return &planCandidate{
+			EmployeeID: id,
+			Status:     [][24]model.AvailabilityStatus{day},
+			Rates:      [][24]int{hours(0, 24, rate)},
+			DailyHours: []int{0},
+			Assigned:   []bool{false},
+		}
+	}
+	limits := ShiftPlanLimits{MaxDaily: 8, MaxWeekly: 40, MinShift: 3}
+
+	tests := []struct {
+		name       string
+		need       [24]int
+		candidates []*planCandidate
+		limits     ShiftPlanLimits
+		want       []plannedShift
+		wantNeed   [24]int
+	}{
+		{
+			name:       "時給の安い従業員を割り当てる",
+			need:       hours(10, 14, 1),
+			candidates: []*planCandidate{candidate(1, A, 9, 22, 1200), candidate(2, A, 9, 22, 1100)},
+			limits:     limits,
+			want:       []plannedShift{{EmployeeID: 2, Day: 0, StartHour: 10, EndHour: 14, Cost: 4400}},
+		},
+		{
+			name:       "勤務希望の時間帯を優先する",
+			need:       hours(10, 14, 1),
+			candidates: []*planCandidate{candidate(1, A, 9, 22, 1100), candidate(2, P, 9, 22, 1200)},
+			limits:     limits,
+			want:       []plannedShift{{EmployeeID: 2, Day: 0, StartHour: 10, EndHour: 14, Cost: 4800}},
+		},
+		{
+			name:       "1日の最大勤務時間で分ける",
+			need:       hours(9, 21, 1),
+			candidates: []*planCandidate{candidate(1, A, 9, 22, 1100), candidate(2, A, 9, 22, 1200)},
+			limits:     limits,
+			want: []plannedShift{
+				{EmployeeID: 1, Day: 0, StartHour: 9, EndHour: 17, Cost: 8800},
+				{EmployeeID: 2, Day: 0, StartHour: 17, EndHour: 21, Cost: 4800},
+			},
+		},
+		{
+			name:       "最短の長さに満たない場合は前後に広げる",
+			need:       hours(12, 13, 1),
+			candidates: []*planCandidate{candidate(1, A, 11, 14, 1100)},
+			limits:     limits,
+			want:       []plannedShift{{EmployeeID: 1, Day: 0, StartHour: 11, EndHour: 14, Cost: 3300}},
+		},
+		{
+			name:       "最短の長さを確保できない従業員は割り当てない",
+			need:       hours(12, 13, 1),
+			candidates: []*planCandidate{candidate(1, A, 12, 14, 1100)},
+			limits:     limits,
+			want:       []plannedShift{},
+			wantNeed:   hours(12, 13, 1),
+		},
+		{
+			name:       "週の最大勤務時間を超えない",
+			need:       hours(10, 14, 1),
+			candidates: []*planCandidate{func() *planCandidate { c := candidate(1, A, 9, 22, 1100); c.Hours = 38; return c }()},
+			limits:     limits,
+			want:       []plannedShift{},
+			wantNeed:   hours(10, 14, 1),
+		},
+		{
+			name:       "勤務できる従業員がいない時間帯は不足として残す",
+			need:       hours(10, 18, 2),
+			candidates: []*planCandidate{candidate(1, A, 10, 18, 1100)},
+			limits:     limits,
+			want:       []plannedShift{{EmployeeID: 1, Day: 0, StartHour: 10, EndHour: 18, Cost: 8800}},
+			wantNeed:   hours(10, 18, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			need := [][24]int{tt.need}
+			got := planShifts(need, tt.candidates, tt.limits)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planShifts() = %+v, want %+v", got, tt.want)
+			}
+			if need[0] != tt.wantNeed {
+				t.Errorf("remaining need = %v, want %v", need[0], tt.wantNeed)
+			}
+		})
+	}
+}
+
+func TestStaffingShortages(t *testing.T) {
+	var day [24]int
+	day[10], day[11], day[12], day[18] = 1, 1, 2, 1
+	days := []time.Time{time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)}
+
+	want := []model.StaffingShortage{
+		{Date: "2024-04-01", StartHour: 10, EndHour: 12, Missing: 1},
+		{Date: "2024-04-01", StartHour: 12, EndHour: 13, Missing: 2},
+		{Date: "2024-04-01", StartHour: 18, EndHour: 19, Missing: 1},
+	}
+	if got := staffingShortages([][24]int{day}, days); !reflect.DeepEqual(got, want) {
+		t.Errorf("staffingShortages() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,320 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+var ErrShiftSwapNotFound = errors.New("シフト交代の申請が見つかりません")
+
+type ShiftRequestService struct {
+	availabilityRepo repositories.AvailabilityRepository
+	swapRepo         repositories.ShiftSwapRepository
+	shiftRepo        repositories.ShiftRepository
+	empRepo          repositories.EmployeeRepository
+}
+
+func NewShiftRequestService(availabilityRepo repositories.AvailabilityRepository, swapRepo repositories.ShiftSwapRepository, shiftRepo repositories.ShiftRepository, empRepo repositories.EmployeeRepository) *ShiftRequestService {
+	return &ShiftRequestService{availabilityRepo: availabilityRepo, swapRepo: swapRepo, shiftRepo: shiftRepo, empRepo: empRepo}
+}
+
+// 自分の勤務希望を取得
+func (s *ShiftRequestService) GetMyAvailabilities(actor Actor, from time.Time, to time.Time) ([]model.Availability, error) {
+	return s.availabilityRepo.GetEmployeeAvailabilities(actor.EmployeeID, from, to)
+}
+
+// 店舗を担当する従業員の勤務希望を取得
+func (s *ShiftRequestService) GetStoreAvailabilities(actor Actor, storeID uint, from time.Time, to time.Time) ([]model.Availability, error) {
+	if err := actor.authorizeStore(model.PermManageShifts, storeID); err != nil {
+		return nil, err
+	}
+	return s.availabilityRepo.GetStoreAvailabilities(storeID, from, to)
+}
+
+// 週の勤務希望を提出（その週に提出済みの勤務希望は置き換える）
+func (s *ShiftRequestService) SubmitAvailabilities(actor Actor, weekStart time.Time, availabilities []model.Availability) ([]model.Availability, error) {
+	if err := validateAvailabilities(weekStart, availabilities); err != nil {
+		return nil, err
+	}
+	for i := range availabilities {
+		availabilities[i].EmployeeID = actor.EmployeeID
+	}
+	if err := s.availabilityRepo.ReplaceAvailabilities(actor.EmployeeID, weekStart, weekStart.AddDate(0, 0, 7), availabilities); err != nil {
+		return nil, err
+	}
+	return availabilities, nil
+}
+
+// 自分が申請した、または交代相手になっているシフト交代の申請を取得
+func (s *ShiftRequestService) GetMyShiftSwapRequests(actor Actor) ([]model.ShiftSwapRequest, error) {
+	return s.swapRepo.GetEmployeeShiftSwapRequests(actor.EmployeeID)
+}
+
+// 店舗のシフト交代の申請を取得
+func (s *ShiftRequestService) GetStoreShiftSwapRequests(actor Actor, storeID uint, status model.SwapStatus) ([]model.ShiftSwapRequest, error) {
+	if err := actor.authorizeStore(model.PermManageShifts, storeID); err != nil {
+		return nil, err
+	}
+	return s.swapRepo.GetStoreShiftSwapRequests(storeID, status)
+}
+
+// シフト交代を申請（自分の公開済みで開始前のシフトのみ）
+func (s *ShiftRequestService) RequestShiftSwap(actor Actor, request *model.ShiftSwapRequest) error {
+	if !request.Type.IsValid() {
+		return fmt.Errorf("シフト交代の種類が不正です: %s", request.Type)
+	}
+	if request.TargetEmployeeID == actor.EmployeeID {
+		return errors.New("自分以外の従業員を交代相手に指定してください")
+	}
+
+	now := time.Now()
+	shift, err := s.findSwapShift(request.ShiftID, now)
+	if err != nil {
+		return err
+	}
+	if shift.EmployeeID != actor.EmployeeID {
+		return ErrForbidden
+	}
+	shiftIDs := []uint{shift.ID}
+
+	if request.Type == model.SwapTypeSwap {
+		if request.CounterShiftID == nil {
+			return errors.New("交換する相手のシフトを指定してください")
+		}
+		counter, err := s.findSwapShift(*request.CounterShiftID, now)
+		if err != nil {
+			return err
+		}
+		if counter.EmployeeID != request.TargetEmployeeID {
+			return errors.New("交換する相手のシフトではありません")
+		}
+		if counter.StoreID != shift.StoreID {
+			return errors.New("同じ店舗のシフトとのみ交換できます")
+		}
+		shiftIDs = append(shiftIDs, counter.ID)
+	} else {
+		request.CounterShiftID = nil
+	}
+
+	for _, shiftID := range shiftIDs {
+		pending, err := s.swapRepo.HasPendingShiftSwapRequest(shiftID)
+		if err != nil {
+			return err
+		}
+		if pending {
+			return errors.New("承認待ちの申請があるシフトです")
+		}
+	}
+	if err := s.checkCompetent(request.TargetEmployeeID, shift.StoreID); err != nil {
+		return err
+	}
+
+	request.RequesterID = actor.EmployeeID
+	request.StoreID = shift.StoreID
+	request.Status = model.SwapStatusPending
+	request.ReviewedByID = nil
+	request.ReviewedAt = nil
+	request.ReviewNote = ""
+	return s.swapRepo.CreateShiftSwapRequest(request)
+}
+
+// シフト交代の申請を取り下げ（申請者のみ）
+func (s *ShiftRequestService) CancelShiftSwap(actor Actor, requestID uint) (*model.ShiftSwapRequest, error) {
+	request, err := s.findShiftSwapRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.RequesterID != actor.EmployeeID {
+		return nil, ErrForbidden
+	}
+	if !request.IsPending() {
+		return nil, errors.New("承認待ちの申請ではありません")
+	}
+
+	request.Status = model.SwapStatusCancelled
+	if err := s.swapRepo.UpdateShiftSwapRequest(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// シフト交代の申請を承認し、シフトの担当者を入れ替える
+func (s *ShiftRequestService) ApproveShiftSwap(actor Actor, requestID uint, note string) (*model.ShiftSwapRequest, error) {
+	request, err := s.findPendingShiftSwap(actor, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 申請後にシフトが変更されている場合があるため承認時にも確認する
+	now := time.Now()
+	shift, err := s.findSwapShift(request.ShiftID, now)
+	if err != nil {
+		return nil, err
+	}
+	if shift.EmployeeID != request.RequesterID {
+		return nil, errors.New("申請後にシフトの担当者が変更されています")
+	}
+	if err := s.checkCompetent(request.TargetEmployeeID, shift.StoreID); err != nil {
+		return nil, err
+	}
+
+	shifts := []*model.Shift{shift}
+	var counterID uint
+	if request.CounterShiftID != nil {
+		counter, err := s.findSwapShift(*request.CounterShiftID, now)
+		if err != nil {
+			return nil, err
+		}
+		if counter.EmployeeID != request.TargetEmployeeID {
+			return nil, errors.New("申請後にシフトの担当者が変更されています")
+		}
+		if err := s.checkOverlap(request.RequesterID, counter, shift.ID); err != nil {
+			return nil, err
+		}
+		counter.EmployeeID = request.RequesterID
+		shifts = append(shifts, counter)
+		counterID = counter.ID
+	}
+	if err := s.checkOverlap(request.TargetEmployeeID, shift, counterID); err != nil {
+		return nil, err
+	}
+	shift.EmployeeID = request.TargetEmployeeID
+
+	review(request, actor, model.SwapStatusApproved, note)
+	if err := s.swapRepo.ApproveShiftSwapRequest(request, shifts); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// シフト交代の申請を却下
+func (s *ShiftRequestService) RejectShiftSwap(actor Actor, requestID uint, note string) (*model.ShiftSwapRequest, error) {
+	request, err := s.findPendingShiftSwap(actor, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	review(request, actor, model.SwapStatusRejected, note)
+	if err := s.swapRepo.UpdateShiftSwapRequest(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// 承認・却下の結果を記録
+func review(request *model.ShiftSwapRequest, actor Actor, status model.SwapStatus, note string) {
+	now := time.Now()
+	reviewerID := actor.EmployeeID
+	request.Status = status
+	request.ReviewedByID = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNote = note
+}
+
+// 申請IDから申請を取得（存在しない場合はエラー）
+func (s *ShiftRequestService) findShiftSwapRequest(requestID uint) (*model.ShiftSwapRequest, error) {
+	request, err := s.swapRepo.FindShiftSwapRequestByID(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, ErrShiftSwapNotFound
+	}
+	return request, nil
+}
+
+// 承認・却下する申請を取得（管理できない店舗の場合・承認待ちでない場合はエラー）
+func (s *ShiftRequestService) findPendingShiftSwap(actor Actor, requestID uint) (*model.ShiftSwapRequest, error) {
+	request, err := s.findShiftSwapRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if err := actor.authorizeStore(model.PermManageShifts, request.StoreID); err != nil {
+		return nil, err
+	}
+	if !request.IsPending() {
+		return nil, errors.New("承認待ちの申請ではありません")
+	}
+	return request, nil
+}
+
+// 交代の対象にできるシフトを取得（公開済みで開始前のシフトのみ）
+func (s *ShiftRequestService) findSwapShift(shiftID uint, now time.Time) (*model.Shift, error) {
+	shift, err := s.shiftRepo.FindShiftByID(shiftID)
+	if err != nil {
+		return nil, err
+	}
+	if shift == nil {
+		return nil, ErrShiftNotFound
+	}
+	if !shift.IsPublished() {
+		return nil, errors.New("公開されていないシフトは交代できません")
+	}
+	if !shift.StartTime.After(now) {
+		return nil, errors.New("開始済みのシフトは交代できません")
+	}
+	return shift, nil
+}
+
+// 交代相手が在籍中で、シフトの店舗を担当しているか確認
+func (s *ShiftRequestService) checkCompetent(employeeID uint, storeID uint) error {
+	employee, err := findActiveEmployee(s.empRepo, employeeID)
+	switch {
+	case errors.Is(err, ErrEmployeeNotFound):
+		return fmt.Errorf("交代相手の%w", err)
+	case errors.Is(err, ErrEmployeeDeactivated):
+		return fmt.Errorf("交代相手は%w", err)
+	case err != nil:
+		return err
+	}
+	if employee.StoreID() != storeID {
+		return errors.New("交代相手はこの店舗の担当ではありません")
+	}
+	return nil
+}
+
+// 引き受けるシフトが従業員の他のシフトと重ならないか確認（excludeID は手放すシフト）
+func (s *ShiftRequestService) checkOverlap(employeeID uint, shift *model.Shift, excludeID uint) error {
+	overlapping, err := s.shiftRepo.HasOverlappingShift(employeeID, shift.StartTime, shift.EndTime, excludeID)
+	if err != nil {
+		return err
+	}
+	if overlapping {
+		return errors.New("交代相手の別のシフトと時間が重なっています")
+	}
+	return nil
+}
+
+// 週の勤務希望の検証（対象日は weekStart から7日間）
+func validateAvailabilities(weekStart time.Time, availabilities []model.Availability) error {
+	from := weekStart.Format("2006-01-02")
+	to := weekStart.AddDate(0, 0, 7).Format("2006-01-02")
+	for _, availability := range availabilities {
+		date := availability.Date.Format("2006-01-02")
+		if date < from || date >= to {
+			return fmt.Errorf("対象の週以外の日付です: %s", date)
+		}
+		if !availability.Status.IsValid() {
+			return fmt.Errorf("勤務希望の区分が不正です: %s", availability.Status)
+		}
+		if (availability.StartTime == "") != (availability.EndTime == "") {
+			return errors.New("時間帯は開始と終了の両方を入力してください")
+		}
+		if availability.StartTime == "" {
+			continue
+		}
+		for _, hhmm := range []string{availability.StartTime, availability.EndTime} {
+			if _, err := time.Parse("15:04", hhmm); err != nil {
+				return fmt.Errorf("時刻はHH:MM形式で入力してください: %s", hhmm)
+			}
+		}
+		if availability.StartTime == availability.EndTime {
+			return errors.New("時間帯の開始と終了が同じです")
+		}
+	}
+	return nil
+}
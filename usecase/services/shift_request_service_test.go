@@ -0,0 +1,113 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	repository "github.com/techyoichiro/jobreco-api/infra/database/repositories"
+)
+
+func TestValidateAvailabilities(t *testing.T) {
+	weekStart := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	day := func(offset int) time.Time { return weekStart.AddDate(0, 0, offset) }
+
+	tests := []struct {
+		name           string
+		availabilities []model.Availability
+		wantErr        bool
+	}{
+		{
+			name: "終日と時間帯の希望",
+			availabilities: []model.Availability{
+				{Date: day(0), Status: model.AvailabilityUnavailable},
+				{Date: day(1), Status: model.AvailabilityPreferred, StartTime: "10:00", EndTime: "15:00"},
+				{Date: day(1), Status: model.AvailabilityAvailable, StartTime: "18:00", EndTime: "02:00"},
+				{Date: day(6), Status: model.AvailabilityAvailable},
+			},
+		},
+		{
+			name:           "提出なし",
+			availabilities: []model.Availability{},
+		},
+		{
+			name:           "対象の週より後の日付",
+			availabilities: []model.Availability{{Date: day(7), Status: model.AvailabilityAvailable}},
+			wantErr:        true,
+		},
+		{
+			name:           "対象の週より前の日付",
+			availabilities: []model.Availability{{Date: day(-1), Status: model.AvailabilityAvailable}},
+			wantErr:        true,
+		},
+		{
+			name:           "区分が不正",
+			availabilities: []model.Availability{{Date: day(0), Status: "maybe"}},
+			wantErr:        true,
+		},
+		{
+			name:           "終了時刻がない",
+			availabilities: []model.Availability{{Date: day(0), Status: model.AvailabilityAvailable, StartTime: "10:00"}},
+			wantErr:        true,
+		},
+		{
+			name:           "時刻の形式が不正",
+			availabilities: []model.Availability{{Date: day(0), Status: model.AvailabilityAvailable, StartTime: "10時", EndTime: "15:00"}},
+			wantErr:        true,
+		},
+		{
+			name:           "開始と終了が同じ",
+			availabilities: []model.Availability{{Date: day(0), Status: model.AvailabilityAvailable, StartTime: "10:00", EndTime: "10:00"}},
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAvailabilities(weekStart, tt.availabilities)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateAvailabilities() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckCompetent(t *testing.T) {
+	db := newTestDB(t)
+	service := NewShiftRequestService(nil, nil, nil, repository.NewEmployeeRepository(db))
+
+	storeID := 1
+	deactivatedAt := time.Now()
+	active := &model.Employee{Name: "山田", LoginID: "yamada", Password: "x", RoleID: int(model.RoleStaff), HourlyPay: 1200, CompetentStoreID: &storeID}
+	deactivated := &model.Employee{Name: "佐藤", LoginID: "sato", Password: "x", RoleID: int(model.RoleStaff), HourlyPay: 1200, CompetentStoreID: &storeID, DeactivatedAt: &deactivatedAt}
+	for _, employee := range []*model.Employee{active, deactivated} {
+		if err := db.Create(employee).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		employeeID uint
+		storeID    uint
+		wantErr    bool
+		wantIs     error // 判定に使うエラー（nil の場合は確認しない）
+	}{
+		{"担当店舗の在籍中の従業員", active.ID, 1, false, nil},
+		{"担当外の店舗", active.ID, 2, true, nil},
+		{"退職済みの従業員", deactivated.ID, 1, true, ErrEmployeeDeactivated},
+		{"存在しない従業員", 999, 1, true, ErrEmployeeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.checkCompetent(tt.employeeID, tt.storeID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkCompetent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("checkCompetent() error = %v, want %v", err, tt.wantIs)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+var ErrShiftNotFound = errors.New("シフトが見つかりません")
+
+// 1回のシフトの最大の長さ
+const maxShiftDuration = 24 * time.Hour
+
+// 前週のシフトのコピーの結果
+type ShiftCopyResult struct {
+	Copied  []model.Shift  `json:"copied"`
+	Skipped []SkippedShift `json:"skipped"` // 検証でエラーになりコピーしなかったシフト
+}
+
+// コピーしなかったシフトと理由
+type SkippedShift struct {
+	ShiftID uint   `json:"shift_id"` // コピー元のシフトID
+	Message string `json:"message"`
+}
+
+type ShiftService struct {
+	repo      repositories.ShiftRepository
+	empRepo   repositories.EmployeeRepository
+	storeRepo repositories.StoreRepository
+}
+
+func NewShiftService(repo repositories.ShiftRepository, empRepo repositories.EmployeeRepository, storeRepo repositories.StoreRepository) *ShiftService {
+	return &ShiftService{repo: repo, empRepo: empRepo, storeRepo: storeRepo}
+}
+
+// 店舗のシフトを取得（下書きを含む）
+func (s *ShiftService) GetStoreShifts(actor Actor, storeID uint, from time.Time, to time.Time) ([]model.Shift, error) {
+	if err := actor.authorizeStore(model.PermManageShifts, storeID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetStoreShifts(storeID, from, to)
+}
+
+// 自分の公開済みのシフトを取得
+func (s *ShiftService) GetMyShifts(actor Actor, from time.Time, to time.Time) ([]model.Shift, error) {
+	return s.repo.GetEmployeeShifts(actor.EmployeeID, from, to, true)
+}
+
+// シフト作成（下書きとして作成する）
+func (s *ShiftService) CreateShift(actor Actor, shift *model.Shift) error {
+	if err := actor.authorizeStore(model.PermManageShifts, shift.StoreID); err != nil {
+		return err
+	}
+	if err := s.validateShift(shift); err != nil {
+		return err
+	}
+	shift.PublishedAt = nil
+	return s.repo.CreateShift(shift)
+}
+
+// シフト更新
+func (s *ShiftService) UpdateShift(actor Actor, shiftID uint, input *model.Shift) (*model.Shift, error) {
+	shift, err := s.findShift(actor, shiftID)
+	if err != nil {
+		return nil, err
+	}
+	// 別の店舗に移す場合は移動先の店舗の権限も必要
+	if err := actor.authorizeStore(model.PermManageShifts, input.StoreID); err != nil {
+		return nil, err
+	}
+
+	// 公開済みのシフトの予定を変更した場合は下書きに戻し、再公開するまで従業員に表示しない
+	if shift.EmployeeID != input.EmployeeID || shift.StoreID != input.StoreID ||
+		!shift.StartTime.Equal(input.StartTime) || !shift.EndTime.Equal(input.EndTime) || shift.Position != input.Position {
+		shift.PublishedAt = nil
+	}
+	shift.EmployeeID = input.EmployeeID
+	shift.StoreID = input.StoreID
+	shift.StartTime = input.StartTime
+	shift.EndTime = input.EndTime
+	shift.Position = input.Position
+	shift.Note = input.Note
+	if err := s.validateShift(shift); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateShift(shift); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+// シフト削除
+func (s *ShiftService) DeleteShift(actor Actor, shiftID uint) error {
+	shift, err := s.findShift(actor, shiftID)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteShift(shift)
+}
+
+// 前週のシフトを指定した週にコピー（下書きとして作成する）
+// 時間が重なる・退職済みの従業員など検証でエラーになるシフトはコピーせず、理由とともに返す
+func (s *ShiftService) CopyFromPreviousWeek(actor Actor, storeID uint, weekStart time.Time) (*ShiftCopyResult, error) {
+	if err := actor.authorizeStore(model.PermManageShifts, storeID); err != nil {
+		return nil, err
+	}
+	store, err := findActiveStore(s.storeRepo, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := storeLocation(store)
+	from := time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, loc)
+	previous, err := s.repo.GetStoreShifts(storeID, from.AddDate(0, 0, -7), from)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ShiftCopyResult{Copied: []model.Shift{}, Skipped: []SkippedShift{}}
+	for _, source := range previous {
+		// 壁時計の時刻を保つため日付単位でずらす
+		shift := model.Shift{
+			EmployeeID: source.EmployeeID,
+			StoreID:    storeID,
+			StartTime:  source.StartTime.In(loc).AddDate(0, 0, 7),
+			EndTime:    source.EndTime.In(loc).AddDate(0, 0, 7),
+			Position:   source.Position,
+			Note:       source.Note,
+		}
+		if err := s.validateShift(&shift); err != nil {
+			result.Skipped = append(result.Skipped, SkippedShift{ShiftID: source.ID, Message: err.Error()})
+			continue
+		}
+		if err := s.repo.CreateShift(&shift); err != nil {
+			return nil, err
+		}
+		result.Copied = append(result.Copied, shift)
+	}
+	return result, nil
+}
+
+// 店舗の指定した期間の下書きのシフトを公開
+func (s *ShiftService) PublishShifts(actor Actor, storeID uint, from time.Time, to time.Time) (int64, error) {
+	if err := actor.authorizeStore(model.PermManageShifts, storeID); err != nil {
+		return 0, err
+	}
+	if !to.After(from) {
+		return 0, errors.New("公開する期間が不正です")
+	}
+	return s.repo.PublishShifts(storeID, from, to, time.Now())
+}
+
+// シフトIDからシフトを取得（存在しない場合・管理できない店舗の場合はエラー）
+func (s *ShiftService) findShift(actor Actor, shiftID uint) (*model.Shift, error) {
+	shift, err := s.repo.FindShiftByID(shiftID)
+	if err != nil {
+		return nil, err
+	}
+	if shift == nil {
+		return nil, ErrShiftNotFound
+	}
+	if err := actor.authorizeStore(model.PermManageShifts, shift.StoreID); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+// 入力値の検証
+func (s *ShiftService) validateShift(shift *model.Shift) error {
+	if !shift.EndTime.After(shift.StartTime) {
+		return errors.New("終了時刻は開始時刻より後にしてください")
+	}
+	if shift.EndTime.Sub(shift.StartTime) > maxShiftDuration {
+		return errors.New("シフトは24時間以内にしてください")
+	}
+
+	employee, err := s.empRepo.FindEmpByEmpID(int(shift.EmployeeID))
+	if err != nil {
+		return err
+	}
+	if employee == nil {
+		return errors.New("従業員が見つかりません")
+	}
+	if !employee.IsActive() {
+		return ErrEmployeeDeactivated
+	}
+	if _, err := findActiveStore(s.storeRepo, shift.StoreID); err != nil {
+		return err
+	}
+
+	overlapping, err := s.repo.HasOverlappingShift(shift.EmployeeID, shift.StartTime, shift.EndTime, shift.ID)
+	if err != nil {
+		return err
+	}
+	if overlapping {
+		return errors.New("同じ従業員の別のシフトと時間が重なっています")
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	repository "github.com/techyoichiro/jobreco-api/infra/database/repositories"
+)
+
+// テスト用のシフトのサービスと店舗・従業員（2人目は退職済み）
+func newTestShiftService(t *testing.T) (*ShiftService, *model.Store, []model.Employee) {
+	t.Helper()
+	db := newTestDB(t)
+	store := &model.Store{Name: "本店", Timezone: "Asia/Tokyo"}
+	if err := repository.NewStoreRepository(db).CreateStore(store); err != nil {
+		t.Fatal(err)
+	}
+	deactivatedAt := time.Now()
+	employees := []model.Employee{
+		{Name: "山田", LoginID: "yamada", Password: "x", RoleID: int(model.RoleStaff)},
+		{Name: "佐藤", LoginID: "sato", Password: "x", RoleID: int(model.RoleStaff), DeactivatedAt: &deactivatedAt},
+	}
+	if err := db.Create(&employees).Error; err != nil {
+		t.Fatal(err)
+	}
+	return NewShiftService(repository.NewShiftRepository(db), repository.NewEmployeeRepository(db), repository.NewStoreRepository(db)), store, employees
+}
+
+func TestShiftServiceOverlap(t *testing.T) {
+	service, store, employees := newTestShiftService(t)
+	owner := Actor{EmployeeID: 100, Role: model.RoleOwner}
+	loc := storeLocation(store)
+	at := func(hour int) time.Time { return time.Date(2024, 4, 1, hour, 0, 0, 0, loc) }
+
+	if err := service.CreateShift(owner, &model.Shift{EmployeeID: employees[0].ID, StoreID: store.ID, StartTime: at(9), EndTime: at(17)}); err != nil {
+		t.Fatalf("CreateShift() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		start   int
+		end     int
+		wantErr bool
+	}{
+		{"時間が重なる", 16, 20, true},
+		{"前のシフトの終了時刻から開始", 17, 20, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.CreateShift(owner, &model.Shift{EmployeeID: employees[0].ID, StoreID: store.ID, StartTime: at(tt.start), EndTime: at(tt.end)})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateShift() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestShiftServiceCopyFromPreviousWeek(t *testing.T) {
+	service, store, employees := newTestShiftService(t)
+	owner := Actor{EmployeeID: 100, Role: model.RoleOwner}
+	loc := storeLocation(store)
+	at := func(day, hour int) time.Time { return time.Date(2024, 4, day, hour, 0, 0, 0, loc) }
+
+	// 前週（4/1〜）のシフト：在籍中の従業員2件と、退職済みの従業員1件
+	previous := []*model.Shift{
+		{EmployeeID: employees[0].ID, StoreID: store.ID, StartTime: at(1, 9), EndTime: at(1, 17)},
+		{EmployeeID: employees[0].ID, StoreID: store.ID, StartTime: at(2, 9), EndTime: at(2, 17)},
+	}
+	for _, shift := range previous {
+		if err := service.CreateShift(owner, shift); err != nil {
+			t.Fatal(err)
+		}
+	}
+	retired := &model.Shift{EmployeeID: employees[1].ID, StoreID: store.ID, StartTime: at(3, 9), EndTime: at(3, 17)}
+	if err := service.repo.CreateShift(retired); err != nil {
+		t.Fatal(err)
+	}
+	// コピー先の週に時間が重なるシフトが登録済み
+	if err := service.CreateShift(owner, &model.Shift{EmployeeID: employees[0].ID, StoreID: store.ID, StartTime: at(9, 12), EndTime: at(9, 20)}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := service.CopyFromPreviousWeek(owner, store.ID, at(8, 0))
+	if err != nil {
+		t.Fatalf("CopyFromPreviousWeek() error = %v", err)
+	}
+	if len(result.Copied) != 1 || !result.Copied[0].StartTime.Equal(at(8, 9)) || result.Copied[0].IsPublished() {
+		t.Errorf("Copied = %+v, want one draft shift from %v", result.Copied, at(8, 9))
+	}
+	skipped := map[uint]bool{}
+	for _, s := range result.Skipped {
+		skipped[s.ShiftID] = true
+	}
+	if len(result.Skipped) != 2 || !skipped[previous[1].ID] || !skipped[retired.ID] {
+		t.Errorf("Skipped = %+v, want shifts %d and %d", result.Skipped, previous[1].ID, retired.ID)
+	}
+}
+
+func TestShiftServicePublish(t *testing.T) {
+	service, store, employees := newTestShiftService(t)
+	owner := Actor{EmployeeID: 100, Role: model.RoleOwner}
+	loc := storeLocation(store)
+	at := func(day, hour int) time.Time { return time.Date(2024, 4, day, hour, 0, 0, 0, loc) }
+
+	shift := &model.Shift{EmployeeID: employees[0].ID, StoreID: store.ID, StartTime: at(1, 9), EndTime: at(1, 17)}
+	if err := service.CreateShift(owner, shift); err != nil {
+		t.Fatal(err)
+	}
+	myShifts := func() int {
+		shifts, err := service.GetMyShifts(Actor{EmployeeID: employees[0].ID, Role: model.RoleStaff}, at(1, 0), at(2, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(shifts)
+	}
+	if got := myShifts(); got != 0 {
+		t.Fatalf("draft shifts visible to employee: %d", got)
+	}
+
+	published, err := service.PublishShifts(owner, store.ID, at(1, 0), at(2, 0))
+	if err != nil || published != 1 {
+		t.Fatalf("PublishShifts() = %d, %v, want 1", published, err)
+	}
+	if got := myShifts(); got != 1 {
+		t.Fatalf("published shifts = %d, want 1", got)
+	}
+
+	tests := []struct {
+		name          string
+		end           int
+		note          string
+		wantPublished bool
+	}{
+		{"メモだけの変更は公開のまま", 17, "早めに来てください", true},
+		{"時間を変更すると下書きに戻す", 18, "早めに来てください", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := service.UpdateShift(owner, shift.ID, &model.Shift{EmployeeID: employees[0].ID, StoreID: store.ID, StartTime: at(1, 9), EndTime: at(1, tt.end), Note: tt.note})
+			if err != nil {
+				t.Fatalf("UpdateShift() error = %v", err)
+			}
+			if updated.IsPublished() != tt.wantPublished {
+				t.Errorf("IsPublished() = %v, want %v", updated.IsPublished(), tt.wantPublished)
+			}
+			if got, want := myShifts(), map[bool]int{true: 1, false: 0}[tt.wantPublished]; got != want {
+				t.Errorf("published shifts = %d, want %d", got, want)
+			}
+		})
+	}
+
+	// 下書きに戻したシフトは再度公開できる
+	if published, err := service.PublishShifts(owner, store.ID, at(1, 0), at(2, 0)); err != nil || published != 1 {
+		t.Errorf("PublishShifts() = %d, %v, want 1", published, err)
+	}
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+var (
+	ErrStoreNotFound = errors.New("店舗が見つかりません")
+	ErrStoreArchived = errors.New("アーカイブ済みの店舗です")
+)
+
+type StoreService struct {
+	repo repositories.StoreRepository
+}
+
+func NewStoreService(repo repositories.StoreRepository) *StoreService {
+	return &StoreService{repo: repo}
+}
+
+// 店舗一覧取得
+func (s *StoreService) GetStores(includeArchived bool) ([]model.Store, error) {
+	return s.repo.GetAllStores(includeArchived)
+}
+
+// 店舗作成
+func (s *StoreService) CreateStore(store *model.Store) error {
+	if err := validateStore(store); err != nil {
+		return err
+	}
+	return s.repo.CreateStore(store)
+}
+
+// 店舗更新
+func (s *StoreService) UpdateStore(storeID uint, input *model.Store) (*model.Store, error) {
+	store, err := findStore(s.repo, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	store.Name = input.Name
+	store.Address = input.Address
+	store.Prefecture = input.Prefecture
+	store.Timezone = input.Timezone
+	store.OpenTime = input.OpenTime
+	store.CloseTime = input.CloseTime
+	store.DayCutoff = input.DayCutoff
+	store.LegalHoliday = input.LegalHoliday
+	store.LateTolerance = input.LateTolerance
+	store.EarlyLeaveTolerance = input.EarlyLeaveTolerance
+	if err := validateStore(store); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateStore(store); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// 店舗アーカイブ（勤怠の参照を残すため削除はしない）
+func (s *StoreService) ArchiveStore(storeID uint) (*model.Store, error) {
+	store, err := findStore(s.repo, storeID)
+	if err != nil {
+		return nil, err
+	}
+	if store.IsArchived() {
+		return store, nil
+	}
+
+	now := time.Now()
+	store.ArchivedAt = &now
+	if err := s.repo.UpdateStore(store); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// 入力値の検証
+func validateStore(store *model.Store) error {
+	store.Name = strings.TrimSpace(store.Name)
+	if store.Name == "" {
+		return errors.New("店舗名を入力してください")
+	}
+
+	store.Prefecture = strings.TrimSpace(store.Prefecture)
+	if store.Prefecture != "" && !model.IsValidPrefecture(store.Prefecture) {
+		return fmt.Errorf("都道府県が不正です: %s", store.Prefecture)
+	}
+
+	if store.Timezone == "" {
+		store.Timezone = "Asia/Tokyo"
+	}
+	if _, err := time.LoadLocation(store.Timezone); err != nil {
+		return fmt.Errorf("タイムゾーンが不正です: %s", store.Timezone)
+	}
+
+	if store.DayCutoff == "" {
+		store.DayCutoff = "05:00"
+	}
+
+	if store.LegalHoliday != nil && (*store.LegalHoliday < int(time.Sunday) || *store.LegalHoliday > int(time.Saturday)) {
+		return fmt.Errorf("法定休日の曜日は0（日曜日）〜6（土曜日）で入力してください: %d", *store.LegalHoliday)
+	}
+	for _, tolerance := range []*int{store.LateTolerance, store.EarlyLeaveTolerance} {
+		if tolerance != nil && (*tolerance < 0 || *tolerance > maxReconcileTolerance) {
+			return fmt.Errorf("遅刻・早退の許容範囲は0〜%d分で入力してください: %d", maxReconcileTolerance, *tolerance)
+		}
+	}
+
+	for _, hhmm := range []string{store.OpenTime, store.CloseTime, store.DayCutoff} {
+		if hhmm == "" {
+			continue
+		}
+		if _, err := time.Parse("15:04", hhmm); err != nil {
+			return fmt.Errorf("時刻はHH:MM形式で入力してください: %s", hhmm)
+		}
+	}
+	return nil
+}
+
+// 店舗IDから店舗を取得（存在しない場合はエラー）
+func findStore(repo repositories.StoreRepository, storeID uint) (*model.Store, error) {
+	store, err := repo.FindStoreByID(storeID)
+	if err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, ErrStoreNotFound
+	}
+	return store, nil
+}
+
+// 打刻や担当店舗の指定に使える店舗か確認
+func findActiveStore(repo repositories.StoreRepository, storeID uint) (*model.Store, error) {
+	store, err := findStore(repo, storeID)
+	if err != nil {
+		return nil, err
+	}
+	if store.IsArchived() {
+		return nil, ErrStoreArchived
+	}
+	return store, nil
+}
+
+// 店舗のタイムゾーン（読み込めない場合は日本時間）
+func storeLocation(store *model.Store) *time.Location {
+	if loc, err := time.LoadLocation(store.Timezone); err == nil {
+		return loc
+	}
+	return time.FixedZone("Asia/Tokyo", 9*60*60)
+}
+
+// 店舗の営業日の切り替え時刻を基準に、打刻時刻が属する勤務日を返す
+func businessDate(store *model.Store, t time.Time) time.Time {
+	loc := storeLocation(store)
+	local := t.In(loc)
+
+	cutoff, err := time.Parse("15:04", store.DayCutoff)
+	if err != nil {
+		cutoff = time.Date(0, 1, 1, 5, 0, 0, 0, time.UTC)
+	}
+	shifted := local.Add(-time.Duration(cutoff.Hour())*time.Hour - time.Duration(cutoff.Minute())*time.Minute)
+	return time.Date(shifted.Year(), shifted.Month(), shifted.Day(), 0, 0, 0, 0, loc)
+}
+
+// 店舗IDと店舗の対応表を取得
+func storesByID(repo repositories.StoreRepository) (map[uint]*model.Store, error) {
+	stores, err := repo.GetAllStores(true)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*model.Store, len(stores))
+	for i := range stores {
+		byID[stores[i].ID] = &stores[i]
+	}
+	return byID, nil
+}
+
+// 店舗IDとタイムゾーンの対応表を取得
+func storeLocations(repo repositories.StoreRepository) (map[uint]*time.Location, error) {
+	stores, err := repo.GetAllStores(true)
+	if err != nil {
+		return nil, err
+	}
+	locations := make(map[uint]*time.Location, len(stores))
+	for i := range stores {
+		locations[stores[i].ID] = storeLocation(&stores[i])
+	}
+	return locations, nil
+}
+
+// 店舗IDと店舗名の対応表を取得
+func storeNames(repo repositories.StoreRepository) (map[uint]string, error) {
+	stores, err := repo.GetAllStores(true)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint]string, len(stores))
+	for _, store := range stores {
+		names[store.ID] = store.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+)
+
+func TestBusinessDate(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	store := &model.Store{Timezone: "Asia/Tokyo", DayCutoff: "05:00"}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{"日中の打刻はその日", time.Date(2024, 4, 1, 18, 0, 0, 0, jst), "2024-04-01"},
+		{"切り替え前の深夜は前日", time.Date(2024, 4, 2, 2, 30, 0, 0, jst), "2024-04-01"},
+		{"切り替え時刻ちょうどは当日", time.Date(2024, 4, 2, 5, 0, 0, 0, jst), "2024-04-02"},
+		{"UTCで渡しても店舗の時刻で判定", time.Date(2024, 4, 1, 16, 0, 0, 0, time.UTC), "2024-04-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := businessDate(store, tt.at).Format("2006-01-02"); got != tt.want {
+				t.Errorf("businessDate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// CSVの文字コード
+type CSVEncoding string
+
+const (
+	CSVEncodingUTF8     CSVEncoding = "utf8" // UTF-8（BOM付き）
+	CSVEncodingShiftJIS CSVEncoding = "sjis" // Shift_JIS
+)
+
+// 文字コードの指定を解釈する（指定がなければBOM付きUTF-8）
+func ParseCSVEncoding(value string) (CSVEncoding, error) {
+	switch CSVEncoding(strings.ToLower(value)) {
+	case "", CSVEncodingUTF8:
+		return CSVEncodingUTF8, nil
+	case CSVEncodingShiftJIS:
+		return CSVEncodingShiftJIS, nil
+	default:
+		return "", fmt.Errorf("文字コードが不正です: %s", value)
+	}
+}
+
+// 従業員と月次の勤怠サマリ
+type EmployeeSummary struct {
+	Employee model.Employee
+	Summary  *model.MonthlySummary
+}
+
+// 従業員の月次の勤怠情報を従業員と合わせて取得
+func (s *SummaryService) GetEmployeeAttendance(actor Actor, employeeID uint, year int, month int) (*EmployeeSummary, error) {
+	employee, err := s.empRepo.FindEmpByEmpID(int(employeeID))
+	if err != nil {
+		return nil, err
+	}
+	if err := actor.authorize(model.PermViewSummary, employee); err != nil {
+		return nil, err
+	}
+	summary, err := s.monthlySummary(employee, year, month)
+	if err != nil {
+		return nil, err
+	}
+	return &EmployeeSummary{Employee: *employee, Summary: summary}, nil
+}
+
+// 店舗と従業員ごとの月次の勤怠サマリ
+type StoreSummary struct {
+	Store     model.Store
+	Employees []EmployeeSummary
+}
+
+// 店舗の従業員全員の月次の勤怠情報を取得
+func (s *SummaryService) GetStoreAttendance(actor Actor, storeID uint, year int, month int) (*StoreSummary, error) {
+	if err := actor.authorizeStore(model.PermViewSummary, storeID); err != nil {
+		return nil, err
+	}
+	store, err := findStore(s.storeRepo, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	employees, err := s.empRepo.GetEmployeesByStore(storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]EmployeeSummary, 0, len(employees))
+	for i := range employees {
+		summary, err := s.monthlySummary(&employees[i], year, month)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, EmployeeSummary{Employee: employees[i], Summary: summary})
+	}
+	return &StoreSummary{Store: *store, Employees: summaries}, nil
+}
+
+// 勤怠サマリの見出し
+var summaryHeader = []string{
+	"従業員ID", "氏名", "勤務日", "勤務区間", "休憩", "休憩時間", "実労働時間",
+	"深夜時間", "1日8時間超", "週40時間超", "時間外合計", "法定休日", "時給", "備考",
+}
+
+// 従業員ごとの勤怠の行と合計の行を作成
+func summaryRows(summary EmployeeSummary) [][]string {
+	id := strconv.FormatUint(uint64(summary.Employee.ID), 10)
+	name := summary.Employee.Name
+
+	rows := make([][]string, 0, len(summary.Summary.Attendances)+4)
+	for _, attendance := range summary.Summary.Attendances {
+		holiday := ""
+		if attendance.Holiday {
+			holiday = "○"
+		}
+		rows = append(rows, []string{
+			id, name, attendance.WorkDate,
+			segmentsText(attendance.Segments),
+			breaksText(attendance.Breaks),
+			attendance.TotalBreakTime,
+			attendance.TotalWorkTime,
+			hoursText(attendance.LateNight),
+			hoursText(attendance.DailyOvertime),
+			hoursText(attendance.WeeklyOvertime),
+			hoursText(attendance.Overtime),
+			holiday,
+			strconv.Itoa(attendance.HourlyPay),
+			attendance.Remarks,
+		})
+	}
+
+	// 合計は実労働時間・時間外の列に、時間外の内訳と法定休日の労働時間は別の行に出力する
+	total := func(label string, column int, hours float64) []string {
+		row := make([]string, len(summaryHeader))
+		row[0], row[1], row[2] = id, name, label
+		row[column] = hoursText(hours)
+		return row
+	}
+	sum := summary.Summary
+	totalRow := total("合計", 6, sum.TotalWorkTime)
+	totalRow[7] = hoursText(sum.LateNight)
+	totalRow[8] = hoursText(sum.DailyOvertime)
+	totalRow[9] = hoursText(sum.WeeklyOvertime)
+	totalRow[10] = hoursText(sum.Overtime)
+	rows = append(rows,
+		totalRow,
+		total("時間外（月60時間以内）", 10, sum.OvertimeUpTo60),
+		total("時間外（月60時間超）", 10, sum.OvertimeOver60),
+		total("法定休日労働", 6, sum.HolidayWorkTime),
+	)
+	return rows
+}
+
+// 勤務区間を「09:00-13:00 店舗名」の形式で連結
+func segmentsText(segments []model.WorkSegmentResponse) string {
+	texts := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		texts = append(texts, segment.StartTime+"-"+segment.EndTime+" "+segment.StoreName)
+	}
+	return strings.Join(texts, " / ")
+}
+
+// 休憩を「食事 12:00-13:00」の形式で連結
+func breaksText(breaks []model.AttendanceBreakResponse) string {
+	texts := make([]string, 0, len(breaks))
+	for _, breakRecord := range breaks {
+		texts = append(texts, breakRecord.BreakType.Label()+" "+breakRecord.StartTime+"-"+breakRecord.EndTime)
+	}
+	return strings.Join(texts, " / ")
+}
+
+// 時間数を小数2桁で出力
+func hoursText(hours float64) string {
+	return strconv.FormatFloat(hours, 'f', 2, 64)
+}
+
+// 勤怠サマリをCSVで出力（Excelで開けるよう改行はCRLF）
+func WriteSummaryCSV(w io.Writer, summaries []EmployeeSummary, enc CSVEncoding) error {
+	if enc == CSVEncodingShiftJIS {
+		// Shift_JISで表せない文字は置き換えて出力する
+		encoder := transform.NewWriter(w, encoding.ReplaceUnsupported(japanese.ShiftJIS.NewEncoder()))
+		if err := writeSummaryCSV(encoder, summaries); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+	return writeSummaryCSV(w, summaries)
+}
+
+// 見出しと従業員ごとの行を出力
+func writeSummaryCSV(w io.Writer, summaries []EmployeeSummary) error {
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = true
+	if err := writer.Write(summaryHeader); err != nil {
+		return err
+	}
+	for _, summary := range summaries {
+		for _, row := range summaryRows(summary) {
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestWriteSummaryCSV(t *testing.T) {
+	summaries := []EmployeeSummary{{
+		Employee: model.Employee{Name: "山田太郎"},
+		Summary: &model.MonthlySummary{
+			Attendances: []model.AttendanceResponse{{
+				WorkDate:       "4/1(月)",
+				Segments:       []model.WorkSegmentResponse{{StoreName: "本店", StartTime: "09:00", EndTime: "18:00"}},
+				Breaks:         []model.AttendanceBreakResponse{{BreakType: model.BreakTypeMeal, StartTime: "12:00", EndTime: "13:00"}},
+				TotalBreakTime: "1.00",
+				TotalWorkTime:  "8.00",
+				HourlyPay:      1200,
+				Remarks:        "09:00-18:00 本店",
+			}},
+			TotalWorkTime:  8,
+			OvertimeOver60: 1.5,
+		},
+	}}
+	summaries[0].Employee.ID = 3
+
+	tests := []struct {
+		name    string
+		enc     CSVEncoding
+		decode  func([]byte) (string, error)
+		wantBOM bool
+	}{
+		{"BOM付きUTF-8", CSVEncodingUTF8, func(b []byte) (string, error) { return string(b), nil }, true},
+		{"Shift_JIS", CSVEncodingShiftJIS, func(b []byte) (string, error) {
+			decoded, err := japanese.ShiftJIS.NewDecoder().Bytes(b)
+			return string(decoded), err
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteSummaryCSV(&buf, summaries, tt.enc); err != nil {
+				t.Fatalf("WriteSummaryCSV() error = %v", err)
+			}
+			text, err := tt.decode(buf.Bytes())
+			if err != nil {
+				t.Fatalf("decode error = %v", err)
+			}
+			if got := strings.HasPrefix(text, "\ufeff"); got != tt.wantBOM {
+				t.Errorf("BOM = %v, want %v", got, tt.wantBOM)
+			}
+			if !strings.Contains(text, "\r\n") {
+				t.Errorf("改行がCRLFではありません")
+			}
+
+			rows, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(text, "\ufeff"))).ReadAll()
+			if err != nil {
+				t.Fatalf("csv error = %v", err)
+			}
+			// 見出し・勤怠1行・合計4行
+			if len(rows) != 6 {
+				t.Fatalf("rows = %d, want 6", len(rows))
+			}
+			day := rows[1]
+			if day[0] != "3" || day[1] != "山田太郎" || day[3] != "09:00-18:00 本店" || day[4] != "食事 12:00-13:00" || day[12] != "1200" {
+				t.Errorf("勤怠の行 = %v", day)
+			}
+			if rows[2][2] != "合計" || rows[2][6] != "8.00" {
+				t.Errorf("合計の行 = %v", rows[2])
+			}
+			if rows[4][2] != "時間外（月60時間超）" || rows[4][10] != "1.50" {
+				t.Errorf("時間外の内訳の行 = %v", rows[4])
+			}
+		})
+	}
+}
+
+func TestParseCSVEncoding(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    CSVEncoding
+		wantErr bool
+	}{
+		{"", CSVEncodingUTF8, false},
+		{"utf8", CSVEncodingUTF8, false},
+		{"SJIS", CSVEncodingShiftJIS, false},
+		{"euc-jp", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseCSVEncoding(tt.value)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseCSVEncoding(%q) = %v, %v", tt.value, got, err)
+			}
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	model "github.com/techyoichiro/jobreco-api/domain/models"
@@ -10,295 +13,431 @@ import (
 )
 
 type SummaryService struct {
-	repo repositories.SummaryRepository
+	repo      repositories.SummaryRepository
+	empRepo   repositories.EmployeeRepository
+	storeRepo repositories.StoreRepository
+	wageRepo  repositories.WageRepository
 }
 
-func NewSummaryService(repo repositories.SummaryRepository) *SummaryService {
-	return &SummaryService{repo: repo}
+func NewSummaryService(repo repositories.SummaryRepository, empRepo repositories.EmployeeRepository, storeRepo repositories.StoreRepository, wageRepo repositories.WageRepository) *SummaryService {
+	return &SummaryService{repo: repo, empRepo: empRepo, storeRepo: storeRepo, wageRepo: wageRepo}
 }
 
-// GetAllEmployee 全従業員の名前を取得するサービス
-func (s *SummaryService) GetAllEmployee() ([]model.Employee, error) {
-	return s.repo.GetAllEmployee()
+// 操作者が対象従業員に対して指定した操作を行えるか確認
+func (s *SummaryService) authorizeEmployee(actor Actor, p model.Permission, employeeID uint) error {
+	employee, err := s.empRepo.FindEmpByEmpID(int(employeeID))
+	if err != nil {
+		return err
+	}
+	return actor.authorize(p, employee)
+}
+
+// GetAllEmployee 操作者が閲覧できる従業員の名前を取得するサービス
+func (s *SummaryService) GetAllEmployee(actor Actor) ([]model.Employee, error) {
+	employees, err := s.repo.GetAllEmployee()
+	if err != nil {
+		return nil, err
+	}
+
+	visible := []model.Employee{}
+	for i := range employees {
+		if actor.CanAccess(model.PermListEmployees, &employees[i]) {
+			visible = append(visible, employees[i])
+		}
+	}
+	return visible, nil
+}
+
+// 指定した従業員IDの月次の勤怠情報を取得するサービス
+func (s *SummaryService) GetAttendance(actor Actor, employeeID uint, year int, month int) (*model.MonthlySummary, error) {
+	employee, err := s.empRepo.FindEmpByEmpID(int(employeeID))
+	if err != nil {
+		return nil, err
+	}
+	if err := actor.authorize(model.PermViewSummary, employee); err != nil {
+		return nil, err
+	}
+	return s.monthlySummary(employee, year, month)
 }
 
-// 指定した従業員IDの勤怠情報を取得するサービス
-func (s *SummaryService) GetAttendance(employeeID uint, year int, month int) ([]model.AttendanceResponse, error) {
-	attendances, err := s.repo.GetAttendance(employeeID, year, month)
+// 従業員の月次の勤怠情報を集計
+func (s *SummaryService) monthlySummary(employee *model.Employee, year int, month int) (*model.MonthlySummary, error) {
+	work, err := loadMonthlyWork(s.repo, s.storeRepo, employee, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	// 勤務日ごとにその日に適用されていた時給を使う
+	wages, err := loadWageTable(s.wageRepo, employee)
 	if err != nil {
 		return nil, err
 	}
 
-	hourlyPay, err := s.repo.GetHourlyPay(employeeID)
+	names, err := storeNames(s.storeRepo)
 	if err != nil {
 		return nil, err
 	}
 
-	response := []model.AttendanceResponse{}
-	for _, attendance := range attendances {
+	summary := &model.MonthlySummary{Attendances: []model.AttendanceResponse{}}
+	for _, day := range work.Days {
+		attendance := day.Attendance
 
 		// 勤務取得
 		workDate := formatDate(&attendance.WorkDate)
 
-		// 勤務時刻取得
-		startTime1 := formatTime(attendance.StartTime1)
-		endTime1 := formatTime(attendance.EndTime1)
-		startTime2 := formatTime(attendance.StartTime2)
-		endTime2 := formatTime(attendance.EndTime2)
-
-		// 休憩記録取得
-		var breakStart string
-		var breakEnd string
-		if attendance.BreakStart != nil {
-			breakStart = formatTime(attendance.BreakStart)
-		}
-		if attendance.BreakEnd != nil {
-			breakEnd = formatTime(attendance.BreakEnd)
-		}
+		summary.Attendances = append(summary.Attendances, model.AttendanceResponse{
+			ID:             attendance.ID,
+			WorkDate:       workDate,
+			Segments:       segmentResponses(attendance.Segments, names),
+			Breaks:         breakResponses(attendance.Breaks),
+			TotalBreakTime: formatHours(calculateBreakDuration(attendance)),
+			TotalWorkTime:  calculateWorkTime(attendance),
+			LateNight:      day.LateNight.Hours(),
+			DailyOvertime:  day.Overtime.Daily.Hours(),
+			WeeklyOvertime: day.Overtime.Weekly.Hours(),
+			Overtime:       day.Overtime.Total().Hours(),
+			Holiday:        day.Holiday,
+			Remarks:        generateRemarks(attendance, names),
+			HourlyPay:      wages.rateOn(attendance.WorkDate),
+		})
+	}
 
-		response = append(response, model.AttendanceResponse{
-			ID:            attendance.ID,
-			WorkDate:      workDate,
-			StartTime1:    startTime1,
-			EndTime1:      endTime1,
-			StartTime2:    startTime2,
-			EndTime2:      endTime2,
-			TotalWorkTime: calculateWorkTime(attendance),
-			BreakStart:    breakStart,
-			BreakEnd:      breakEnd,
-			Overtime:      calculateOvertime(attendance),
-			Remarks:       generateRemarks(attendance),
-			HourlyPay:     hourlyPay,
+	upTo60, over60 := splitMonthlyOvertime(work.Overtime())
+	summary.TotalWorkTime = work.Worked.Hours()
+	summary.LateNight = work.LateNight.Hours()
+	summary.DailyOvertime = work.DailyOvertime.Hours()
+	summary.WeeklyOvertime = work.WeeklyOvertime.Hours()
+	summary.Overtime = work.Overtime().Hours()
+	summary.OvertimeUpTo60 = upTo60.Hours()
+	summary.OvertimeOver60 = over60.Hours()
+	summary.HolidayWorkTime = work.HolidayWork.Hours()
+
+	return summary, nil
+}
+
+// 勤務区間をレスポンス用に変換
+func segmentResponses(segments []model.WorkSegment, names map[uint]string) []model.WorkSegmentResponse {
+	responses := make([]model.WorkSegmentResponse, 0, len(segments))
+	for _, segment := range segments {
+		responses = append(responses, model.WorkSegmentResponse{
+			ID:        segment.ID,
+			StoreID:   segment.StoreID,
+			StoreName: names[segment.StoreID],
+			StartTime: formatTime(&segment.StartTime),
+			EndTime:   formatTimeIfNotNil(segment.EndTime),
 		})
 	}
+	return responses
+}
 
-	return response, nil
+// 休憩をレスポンス用に変換
+func breakResponses(breaks []model.AttendanceBreak) []model.AttendanceBreakResponse {
+	responses := make([]model.AttendanceBreakResponse, 0, len(breaks))
+	for _, breakRecord := range breaks {
+		responses = append(responses, model.AttendanceBreakResponse{
+			ID:        breakRecord.ID,
+			BreakType: breakRecord.BreakType,
+			StartTime: formatTime(&breakRecord.StartTime),
+			EndTime:   formatTimeIfNotNil(breakRecord.EndTime),
+		})
+	}
+	return responses
 }
 
+// 時間を5分単位で切り下げるための定数
+const roundTo = 5 * time.Minute
+
 // 勤務時間を計算
 func calculateWorkTime(attendance model.Attendance) string {
-	// 勤務開始時間と終了時間を取得
-	startTime := attendance.StartTime1
-	var endTime *time.Time
-	if attendance.EndTime2 != nil {
-		endTime = attendance.EndTime2
-	} else {
-		endTime = attendance.EndTime1
-	}
-
-	// 勤務開始時間または終了時間がnilの場合は0時間を返却
-	if startTime == nil || endTime == nil {
+	// 終了済みの勤務区間がない場合は0時間を返却
+	if !hasClosedSegment(attendance) {
 		return "0.0"
 	}
 
-	// 時間を5分単位で切り下げるための定数
-	const roundTo = 5 * time.Minute
+	// 実勤務時間を時間単位で返却する
+	return formatHours(calculateWorkDuration(attendance))
+}
 
-	// 勤務時間を5分単位で丸める
-	startTimeRounded := startTime.Truncate(roundTo)
-	endTimeRounded := endTime.Truncate(roundTo)
+// 終了済みの勤務区間があるか
+func hasClosedSegment(attendance model.Attendance) bool {
+	for _, segment := range attendance.Segments {
+		if segment.EndTime != nil {
+			return true
+		}
+	}
+	return false
+}
 
-	// 勤務時間を計算
-	workDuration := endTimeRounded.Sub(startTimeRounded)
+// 実勤務時間（勤務時間 - 休憩時間）を計算
+func calculateWorkDuration(attendance model.Attendance) time.Duration {
+	// 終了済みの勤務区間の勤務時間を5分単位で丸めて合計
+	var workDuration time.Duration
+	for _, segment := range attendance.Segments {
+		// 勤務中の区間は集計しない
+		if segment.EndTime == nil {
+			continue
+		}
+		workDuration += segment.EndTime.Truncate(roundTo).Sub(segment.StartTime.Truncate(roundTo))
+	}
+	return workDuration - calculateBreakDuration(attendance)
+}
 
-	// 休憩時間を計算
+// 全休憩の合計時間を計算（終了済みの勤務区間と重なる部分のみ）
+func calculateBreakDuration(attendance model.Attendance) time.Duration {
 	var breakDuration time.Duration
-	if attendance.BreakStart != nil && attendance.BreakEnd != nil {
-		breakStartRounded := attendance.BreakStart.Truncate(roundTo)
-		breakEndRounded := attendance.BreakEnd.Truncate(roundTo)
+	for _, breakRecord := range attendance.Breaks {
+		if breakRecord.EndTime == nil {
+			continue
+		}
 
 		// 休憩時間を5分単位で丸めた後に計算
-		breakDuration = breakEndRounded.Sub(breakStartRounded)
+		breakStartRounded := breakRecord.StartTime.Truncate(roundTo)
+		breakEndRounded := breakRecord.EndTime.Truncate(roundTo)
+		for _, segment := range attendance.Segments {
+			if segment.EndTime == nil {
+				continue
+			}
+			breakDuration += overlap(segment.StartTime.Truncate(roundTo), segment.EndTime.Truncate(roundTo), breakStartRounded, breakEndRounded)
+		}
 	}
-
-	// 実勤務時間（勤務時間 - 休憩時間）を計算
-	actualWorkDuration := workDuration - breakDuration
-
-	// 実勤務時間を時間単位で返却する
-	hours := actualWorkDuration.Seconds() / 3600
-	formattedHours := fmt.Sprintf("%.2f", hours)
-	return formattedHours
+	return breakDuration
 }
 
-// 22時以降の勤務時間を計算
-func calculateOvertime(attendance model.Attendance) float64 {
-	const overtimeThresholdHour = 22
-	const roundTo = 5 * time.Minute // 5分刻み
+// 時間を小数点以下2桁の時間単位の文字列にする
+func formatHours(d time.Duration) string {
+	return fmt.Sprintf("%.2f", d.Hours())
+}
 
-	// 勤務終了時間を取得 (EndTime2が存在する場合はそれを使用)
-	var endTime *time.Time
-	if attendance.EndTime2 != nil {
-		endTime = attendance.EndTime2
-	} else {
-		endTime = attendance.EndTime1
+// 2つの期間が重なる時間を返す
+func overlap(start1, end1, start2, end2 time.Time) time.Duration {
+	start := start1
+	if start2.After(start) {
+		start = start2
 	}
-
-	// 終了時間がnilの場合、0を返却
-	if endTime == nil {
-		return 0.0
+	end := end1
+	if end2.Before(end) {
+		end = end2
 	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
 
-	// 勤務終了時間が22時を超えている場合の処理
-	overtime := 0.0
-	if endTime.Hour() >= overtimeThresholdHour {
-		// 22時以降の時間を計算
-		overtimeStart := time.Date(endTime.Year(), endTime.Month(), endTime.Day(), overtimeThresholdHour, 0, 0, 0, endTime.Location())
-		if endTime.After(overtimeStart) {
-			overtimeDuration := endTime.Sub(overtimeStart)
-
-			// 5分刻みに切り下げ
-			roundedOvertime := overtimeDuration.Truncate(roundTo)
-
-			// 時間単位で返却
-			overtime = roundedOvertime.Seconds() / 3600
-		}
+// 勤怠の店舗のタイムゾーン（最初の勤務区間の店舗、不明な場合は日本時間）
+func attendanceLocation(attendance model.Attendance, locations map[uint]*time.Location) *time.Location {
+	if len(attendance.Segments) > 0 {
+		return locationOf(locations, attendance.Segments[0].StoreID)
 	}
+	return time.FixedZone("Asia/Tokyo", 9*60*60)
+}
 
-	return overtime
+// 店舗のタイムゾーン（不明な場合は日本時間）
+func locationOf(locations map[uint]*time.Location, storeID uint) *time.Location {
+	if loc, ok := locations[storeID]; ok {
+		return loc
+	}
+	return time.FixedZone("Asia/Tokyo", 9*60*60)
 }
 
 // サマリ１件を取得
-func (s *SummaryService) GetAttendanceByID(attendanceID uint) (*model.AttendanceResponse, error) {
+func (s *SummaryService) GetAttendanceByID(actor Actor, attendanceID uint) (*model.AttendanceResponse, error) {
 	attendance, err := s.repo.GetAttendanceByID(attendanceID)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.authorizeEmployee(actor, model.PermViewSummary, attendance.EmployeeID); err != nil {
+		return nil, err
+	}
 
-	remarks := generateRemarks(*attendance)
+	names, err := storeNames(s.storeRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	remarks := generateRemarks(*attendance, names)
 
 	response := model.AttendanceResponse{
-		ID:         attendance.ID,
-		WorkDate:   formatDate(&attendance.WorkDate),
-		StartTime1: formatTime(attendance.StartTime1),
-		EndTime1:   formatTimeIfNotNil(attendance.EndTime1),
-		StartTime2: formatTime(attendance.StartTime2),
-		EndTime2:   formatTime(attendance.EndTime2),
-		BreakStart: formatTime(attendance.BreakStart),
-		BreakEnd:   formatTime(attendance.BreakEnd),
-		StoreID1:   attendance.StoreID1,
-		StoreID2:   attendance.StoreID2,
-		Remarks:    remarks,
+		ID:       attendance.ID,
+		WorkDate: formatDate(&attendance.WorkDate),
+		Segments: segmentResponses(attendance.Segments, names),
+		Breaks:   breakResponses(attendance.Breaks),
+		Remarks:  remarks,
 	}
 
 	return &response, nil
 }
 
-// IDで指定された勤怠情報を更新する
-func (s *SummaryService) UpdateAttendance(attendanceResponse *model.AttendanceResponse) error {
-	loc, err := time.LoadLocation("Asia/Tokyo")
-	if err != nil {
-		return fmt.Errorf("failed to load location: %w", err)
-	}
-
-	// 受け取ったデータのwork_dateを取得
-	workDate, err := s.repo.GetWorkDateByID(attendanceResponse.ID)
-	if err != nil {
-		return fmt.Errorf("failed to load workDate: %w", err)
+// 勤怠の編集内容（時:分の文字列）を検証し、更新用の勤怠に変換する
+func buildAttendanceEdit(storeRepo repositories.StoreRepository, current *model.Attendance, attendanceResponse *model.AttendanceResponse) (*model.Attendance, error) {
+	if len(attendanceResponse.Segments) == 0 {
+		return nil, errors.New("勤務区間を1件以上入力してください")
 	}
 
 	// workDate を time.Time 型から受け取っている前提で、文字列に変換
-	workDateStr := workDate.Format("2006-01-02")
-
-	// 受け取ったデータをパースして、DBに合わせた形式に変換
-	startTime1Str := workDateStr + " " + attendanceResponse.StartTime1
-	startTime1, err := time.ParseInLocation("2006-01-02 15:04", startTime1Str, loc)
-	if err != nil {
-		return fmt.Errorf("invalid start time 1: %w", err)
-	}
-
-	var endTime1 *time.Time
-	if attendanceResponse.EndTime1 != "" {
-		endTime1Str := workDateStr + " " + attendanceResponse.EndTime1
-		t, err := time.ParseInLocation("2006-01-02 15:04", endTime1Str, loc)
+	workDateStr := current.WorkDate.Format("2006-01-02")
+
+	// 受け取った勤務区間をパースして、DBに合わせた形式に変換
+	// 直前の時刻より前の時刻は日付をまたいだものとして扱う
+	// 時刻は勤怠の表示（attendanceLocation）と同じく最初の勤務区間の店舗のタイムゾーンで解釈する
+	segments := make([]model.WorkSegment, 0, len(attendanceResponse.Segments))
+	var previous *time.Time
+	var loc *time.Location
+	for i, req := range attendanceResponse.Segments {
+		store, err := findStore(storeRepo, req.StoreID)
 		if err != nil {
-			return fmt.Errorf("invalid end time 1: %w", err)
+			return nil, fmt.Errorf("invalid store of segment %d: %w", i+1, err)
+		}
+		if loc == nil {
+			loc = storeLocation(store)
 		}
-		endTime1 = &t
-	}
 
-	// 他の時間も同様に処理
-	var startTime2 *time.Time
-	if attendanceResponse.StartTime2 != "" {
-		startTime2Str := workDateStr + " " + attendanceResponse.StartTime2
-		t, err := time.ParseInLocation("2006-01-02 15:04", startTime2Str, loc)
+		startTime, err := parseClock(workDateStr, req.StartTime, loc, previous)
 		if err != nil {
-			return fmt.Errorf("invalid start time 2: %w", err)
+			return nil, fmt.Errorf("invalid start time of segment %d: %w", i+1, err)
 		}
-		startTime2 = &t
+		previous = startTime
+
+		var endTime *time.Time
+		if req.EndTime != "" {
+			endTime, err = parseClock(workDateStr, req.EndTime, loc, previous)
+			if err != nil {
+				return nil, fmt.Errorf("invalid end time of segment %d: %w", i+1, err)
+			}
+			previous = endTime
+		} else if i != len(attendanceResponse.Segments)-1 {
+			return nil, fmt.Errorf("勤務区間%dの終了時間を入力してください", i+1)
+		}
+
+		segments = append(segments, model.WorkSegment{
+			AttendanceID: current.ID,
+			StoreID:      req.StoreID,
+			StartTime:    *startTime,
+			EndTime:      endTime,
+		})
 	}
 
-	var endTime2 *time.Time
-	if attendanceResponse.EndTime2 != "" {
-		endTime2Str := workDateStr + " " + attendanceResponse.EndTime2
-		t, err := time.ParseInLocation("2006-01-02 15:04", endTime2Str, loc)
-		if err != nil {
-			return fmt.Errorf("invalid end time 2: %w", err)
+	// 休憩は最初の勤務区間の開始時間を基準に日付をまたいだか判定する
+	breaks := make([]model.AttendanceBreak, 0, len(attendanceResponse.Breaks))
+	for i, req := range attendanceResponse.Breaks {
+		if !req.BreakType.IsValid() {
+			return nil, fmt.Errorf("invalid break type of break %d: %s", i+1, req.BreakType)
 		}
-		endTime2 = &t
-	}
 
-	var breakStart *time.Time
-	if attendanceResponse.BreakStart != "" {
-		breakStartStr := workDateStr + " " + attendanceResponse.BreakStart
-		t, err := time.ParseInLocation("2006-01-02 15:04", breakStartStr, loc)
+		breakStart, err := parseClock(workDateStr, req.StartTime, loc, &segments[0].StartTime)
 		if err != nil {
-			return fmt.Errorf("invalid break start: %w", err)
+			return nil, fmt.Errorf("invalid start time of break %d: %w", i+1, err)
 		}
-		breakStart = &t
-	}
 
-	var breakEnd *time.Time
-	if attendanceResponse.BreakEnd != "" {
-		breakEndStr := workDateStr + " " + attendanceResponse.BreakEnd
-		t, err := time.ParseInLocation("2006-01-02 15:04", breakEndStr, loc)
-		if err != nil {
-			return fmt.Errorf("invalid break end: %w", err)
+		var breakEnd *time.Time
+		if req.EndTime != "" {
+			breakEnd, err = parseClock(workDateStr, req.EndTime, loc, breakStart)
+			if err != nil {
+				return nil, fmt.Errorf("invalid end time of break %d: %w", i+1, err)
+			}
 		}
-		breakEnd = &t
+
+		breaks = append(breaks, model.AttendanceBreak{
+			AttendanceID: current.ID,
+			BreakType:    req.BreakType,
+			StartTime:    *breakStart,
+			EndTime:      breakEnd,
+		})
+	}
+
+	if err := validateAttendanceTimes(segments, breaks, maxShiftLength()); err != nil {
+		return nil, err
 	}
 
 	// Attendanceモデルのインスタンスを作成
 	attendance := &model.Attendance{
-		ID:         attendanceResponse.ID,
-		StartTime1: &startTime1,
-		EndTime1:   endTime1,
-		StartTime2: startTime2,
-		EndTime2:   endTime2,
-		BreakStart: breakStart,
-		BreakEnd:   breakEnd,
-		StoreID1:   attendanceResponse.StoreID1,
-		StoreID2:   attendanceResponse.StoreID2,
-	}
-
-	return s.repo.UpdateAttendance(attendance)
+		ID:       current.ID,
+		Segments: segments,
+		Breaks:   breaks,
+	}
+
+	// 退勤漏れの修正で勤務区間・休憩がすべて終了した場合は退勤済みにする
+	if segments[len(segments)-1].EndTime != nil && attendance.OpenBreak() == nil {
+		attendance.StatusID = model.StatusClockedOut
+	}
+	return attendance, nil
+}
+
+// 勤務区間・休憩の時刻を検証する
+// 直前より前の時刻は翌日として扱うため、入力の誤り（終了が開始より前・区間の重なり）は1回の勤務の最大の長さを超えることで検出する
+func validateAttendanceTimes(segments []model.WorkSegment, breaks []model.AttendanceBreak, maxShift time.Duration) error {
+	hours := int(maxShift.Hours())
+	first := segments[0].StartTime
+	last := segments[len(segments)-1]
+	for i, segment := range segments {
+		if segment.EndTime != nil && segment.EndTime.Sub(segment.StartTime) > maxShift {
+			return fmt.Errorf("勤務区間%dが%d時間を超えています。開始・終了時間を確認してください", i+1, hours)
+		}
+	}
+	end := last.StartTime
+	if last.EndTime != nil {
+		end = *last.EndTime
+	}
+	if end.Sub(first) > maxShift {
+		return fmt.Errorf("勤務が%d時間を超えています。勤務区間の時間が重なっていないか確認してください", hours)
+	}
+
+	// 休憩は勤務の時間内（勤務中の場合は最大の長さまで）で、ほかの休憩と重ならないこと
+	if last.EndTime == nil {
+		end = first.Add(maxShift)
+	}
+	order := make([]int, len(breaks))
+	for i, b := range breaks {
+		order[i] = i
+		if !b.StartTime.Before(end) || (b.EndTime != nil && b.EndTime.After(end)) {
+			return fmt.Errorf("休憩%dが勤務の時間外です", i+1)
+		}
+	}
+	sort.Slice(order, func(a, b int) bool { return breaks[order[a]].StartTime.Before(breaks[order[b]].StartTime) })
+	for k := 1; k < len(order); k++ {
+		previous, current := order[k-1], order[k]
+		if breaks[previous].EndTime == nil || breaks[current].StartTime.Before(*breaks[previous].EndTime) {
+			return fmt.Errorf("休憩%dと休憩%dの時間が重なっています", min(previous, current)+1, max(previous, current)+1)
+		}
+	}
+	return nil
+}
+
+// 勤務日と時:分から時刻を生成する（after より前になる場合は翌日の時刻とする）
+func parseClock(workDate string, hhmm string, loc *time.Location, after *time.Time) (*time.Time, error) {
+	t, err := time.ParseInLocation("2006-01-02 15:04", workDate+" "+hhmm, loc)
+	if err != nil {
+		return nil, err
+	}
+	for after != nil && t.Before(*after) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
 }
 
 // 備考欄生成
-func generateRemarks(attendance model.Attendance) string {
-
-	// 時間をフォーマットし、StoreID を string 型に変換
-	startTime1 := attendance.StartTime1.Format("15:04")
-	endTime1 := "-"
-	if attendance.EndTime1 != nil {
-		endTime1 = attendance.EndTime1.Format("15:04")
-	}
-	storeID1 := strconv.FormatUint(uint64(attendance.StoreID1), 10)
-
-	remark1 := startTime1 + "-" + endTime1 + " " + storeID1
-	// 2店舗で勤務していた場合
-	if attendance.StartTime2 != nil {
-		startTime2 := attendance.StartTime2.Format("15:04")
-		endTime2 := "-"
-		if attendance.EndTime2 != nil {
-			endTime2 = attendance.EndTime2.Format("15:04")
+func generateRemarks(attendance model.Attendance, names map[uint]string) string {
+	remarks := make([]string, 0, len(attendance.Segments))
+	for _, segment := range attendance.Segments {
+		// 時間をフォーマットし、店舗名に変換
+		startTime := segment.StartTime.Format("15:04")
+		endTime := "-"
+		if segment.EndTime != nil {
+			endTime = segment.EndTime.Format("15:04")
 		}
-		storeID2 := strconv.FormatUint(uint64(*attendance.StoreID2), 10)
-		remark2 := startTime2 + "-" + endTime2 + " " + storeID2
-		return formatRemarks(remark1, remark2)
+		remarks = append(remarks, startTime+"-"+endTime+" "+storeLabel(names, segment.StoreID))
 	}
 
 	// 備考欄をカンマで連結
-	return remark1
+	return formatRemarks(remarks)
+}
+
+// 備考欄用の店舗表記（店舗名が取得できない場合はIDを表示）
+func storeLabel(names map[uint]string, storeID uint) string {
+	if name, ok := names[storeID]; ok {
+		return name
+	}
+	return strconv.FormatUint(uint64(storeID), 10)
 }
 
 // 日付フォーマット
@@ -317,12 +456,6 @@ func formatTime(t *time.Time) string {
 	return t.Format("15:04") // 時:分 の形式でフォーマット
 }
 
-// 時刻の文字列を time.Time 型に変換するヘルパー関数
-func parseTime(timeStr string) *time.Time {
-	t, _ := time.Parse("15:04", timeStr)
-	return &t
-}
-
 // nil チェックとデリファレンスを行う関数
 func formatTimeIfNotNil(t *time.Time) string {
 	if t == nil {
@@ -332,6 +465,6 @@ func formatTimeIfNotNil(t *time.Time) string {
 }
 
 // カンマ区切り
-func formatRemarks(segmentRemark1, segmentRemark2 string) string {
-	return fmt.Sprintf("%s, %s", segmentRemark1, segmentRemark2)
+func formatRemarks(segmentRemarks []string) string {
+	return strings.Join(segmentRemarks, ", ")
 }
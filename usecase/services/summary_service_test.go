@@ -0,0 +1,85 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	repository "github.com/techyoichiro/jobreco-api/infra/database/repositories"
+)
+
+func TestBuildAttendanceEdit(t *testing.T) {
+	db := newTestDB(t)
+	storeRepo := repository.NewStoreRepository(db)
+	store := &model.Store{Name: "本店"}
+	if err := storeRepo.CreateStore(store); err != nil {
+		t.Fatal(err)
+	}
+	current := &model.Attendance{ID: 1, WorkDate: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)}
+	segment := func(start, end string) model.WorkSegmentResponse {
+		return model.WorkSegmentResponse{StoreID: store.ID, StartTime: start, EndTime: end}
+	}
+	rest := func(start, end string) model.AttendanceBreakResponse {
+		return model.AttendanceBreakResponse{BreakType: model.BreakTypeRest, StartTime: start, EndTime: end}
+	}
+
+	tests := []struct {
+		name     string
+		segments []model.WorkSegmentResponse
+		breaks   []model.AttendanceBreakResponse
+		wantErr  bool
+	}{
+		{"通常の勤務", []model.WorkSegmentResponse{segment("09:00", "18:00")}, []model.AttendanceBreakResponse{rest("12:00", "13:00")}, false},
+		{"日付をまたぐ勤務", []model.WorkSegmentResponse{segment("22:00", "06:00")}, []model.AttendanceBreakResponse{rest("02:00", "02:30")}, false},
+		{"勤務中の休憩", []model.WorkSegmentResponse{segment("09:00", "")}, []model.AttendanceBreakResponse{rest("12:00", "")}, false},
+		{"終了が開始より前", []model.WorkSegmentResponse{segment("09:00", "08:00")}, nil, true},
+		{"勤務区間が重なる", []model.WorkSegmentResponse{segment("09:00", "13:00"), segment("12:00", "17:00")}, nil, true},
+		{"休憩が重なる", []model.WorkSegmentResponse{segment("09:00", "18:00")}, []model.AttendanceBreakResponse{rest("12:00", "13:00"), rest("12:30", "13:30")}, true},
+		{"休憩が勤務の時間外", []model.WorkSegmentResponse{segment("09:00", "18:00")}, []model.AttendanceBreakResponse{rest("19:00", "19:30")}, true},
+		{"休憩の終了が開始より前", []model.WorkSegmentResponse{segment("09:00", "18:00")}, []model.AttendanceBreakResponse{rest("12:00", "11:00")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildAttendanceEdit(storeRepo, current, &model.AttendanceResponse{ID: 1, Segments: tt.segments, Breaks: tt.breaks})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("buildAttendanceEdit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildAttendanceEditTimezone(t *testing.T) {
+	db := newTestDB(t)
+	storeRepo := repository.NewStoreRepository(db)
+	tokyo := &model.Store{Name: "東京店", Timezone: "Asia/Tokyo"}
+	newYork := &model.Store{Name: "ニューヨーク店", Timezone: "America/New_York"}
+	for _, store := range []*model.Store{tokyo, newYork} {
+		if err := storeRepo.CreateStore(store); err != nil {
+			t.Fatal(err)
+		}
+	}
+	current := &model.Attendance{ID: 1, WorkDate: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name     string
+		segments []model.WorkSegmentResponse
+		want     string
+	}{
+		{"勤務区間の店舗のタイムゾーン", []model.WorkSegmentResponse{{StoreID: newYork.ID, StartTime: "09:00", EndTime: "18:00"}}, "America/New_York"},
+		{"店舗を移動しても最初の勤務区間の店舗", []model.WorkSegmentResponse{{StoreID: tokyo.ID, StartTime: "09:00", EndTime: "12:00"}, {StoreID: newYork.ID, StartTime: "13:00", EndTime: "18:00"}}, "Asia/Tokyo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildAttendanceEdit(storeRepo, current, &model.AttendanceResponse{ID: 1, Segments: tt.segments})
+			if err != nil {
+				t.Fatalf("buildAttendanceEdit() error = %v", err)
+			}
+			loc, _ := time.LoadLocation(tt.want)
+			if want := time.Date(2024, 4, 1, 9, 0, 0, 0, loc); !got.Segments[0].StartTime.Equal(want) {
+				t.Errorf("start time = %v, want %v", got.Segments[0].StartTime, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// 集計シートの名前
+const timesheetSummarySheet = "集計"
+
+// 従業員ごとの勤務表の見出し
+var timesheetHeader = []any{"日付", "打刻", "休憩", "休憩時間", "労働時間", "深夜時間", "時間外", "時給", "備考"}
+
+// 集計シートの見出し
+var timesheetSummaryHeader = []any{
+	"従業員ID", "氏名", "出勤日数", "労働時間", "深夜時間", "時間外（月60時間以内）", "時間外（月60時間超）", "法定休日労働",
+	"基本給", "深夜割増", "時間外割増", "休日割増", "手当", "総支給額", "控除合計", "差引支給額",
+}
+
+// 店舗・月の勤務表をExcel形式で出力
+// 集計シートと従業員ごとのシートを作成し、合計は数式を使わず計算済みの値を書き込む
+func WriteTimesheetXLSX(w io.Writer, title string, summaries []EmployeeSummary, payrolls map[uint]*model.PayrollRun) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+
+	if err := f.SetSheetName("Sheet1", timesheetSummarySheet); err != nil {
+		return err
+	}
+	if err := writeTimesheetSummary(f, bold, title, summaries, payrolls); err != nil {
+		return err
+	}
+
+	used := map[string]bool{timesheetSummarySheet: true}
+	for _, summary := range summaries {
+		sheet := timesheetSheetName(summary.Employee, used)
+		if _, err := f.NewSheet(sheet); err != nil {
+			return err
+		}
+		if err := writeEmployeeTimesheet(f, bold, sheet, title, summary, payrolls[summary.Employee.ID]); err != nil {
+			return err
+		}
+	}
+
+	f.SetActiveSheet(0)
+	return f.Write(w)
+}
+
+// 集計シート（従業員ごとに1行、最後に合計の行）
+func writeTimesheetSummary(f *excelize.File, bold int, title string, summaries []EmployeeSummary, payrolls map[uint]*model.PayrollRun) error {
+	sheet := timesheetSummarySheet
+	if err := f.SetCellValue(sheet, "A1", title); err != nil {
+		return err
+	}
+	if err := setRow(f, sheet, 3, timesheetSummaryHeader, bold); err != nil {
+		return err
+	}
+
+	totals := make([]float64, len(timesheetSummaryHeader))
+	row := 4
+	for _, summary := range summaries {
+		sum := summary.Summary
+		values := []float64{
+			float64(len(sum.Attendances)), sum.TotalWorkTime, sum.LateNight, sum.OvertimeUpTo60, sum.OvertimeOver60, sum.HolidayWorkTime,
+		}
+		if run := payrolls[summary.Employee.ID]; run != nil {
+			values = append(values,
+				float64(run.BasePay), float64(run.LateNightPremium), float64(run.OvertimePremium),
+				float64(run.HolidayPremium), float64(run.Allowances), float64(run.GrossPay),
+				float64(run.TotalDeductions), float64(run.NetPay))
+		} else {
+			values = append(values, 0, 0, 0, 0, 0, 0, 0, 0)
+		}
+
+		cells := []any{summary.Employee.ID, summary.Employee.Name}
+		for i, value := range values {
+			cells = append(cells, value)
+			totals[i+2] += value
+		}
+		if err := setRow(f, sheet, row, cells, 0); err != nil {
+			return err
+		}
+		row++
+	}
+
+	cells := []any{"合計", ""}
+	for _, total := range totals[2:] {
+		cells = append(cells, total)
+	}
+	if err := setRow(f, sheet, row, cells, bold); err != nil {
+		return err
+	}
+	return f.SetColWidth(sheet, "B", "B", 16)
+}
+
+// 従業員ごとのシート（日ごとの行、合計、給与）
+func writeEmployeeTimesheet(f *excelize.File, bold int, sheet string, title string, summary EmployeeSummary, run *model.PayrollRun) error {
+	if err := f.SetCellValue(sheet, "A1", title); err != nil {
+		return err
+	}
+	if err := f.SetCellValue(sheet, "A2", fmt.Sprintf("%d %s", summary.Employee.ID, summary.Employee.Name)); err != nil {
+		return err
+	}
+	if err := setRow(f, sheet, 4, timesheetHeader, bold); err != nil {
+		return err
+	}
+
+	row := 5
+	for _, attendance := range summary.Summary.Attendances {
+		cells := []any{
+			attendance.WorkDate,
+			segmentsText(attendance.Segments),
+			breaksText(attendance.Breaks),
+			parseHours(attendance.TotalBreakTime),
+			parseHours(attendance.TotalWorkTime),
+			attendance.LateNight,
+			attendance.Overtime,
+			attendance.HourlyPay,
+			attendance.Remarks,
+		}
+		if err := setRow(f, sheet, row, cells, 0); err != nil {
+			return err
+		}
+		row++
+	}
+
+	sum := summary.Summary
+	if err := setRow(f, sheet, row, []any{"合計", "", "", "", sum.TotalWorkTime, sum.LateNight, sum.Overtime}, bold); err != nil {
+		return err
+	}
+
+	// 給与の内訳
+	row += 2
+	lines := [][]any{
+		{"時間外（月60時間超）", sum.OvertimeOver60},
+		{"法定休日労働", sum.HolidayWorkTime},
+	}
+	if run != nil {
+		lines = append(lines,
+			[]any{"基本給", run.BasePay},
+			[]any{"深夜割増", run.LateNightPremium},
+			[]any{"時間外割増", run.OvertimePremium},
+			[]any{"休日割増", run.HolidayPremium},
+			[]any{"手当", run.Allowances},
+			[]any{"総支給額", run.GrossPay},
+			[]any{"控除合計", run.TotalDeductions},
+			[]any{"差引支給額", run.NetPay},
+		)
+	}
+	for _, line := range lines {
+		if err := setRow(f, sheet, row, line, 0); err != nil {
+			return err
+		}
+		row++
+	}
+
+	if err := f.SetColWidth(sheet, "B", "C", 28); err != nil {
+		return err
+	}
+	return f.SetColWidth(sheet, "I", "I", 36)
+}
+
+// 指定した行に値を書き込む（style が0の場合は書式を設定しない）
+func setRow(f *excelize.File, sheet string, row int, values []any, style int) error {
+	start, err := excelize.CoordinatesToCellName(1, row)
+	if err != nil {
+		return err
+	}
+	if err := f.SetSheetRow(sheet, start, &values); err != nil {
+		return err
+	}
+	if style == 0 {
+		return nil
+	}
+	end, err := excelize.CoordinatesToCellName(len(values), row)
+	if err != nil {
+		return err
+	}
+	return f.SetCellStyle(sheet, start, end, style)
+}
+
+// "8.00" 形式の時間数を数値に変換（変換できない場合は0）
+func parseHours(value string) float64 {
+	hours, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return hours
+}
+
+// 従業員のシート名（Excelで使えない文字を除き31文字以内、重複する場合は番号を付ける）
+func timesheetSheetName(employee model.Employee, used map[string]bool) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return -1
+		}
+		return r
+	}, fmt.Sprintf("%d_%s", employee.ID, employee.Name))
+
+	runes := []rune(name)
+	if len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	base := name
+	for i := 2; used[name]; i++ {
+		suffix := fmt.Sprintf("(%d)", i)
+		name = string([]rune(base)[:min(len([]rune(base)), 31-len(suffix))]) + suffix
+	}
+	used[name] = true
+	return name
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"bytes"
+	"testing"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWriteTimesheetXLSX(t *testing.T) {
+	employee := func(id uint, name string) model.Employee {
+		e := model.Employee{Name: name}
+		e.ID = id
+		return e
+	}
+	summaries := []EmployeeSummary{
+		{Employee: employee(1, "山田"), Summary: &model.MonthlySummary{
+			Attendances: []model.AttendanceResponse{
+				{WorkDate: "4/1(月)", TotalWorkTime: "8.00", HourlyPay: 1200},
+				{WorkDate: "4/2(火)", TotalWorkTime: "4.50", LateNight: 1, HourlyPay: 1200},
+			},
+			TotalWorkTime: 12.5,
+			LateNight:     1,
+		}},
+		{Employee: employee(2, "佐藤"), Summary: &model.MonthlySummary{TotalWorkTime: 0}},
+	}
+	payrolls := map[uint]*model.PayrollRun{
+		1: {BasePay: 15000, LateNightPremium: 300, GrossPay: 15300, TotalDeductions: 500, NetPay: 14800},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTimesheetXLSX(&buf, "本店 2024年4月 勤務表", summaries, payrolls); err != nil {
+		t.Fatalf("WriteTimesheetXLSX() error = %v", err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	defer f.Close()
+
+	if got := f.GetSheetList(); len(got) != 3 || got[0] != "集計" || got[1] != "1_山田" || got[2] != "2_佐藤" {
+		t.Fatalf("sheets = %v", got)
+	}
+
+	tests := []struct {
+		name  string
+		sheet string
+		cell  string
+		want  string
+	}{
+		{"タイトル", "集計", "A1", "本店 2024年4月 勤務表"},
+		{"出勤日数", "集計", "C4", "2"},
+		{"総支給額", "集計", "N4", "15300"},
+		{"差引支給額", "集計", "P4", "14800"},
+		{"合計の行", "集計", "A6", "合計"},
+		{"労働時間の合計", "集計", "D6", "12.5"},
+		{"総支給額の合計", "集計", "N6", "15300"},
+		{"日付", "1_山田", "A6", "4/2(火)"},
+		{"日ごとの労働時間", "1_山田", "E6", "4.5"},
+		{"従業員の合計", "1_山田", "E7", "12.5"},
+		{"給与の計算がない従業員", "2_佐藤", "A5", "合計"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.GetCellValue(tt.sheet, tt.cell)
+			if err != nil {
+				t.Fatalf("GetCellValue() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("%s!%s = %q, want %q", tt.sheet, tt.cell, got, tt.want)
+			}
+			if formula, _ := f.GetCellFormula(tt.sheet, tt.cell); formula != "" {
+				t.Errorf("%s!%s に数式があります: %s", tt.sheet, tt.cell, formula)
+			}
+		})
+	}
+}
+
+func TestTimesheetSheetName(t *testing.T) {
+	employee := func(id uint, name string) model.Employee {
+		e := model.Employee{Name: name}
+		e.ID = id
+		return e
+	}
+	used := map[string]bool{"集計": true}
+
+	tests := []struct {
+		name     string
+		employee model.Employee
+		want     string
+	}{
+		{"IDと氏名", employee(1, "山田太郎"), "1_山田太郎"},
+		{"使えない文字を除く", employee(2, "a/b[c]"), "2_abc"},
+		{"31文字まで", employee(3, "あいうえおかきくけこさしすせそたちつてとなにぬねのはひふへほ"), "3_あいうえおかきくけこさしすせそたちつてとなにぬねのはひふへ"},
+		{"重複は番号を付ける", employee(1, "山田太郎"), "1_山田太郎(2)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := timesheetSheetName(tt.employee, used); got != tt.want {
+				t.Errorf("timesheetSheetName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+// 勤務日ごとの時給を決めるための時給の履歴
+type wageTable struct {
+	histories []model.WageHistory // 適用開始日順
+	current   int                 // 履歴がない場合の時給
+}
+
+// 従業員の時給の履歴を取得
+func loadWageTable(repo repositories.WageRepository, employee *model.Employee) (wageTable, error) {
+	histories, err := repo.GetWageHistories(employee.ID)
+	if err != nil {
+		return wageTable{}, err
+	}
+	return wageTable{histories: histories, current: employee.HourlyPay}, nil
+}
+
+// 指定した日に適用される時給
+// 最初の履歴より前の日は最初の時給を適用する
+func (t wageTable) rateOn(date time.Time) int {
+	if len(t.histories) == 0 {
+		return t.current
+	}
+
+	day := date.Format("2006-01-02")
+	rate := t.histories[0].HourlyPay
+	for _, history := range t.histories {
+		if history.EffectiveFrom.Format("2006-01-02") > day {
+			break
+		}
+		rate = history.HourlyPay
+	}
+	return rate
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+// 賃金ルールを適用して勤務日の賃金を計算するための情報
+type wageRules struct {
+	rules     []model.WageRule        // 従業員に適用される賃金ルール（ID順）
+	holidays  map[string]bool         // 祝日（YYYY-MM-DD）
+	locations map[uint]*time.Location // 店舗のタイムゾーン
+}
+
+// 従業員に適用される賃金ルールと、指定した期間の祝日を取得
+func loadWageRules(ruleRepo repositories.WageRuleRepository, holidayRepo repositories.HolidayRepository, storeRepo repositories.StoreRepository, employeeID uint, from time.Time, to time.Time) (wageRules, error) {
+	rules, err := ruleRepo.GetWageRulesForEmployee(employeeID)
+	if err != nil {
+		return wageRules{}, err
+	}
+
+	// 日付をまたぐ勤務のため翌日の祝日まで取得する
+	holidays, err := holidayRepo.GetHolidaysBetween(from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return wageRules{}, err
+	}
+	holidayDates := make(map[string]bool, len(holidays))
+	for _, holiday := range holidays {
+		holidayDates[holiday.Date.Format("2006-01-02")] = true
+	}
+
+	locations, err := storeLocations(storeRepo)
+	if err != nil {
+		return wageRules{}, err
+	}
+	return wageRules{rules: rules, holidays: holidayDates, locations: locations}, nil
+}
+
+// 勤務日の賃金（割増を除く）を計算
+// 勤務区間を5分ごとに分け、休憩中を除いた時間にそれぞれの時刻の時給を掛けて合計する
+func (w wageRules) dailyPay(attendance model.Attendance, base int) float64 {
+	// 誤差を避けるため「時給 × 分」で合計してから時間単位に直す
+	slotMinutes := int(roundTo / time.Minute)
+	rateMinutes := 0
+	for _, segment := range attendance.Segments {
+		// 勤務中の区間は集計しない
+		if segment.EndTime == nil {
+			continue
+		}
+
+		end := segment.EndTime.Truncate(roundTo)
+		for t := segment.StartTime.Truncate(roundTo); t.Before(end); t = t.Add(roundTo) {
+			if onBreak(attendance.Breaks, t) {
+				continue
+			}
+			rateMinutes += w.rateAt(base, segment.StoreID, attendance.WorkDate, t) * slotMinutes
+		}
+	}
+	return float64(rateMinutes) / 60
+}
+
+// 指定した店舗・時刻の時給（ルールは勤務日に適用されていたものだけを使う）
+// 時給を置き換えるルールは最も条件の細かいもの（同じ場合は後から登録したもの）を使い、加算額は当てはまるルールすべてを合計する
+func (w wageRules) rateAt(base int, storeID uint, workDate time.Time, t time.Time) int {
+	local := t.In(locationOf(w.locations, storeID))
+	dayType := w.dayType(local)
+
+	rate := base
+	best := -1
+	addition := 0
+	for _, rule := range w.rules {
+		if !rule.EffectiveOn(workDate) || !ruleMatches(rule, storeID, dayType, local) {
+			continue
+		}
+		if rule.HourlyPay > 0 {
+			if specificity := ruleSpecificity(rule); specificity >= best {
+				best = specificity
+				rate = rule.HourlyPay
+			}
+		}
+		addition += rule.Addition
+	}
+	return rate + addition
+}
+
+// 日付の曜日区分（土日と祝日は土日祝）
+func (w wageRules) dayType(local time.Time) model.DayType {
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday || w.holidays[local.Format("2006-01-02")] {
+		return model.DayTypeHoliday
+	}
+	return model.DayTypeWeekday
+}
+
+// ルールが店舗・曜日区分・時刻に当てはまるか
+func ruleMatches(rule model.WageRule, storeID uint, dayType model.DayType, local time.Time) bool {
+	if rule.StoreID != nil && *rule.StoreID != storeID {
+		return false
+	}
+	if rule.DayType != model.DayTypeAny && rule.DayType != dayType {
+		return false
+	}
+	return inTimeBand(rule.StartTime, rule.EndTime, local)
+}
+
+// ルールの条件の細かさ（従業員 > 店舗 > 曜日区分・時間帯）
+func ruleSpecificity(rule model.WageRule) int {
+	specificity := 0
+	if rule.EmployeeID != nil {
+		specificity += 4
+	}
+	if rule.StoreID != nil {
+		specificity += 2
+	}
+	if rule.DayType != model.DayTypeAny || rule.StartTime != "" || rule.EndTime != "" {
+		specificity++
+	}
+	return specificity
+}
+
+// 時刻が時間帯に含まれるか（開始が終了より後の場合は日付をまたぐ時間帯）
+func inTimeBand(start string, end string, local time.Time) bool {
+	from, to := 0, 24*60
+	if start != "" {
+		from = clockMinutes(start)
+	}
+	if end != "" {
+		to = clockMinutes(end)
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	if from <= to {
+		return minute >= from && minute < to
+	}
+	return minute >= from || minute < to
+}
+
+// HH:MM を0時からの分に変換（形式は登録時に検証済み）
+func clockMinutes(hhmm string) int {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0
+	}
+	return t.Hour()*60 + t.Minute()
+}
+
+// 時刻が終了済みの休憩中か
+func onBreak(breaks []model.AttendanceBreak, t time.Time) bool {
+	for _, breakRecord := range breaks {
+		if breakRecord.EndTime == nil {
+			continue
+		}
+		if !t.Before(breakRecord.StartTime.Truncate(roundTo)) && t.Before(breakRecord.EndTime.Truncate(roundTo)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+var (
+	ErrWageRuleNotFound = errors.New("賃金ルールが見つかりません")
+	ErrHolidayNotFound  = errors.New("祝日が見つかりません")
+)
+
+type WageRuleService struct {
+	repo        repositories.WageRuleRepository
+	holidayRepo repositories.HolidayRepository
+	empRepo     repositories.EmployeeRepository
+	storeRepo   repositories.StoreRepository
+	minimumRepo repositories.MinimumWageRepository
+	periodRepo  repositories.PeriodRepository
+}
+
+func NewWageRuleService(repo repositories.WageRuleRepository, holidayRepo repositories.HolidayRepository, empRepo repositories.EmployeeRepository, storeRepo repositories.StoreRepository, minimumRepo repositories.MinimumWageRepository, periodRepo repositories.PeriodRepository) *WageRuleService {
+	return &WageRuleService{repo: repo, holidayRepo: holidayRepo, empRepo: empRepo, storeRepo: storeRepo, minimumRepo: minimumRepo, periodRepo: periodRepo}
+}
+
+// 賃金ルール一覧取得
+func (s *WageRuleService) GetWageRules() ([]model.WageRule, error) {
+	return s.repo.GetWageRules()
+}
+
+// 今日の日付（日本時間）
+func wageRuleToday() time.Time {
+	now := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// 賃金ルール作成（適用開始日を省略した場合は今日から適用する）
+func (s *WageRuleService) CreateWageRule(rule *model.WageRule) error {
+	if err := s.validateWageRule(rule); err != nil {
+		return err
+	}
+	return s.repo.CreateWageRule(rule)
+}
+
+// 賃金ルール更新
+// 適用済みのルールは変更前の内容で前日まで適用し、変更後の内容を新しいルールとして適用開始日から適用する
+func (s *WageRuleService) UpdateWageRule(ruleID uint, input *model.WageRule) (*model.WageRule, error) {
+	rule, err := s.findWageRule(ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule.EffectiveTo != nil {
+		return nil, errors.New("適用が終了した賃金ルールは変更できません")
+	}
+
+	next := &model.WageRule{
+		EmployeeID:    input.EmployeeID,
+		StoreID:       input.StoreID,
+		DayType:       input.DayType,
+		StartTime:     input.StartTime,
+		EndTime:       input.EndTime,
+		HourlyPay:     input.HourlyPay,
+		Addition:      input.Addition,
+		Note:          input.Note,
+		EffectiveFrom: input.EffectiveFrom,
+		EffectiveTo:   input.EffectiveTo,
+	}
+	if err := s.validateWageRule(next); err != nil {
+		return nil, err
+	}
+
+	// まだ適用されていない（適用開始日が明日以降の）ルールはそのまま書き換える
+	if rule.EffectiveFrom.After(wageRuleToday()) {
+		next.ID = rule.ID
+		next.CreatedAt = rule.CreatedAt
+		if err := s.repo.UpdateWageRule(next); err != nil {
+			return nil, err
+		}
+		return next, nil
+	}
+
+	// 適用済みのルールは過去の勤務の計算に使われているため、適用開始日より後からしか変更できない
+	if !next.EffectiveFrom.After(rule.EffectiveFrom) {
+		return nil, fmt.Errorf("適用済みの賃金ルールは、適用開始日（%s）より後の日付から変更してください", rule.EffectiveFrom.Format("2006-01-02"))
+	}
+	effectiveTo := next.EffectiveFrom.AddDate(0, 0, -1)
+	rule.EffectiveTo = &effectiveTo
+	if err := s.repo.ReplaceWageRule(rule, next); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// 賃金ルール削除（まだ適用されていないルールのみ削除し、適用済みのルールは前日までで適用を終了する）
+// 今日から適用したルールは今日の打刻に使われているため、今日までで適用を終了する
+func (s *WageRuleService) DeleteWageRule(ruleID uint) error {
+	rule, err := s.findWageRule(ruleID)
+	if err != nil {
+		return err
+	}
+
+	today := wageRuleToday()
+	if rule.EffectiveFrom.After(today) {
+		return s.repo.DeleteWageRule(rule)
+	}
+	effectiveTo := today.AddDate(0, 0, -1)
+	if rule.EffectiveFrom.After(effectiveTo) {
+		effectiveTo = rule.EffectiveFrom
+	}
+	if rule.EffectiveTo != nil && !rule.EffectiveTo.After(effectiveTo) {
+		return errors.New("適用が終了した賃金ルールです")
+	}
+	rule.EffectiveTo = &effectiveTo
+	return s.repo.UpdateWageRule(rule)
+}
+
+// 賃金ルールIDから賃金ルールを取得（存在しない場合はエラー）
+func (s *WageRuleService) findWageRule(ruleID uint) (*model.WageRule, error) {
+	rule, err := s.repo.FindWageRuleByID(ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, ErrWageRuleNotFound
+	}
+	return rule, nil
+}
+
+// 入力値の検証
+func (s *WageRuleService) validateWageRule(rule *model.WageRule) error {
+	if !rule.DayType.IsValid() {
+		return fmt.Errorf("曜日区分が不正です: %s", rule.DayType)
+	}
+	for _, hhmm := range []string{rule.StartTime, rule.EndTime} {
+		if hhmm == "" {
+			continue
+		}
+		if _, err := time.Parse("15:04", hhmm); err != nil {
+			return fmt.Errorf("時刻はHH:MM形式で入力してください: %s", hhmm)
+		}
+	}
+	if rule.StartTime != "" && rule.StartTime == rule.EndTime {
+		return errors.New("時間帯の開始と終了が同じです")
+	}
+	if rule.HourlyPay < 0 || rule.Addition < 0 {
+		return errors.New("時給・加算額は0以上で入力してください")
+	}
+	if rule.HourlyPay == 0 && rule.Addition == 0 {
+		return errors.New("時給か加算額のどちらかを入力してください")
+	}
+
+	if rule.EffectiveFrom.IsZero() {
+		rule.EffectiveFrom = wageRuleToday()
+	}
+	rule.EffectiveFrom = time.Date(rule.EffectiveFrom.Year(), rule.EffectiveFrom.Month(), rule.EffectiveFrom.Day(), 0, 0, 0, 0, time.UTC)
+	if rule.EffectiveTo != nil {
+		effectiveTo := time.Date(rule.EffectiveTo.Year(), rule.EffectiveTo.Month(), rule.EffectiveTo.Day(), 0, 0, 0, 0, time.UTC)
+		if effectiveTo.Before(rule.EffectiveFrom) {
+			return errors.New("適用終了日は適用開始日以降の日付を入力してください")
+		}
+		rule.EffectiveTo = &effectiveTo
+	}
+
+	if rule.EmployeeID != nil {
+		employee, err := s.empRepo.FindEmpByEmpID(int(*rule.EmployeeID))
+		if err != nil {
+			return err
+		}
+		if employee == nil {
+			return errors.New("従業員が見つかりません")
+		}
+	}
+	if rule.StoreID != nil {
+		if _, err := findStore(s.storeRepo, *rule.StoreID); err != nil {
+			return err
+		}
+	}
+
+	// 締め済みの月にさかのぼってルールを適用すると確定した給与と合わなくなる
+	if rule.EffectiveFrom.Before(wageRuleToday()) {
+		storeIDs := []uint{}
+		if rule.StoreID != nil {
+			storeIDs = append(storeIDs, *rule.StoreID)
+		} else {
+			stores, err := s.storeRepo.GetAllStores(true)
+			if err != nil {
+				return err
+			}
+			for _, store := range stores {
+				storeIDs = append(storeIDs, store.ID)
+			}
+		}
+		for _, storeID := range storeIDs {
+			if err := ensurePeriodsOpenSince(s.periodRepo, storeID, rule.EffectiveFrom, wageRuleToday()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 指定した年の祝日を取得
+func (s *WageRuleService) GetHolidays(year int) ([]model.Holiday, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return s.holidayRepo.GetHolidaysBetween(from, from.AddDate(1, 0, -1))
+}
+
+// 祝日登録
+func (s *WageRuleService) CreateHoliday(date time.Time, name string) (*model.Holiday, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("祝日名を入力してください")
+	}
+
+	holiday := &model.Holiday{
+		Date: time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		Name: name,
+	}
+	if err := s.holidayRepo.CreateHoliday(holiday); err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+// 祝日削除
+func (s *WageRuleService) DeleteHoliday(holidayID uint) error {
+	holiday, err := s.holidayRepo.FindHolidayByID(holidayID)
+	if err != nil {
+		return err
+	}
+	if holiday == nil {
+		return ErrHolidayNotFound
+	}
+	return s.holidayRepo.DeleteHoliday(holiday)
+}
+
+// 最低賃金の一覧を取得（prefecture が空の場合は全都道府県）
+func (s *WageRuleService) GetMinimumWages(prefecture string) ([]model.MinimumWage, error) {
+	if prefecture != "" && !model.IsValidPrefecture(prefecture) {
+		return nil, fmt.Errorf("都道府県が不正です: %s", prefecture)
+	}
+	return s.minimumRepo.GetMinimumWages(prefecture)
+}
+
+// 最低賃金登録（改定のたびに適用開始日を指定して追加する）
+func (s *WageRuleService) CreateMinimumWage(prefecture string, effectiveFrom time.Time, hourlyWage int) (*model.MinimumWage, error) {
+	prefecture = strings.TrimSpace(prefecture)
+	if !model.IsValidPrefecture(prefecture) {
+		return nil, fmt.Errorf("都道府県が不正です: %s", prefecture)
+	}
+	if hourlyWage <= 0 {
+		return nil, errors.New("最低賃金は1円以上で入力してください")
+	}
+
+	wage := &model.MinimumWage{
+		Prefecture:    prefecture,
+		EffectiveFrom: time.Date(effectiveFrom.Year(), effectiveFrom.Month(), effectiveFrom.Day(), 0, 0, 0, 0, time.UTC),
+		HourlyWage:    hourlyWage,
+	}
+	existing, err := s.minimumRepo.GetMinimumWages(prefecture)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if other.EffectiveFrom.Format("2006-01-02") == wage.EffectiveFrom.Format("2006-01-02") {
+			return nil, fmt.Errorf("%sの%sから適用する最低賃金は登録済みです", prefecture, wage.EffectiveFrom.Format("2006-01-02"))
+		}
+	}
+
+	if err := s.minimumRepo.CreateMinimumWage(wage); err != nil {
+		return nil, err
+	}
+	return wage, nil
+}
+
+// 最低賃金削除
+func (s *WageRuleService) DeleteMinimumWage(wageID uint) error {
+	wage, err := s.minimumRepo.FindMinimumWageByID(wageID)
+	if err != nil {
+		return err
+	}
+	if wage == nil {
+		return ErrMinimumWageNotFound
+	}
+	return s.minimumRepo.DeleteMinimumWage(wage)
+}
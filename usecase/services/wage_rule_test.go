@@ -0,0 +1,196 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	repository "github.com/techyoichiro/jobreco-api/infra/database/repositories"
+)
+
+func TestWageRulesDailyPay(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	employeeID, storeB := uint(1), uint(2)
+	rules := wageRules{
+		rules: []model.WageRule{
+			// 店舗Bでは時給1300円
+			{EmployeeID: &employeeID, StoreID: &storeB, HourlyPay: 1300},
+			// 土日祝のランチは100円加算
+			{DayType: model.DayTypeHoliday, StartTime: "11:00", EndTime: "14:00", Addition: 100},
+		},
+		holidays:  map[string]bool{"2024-04-29": true},
+		locations: map[uint]*time.Location{1: jst, 2: jst},
+	}
+
+	// 10:00〜15:00 に勤務し、12:00〜12:30 に休憩
+	attendance := func(day int, storeID uint) model.Attendance {
+		start := time.Date(2024, 4, day, 10, 0, 0, 0, jst)
+		end := time.Date(2024, 4, day, 15, 0, 0, 0, jst)
+		breakStart := time.Date(2024, 4, day, 12, 0, 0, 0, jst)
+		breakEnd := time.Date(2024, 4, day, 12, 30, 0, 0, jst)
+		return model.Attendance{
+			Segments: []model.WorkSegment{{StoreID: storeID, StartTime: start, EndTime: &end}},
+			Breaks:   []model.AttendanceBreak{{StartTime: breakStart, EndTime: &breakEnd}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		attendance model.Attendance
+		want       float64
+	}{
+		{"平日・店舗A", attendance(24, 1), 4.5 * 1000},
+		{"平日・店舗B", attendance(24, 2), 4.5 * 1300},
+		{"土曜・店舗A", attendance(27, 1), 4.5*1000 + 2.5*100},
+		{"土曜・店舗B", attendance(27, 2), 4.5*1300 + 2.5*100},
+		{"祝日・店舗B", attendance(29, 2), 4.5*1300 + 2.5*100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.dailyPay(tt.attendance, 1000); got != tt.want {
+				t.Errorf("dailyPay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWageRulesRateAtSpecificity(t *testing.T) {
+	employeeID, storeID := uint(1), uint(1)
+	rules := wageRules{rules: []model.WageRule{
+		{EmployeeID: &employeeID, HourlyPay: 1200},
+		{StoreID: &storeID, HourlyPay: 1100},
+		{StoreID: &storeID, DayType: model.DayTypeWeekday, HourlyPay: 1050},
+	}}
+
+	// 従業員を指定したルールが店舗を指定したルールより優先される
+	at := time.Date(2024, 4, 24, 10, 0, 0, 0, time.FixedZone("Asia/Tokyo", 9*60*60))
+	if got := rules.rateAt(1000, storeID, at, at); got != 1200 {
+		t.Errorf("rateAt() = %d, want 1200", got)
+	}
+}
+
+func TestInTimeBand(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 4, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		start, end string
+		at         time.Time
+		want       bool
+	}{
+		{"終日", "", "", at(3, 0), true},
+		{"時間帯内", "11:00", "14:00", at(11, 0), true},
+		{"終了時刻は含まない", "11:00", "14:00", at(14, 0), false},
+		{"日付をまたぐ時間帯（夜）", "22:00", "05:00", at(23, 30), true},
+		{"日付をまたぐ時間帯（朝）", "22:00", "05:00", at(4, 55), true},
+		{"日付をまたぐ時間帯の外", "22:00", "05:00", at(12, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inTimeBand(tt.start, tt.end, tt.at); got != tt.want {
+				t.Errorf("inTimeBand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWageRulesRateAtEffectiveDates(t *testing.T) {
+	date := func(d int) time.Time { return time.Date(2024, 4, d, 0, 0, 0, 0, time.UTC) }
+	ended := date(14)
+	rules := wageRules{rules: []model.WageRule{
+		{HourlyPay: 1100, EffectiveFrom: date(1), EffectiveTo: &ended},
+		{HourlyPay: 1200, EffectiveFrom: date(15)},
+	}}
+
+	tests := []struct {
+		name string
+		day  int
+		want int
+	}{
+		{"適用開始前は基本の時給", 0, 1000},
+		{"変更前のルール", 14, 1100},
+		{"変更後のルール", 15, 1200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workDate := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, tt.day-1)
+			at := workDate.Add(10 * time.Hour)
+			if got := rules.rateAt(1000, 1, workDate, at); got != tt.want {
+				t.Errorf("rateAt() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWageRuleServiceKeepsPastRules(t *testing.T) {
+	db := newTestDB(t)
+	service := NewWageRuleService(repository.NewWageRuleRepository(db), repository.NewHolidayRepository(db), repository.NewEmployeeRepository(db), repository.NewStoreRepository(db), repository.NewMinimumWageRepository(db), repository.NewPeriodRepository(db))
+
+	today := wageRuleToday()
+	rule := &model.WageRule{HourlyPay: 1100, EffectiveFrom: today.AddDate(0, -1, 0)}
+	if err := service.CreateWageRule(rule); err != nil {
+		t.Fatalf("CreateWageRule() error = %v", err)
+	}
+
+	// 適用済みのルールの変更は前日で終了し、新しいルールを作成する
+	updated, err := service.UpdateWageRule(rule.ID, &model.WageRule{HourlyPay: 1200})
+	if err != nil {
+		t.Fatalf("UpdateWageRule() error = %v", err)
+	}
+	if updated.ID == rule.ID || !updated.EffectiveFrom.Equal(today) {
+		t.Errorf("UpdateWageRule() = %+v, want new rule from %s", updated, today.Format("2006-01-02"))
+	}
+	rules, err := service.GetWageRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 2 || rules[0].HourlyPay != 1100 || rules[0].EffectiveTo == nil ||
+		rules[0].EffectiveTo.Format("2006-01-02") != today.AddDate(0, 0, -1).Format("2006-01-02") {
+		t.Fatalf("rules after update = %+v", rules)
+	}
+
+	// 適用済みのルールは適用開始日と同じ日付では書き換えられない
+	if _, err := service.UpdateWageRule(updated.ID, &model.WageRule{HourlyPay: 1300, EffectiveFrom: today}); err == nil {
+		t.Errorf("UpdateWageRule() with the same effective date error = nil")
+	}
+	if _, err := service.UpdateWageRule(updated.ID, &model.WageRule{HourlyPay: 1300, EffectiveFrom: today.AddDate(0, 0, -1)}); err == nil {
+		t.Errorf("UpdateWageRule() with an earlier effective date error = nil")
+	}
+
+	// 今日から適用したルールは今日の打刻に使われているため、削除しても今日までは残す
+	if err := service.DeleteWageRule(updated.ID); err != nil {
+		t.Fatalf("DeleteWageRule() error = %v", err)
+	}
+	rules, err = service.GetWageRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 2 || rules[1].EffectiveTo == nil || !rules[1].EffectiveTo.Equal(today) {
+		t.Errorf("rules after delete = %+v, want the rule from today ended today", rules)
+	}
+	if err := service.DeleteWageRule(updated.ID); err == nil {
+		t.Errorf("DeleteWageRule() on ended rule error = nil")
+	}
+	if err := service.DeleteWageRule(rule.ID); err == nil {
+		t.Errorf("DeleteWageRule() on ended rule error = nil")
+	}
+
+	// まだ適用されていないルールは書き換え・削除すると残らない
+	future := &model.WageRule{HourlyPay: 1400, EffectiveFrom: today.AddDate(0, 0, 7)}
+	if err := service.CreateWageRule(future); err != nil {
+		t.Fatalf("CreateWageRule() error = %v", err)
+	}
+	if updated, err := service.UpdateWageRule(future.ID, &model.WageRule{HourlyPay: 1500, EffectiveFrom: today.AddDate(0, 0, 7)}); err != nil || updated.ID != future.ID {
+		t.Fatalf("UpdateWageRule() on future rule = %+v, %v, want updated in place", updated, err)
+	}
+	if err := service.DeleteWageRule(future.ID); err != nil {
+		t.Fatalf("DeleteWageRule() error = %v", err)
+	}
+	if rules, _ := service.GetWageRules(); len(rules) != 2 {
+		t.Errorf("rules after deleting future rule = %+v, want 2", rules)
+	}
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	"github.com/techyoichiro/jobreco-api/domain/repositories"
+)
+
+type WageService struct {
+	repo        repositories.WageRepository
+	empRepo     repositories.EmployeeRepository
+	periodRepo  repositories.PeriodRepository
+	storeRepo   repositories.StoreRepository
+	minimumRepo repositories.MinimumWageRepository
+}
+
+func NewWageService(repo repositories.WageRepository, empRepo repositories.EmployeeRepository, periodRepo repositories.PeriodRepository, storeRepo repositories.StoreRepository, minimumRepo repositories.MinimumWageRepository) *WageService {
+	return &WageService{repo: repo, empRepo: empRepo, periodRepo: periodRepo, storeRepo: storeRepo, minimumRepo: minimumRepo}
+}
+
+// 操作者が対象従業員に対して指定した操作を行えるか確認し、従業員を返す
+func (s *WageService) authorizeEmployee(actor Actor, p model.Permission, employeeID uint) (*model.Employee, error) {
+	employee, err := s.empRepo.FindEmpByEmpID(int(employeeID))
+	if err != nil {
+		return nil, err
+	}
+	if err := actor.authorize(p, employee); err != nil {
+		return nil, err
+	}
+	return employee, nil
+}
+
+// 時給の履歴を取得
+func (s *WageService) GetWageHistories(actor Actor, employeeID uint) ([]model.WageHistory, error) {
+	if _, err := s.authorizeEmployee(actor, model.PermViewPayroll, employeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetWageHistories(employeeID)
+}
+
+// 時給を変更（適用開始日を過ぎていれば現在の時給も更新する）
+// 適用開始日が先の場合は、適用開始日を迎えたときに ApplyDueWageChanges で現在の時給に反映する
+func (s *WageService) ChangeHourlyPay(actor Actor, employeeID uint, hourlyPay int, effectiveFrom time.Time, reason string) (*model.WageHistory, error) {
+	employee, err := s.authorizeEmployee(actor, model.PermUpdateHourlyPay, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := newWageHistory(actor, employee.ID, hourlyPay, effectiveFrom, reason)
+	if err != nil {
+		return nil, err
+	}
+	// 適用開始日に担当店舗の都道府県で適用される最低賃金を下回る時給は登録しない
+	minimums, err := loadMinimumWageTable(s.minimumRepo, s.storeRepo)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMinimumWage(hourlyPay, minimums.forStore(employee.StoreID(), history.EffectiveFrom)); err != nil {
+		return nil, err
+	}
+	// 締め済みの月にさかのぼって時給を変更すると確定した給与と合わなくなる
+	if err := ensurePeriodsOpenSince(s.periodRepo, employee.StoreID(), history.EffectiveFrom, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if !isWageEffective(history) || employee.HourlyPay == hourlyPay {
+		if err := s.repo.CreateWageHistory(history); err != nil {
+			return nil, err
+		}
+		return history, nil
+	}
+
+	// 履歴の追加と現在の時給の更新・監査ログを1つのトランザクションで保存する
+	before, err := snapshotOf(employee)
+	if err != nil {
+		return nil, err
+	}
+	employee.HourlyPay = hourlyPay
+	log, err := buildAudit(employeeAudit(actor, employee), before, employee)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.empRepo.SaveEmployeeChange(repositories.EmployeeChange{Employee: employee, WageHistory: history, AuditLog: log}); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// 適用開始日を迎えた時給の履歴を従業員の現在の時給に反映し、反映した人数を返す
+// 先の日付で登録した時給の変更は登録時には反映されないため、日付が変わるたびに実行する
+// 複数のサーバで同時に実行しても、同じ変更は1回だけ反映される
+func (s *WageService) ApplyDueWageChanges() (int, error) {
+	today := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
+	histories, err := s.repo.GetEffectiveWageHistories(today)
+	if err != nil {
+		return 0, err
+	}
+
+	// 従業員ごとに最後に適用が始まった履歴が現在の時給
+	latest := map[uint]model.WageHistory{}
+	employeeIDs := []uint{}
+	for _, history := range histories {
+		if _, ok := latest[history.EmployeeID]; !ok {
+			employeeIDs = append(employeeIDs, history.EmployeeID)
+		}
+		latest[history.EmployeeID] = history
+	}
+
+	applied := 0
+	for _, employeeID := range employeeIDs {
+		history := latest[employeeID]
+		employee, err := s.empRepo.FindEmpByEmpID(int(employeeID))
+		if err != nil {
+			return applied, err
+		}
+		if employee == nil || employee.HourlyPay == history.HourlyPay {
+			continue
+		}
+
+		before, err := snapshotOf(employee)
+		if err != nil {
+			return applied, err
+		}
+		employee.HourlyPay = history.HourlyPay
+		// 監査ログの操作者は時給の変更を登録した従業員とする
+		actor := Actor{}
+		if history.ChangedByID != nil {
+			actor.EmployeeID = *history.ChangedByID
+		}
+		log, err := buildAudit(employeeAudit(actor, employee), before, employee)
+		if err != nil {
+			return applied, err
+		}
+		// 他のサーバが先に反映した場合は更新も監査ログの記録もしない
+		ok, err := s.empRepo.ApplyHourlyPay(employee.ID, history.HourlyPay, log)
+		if err != nil {
+			return applied, err
+		}
+		if ok {
+			applied++
+		}
+	}
+	return applied, nil
+}
+
+// 時給の履歴を作成（適用開始日は日付のみ保持する）
+func newWageHistory(actor Actor, employeeID uint, hourlyPay int, effectiveFrom time.Time, reason string) (*model.WageHistory, error) {
+	if hourlyPay <= 0 {
+		return nil, errors.New("時給は1円以上で入力してください")
+	}
+
+	history := &model.WageHistory{
+		EmployeeID:    employeeID,
+		HourlyPay:     hourlyPay,
+		EffectiveFrom: time.Date(effectiveFrom.Year(), effectiveFrom.Month(), effectiveFrom.Day(), 0, 0, 0, 0, time.UTC),
+		Reason:        reason,
+	}
+	if actor.EmployeeID != 0 {
+		changedBy := actor.EmployeeID
+		history.ChangedByID = &changedBy
+	}
+	return history, nil
+}
+
+// 適用開始日を過ぎている（今日以前）か
+func isWageEffective(history *model.WageHistory) bool {
+	today := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60)).Format("2006-01-02")
+	return history.EffectiveFrom.Format("2006-01-02") <= today
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	model "github.com/techyoichiro/jobreco-api/domain/models"
+	repository "github.com/techyoichiro/jobreco-api/infra/database/repositories"
+)
+
+func TestWageTableRateOn(t *testing.T) {
+	day := func(m, d int) time.Time {
+		return time.Date(2024, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	}
+	table := wageTable{
+		histories: []model.WageHistory{
+			{HourlyPay: 1100, EffectiveFrom: day(1, 1)},
+			{HourlyPay: 1200, EffectiveFrom: day(4, 15)},
+			{HourlyPay: 1300, EffectiveFrom: day(10, 1)},
+		},
+		current: 1200,
+	}
+
+	tests := []struct {
+		name string
+		date time.Time
+		want int
+	}{
+		{"最初の履歴より前は最初の時給", day(1, 1).AddDate(0, 0, -10), 1100},
+		{"昇給前日", day(4, 14), 1100},
+		{"昇給当日", day(4, 15), 1200},
+		{"将来の昇給後", day(10, 2), 1300},
+		{"日本時間の日付で判定", time.Date(2024, 4, 15, 0, 30, 0, 0, time.FixedZone("Asia/Tokyo", 9*60*60)), 1200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := table.rateOn(tt.date); got != tt.want {
+				t.Errorf("rateOn() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if got := (wageTable{current: 1162}).rateOn(day(4, 1)); got != 1162 {
+		t.Errorf("rateOn() without histories = %d, want 1162", got)
+	}
+}
+
+func TestWageServiceApplyDueWageChanges(t *testing.T) {
+	db := newTestDB(t)
+	service := NewWageService(repository.NewWageRepository(db), repository.NewEmployeeRepository(db), repository.NewPeriodRepository(db), repository.NewStoreRepository(db), repository.NewMinimumWageRepository(db))
+	owner := Actor{EmployeeID: 100, Role: model.RoleOwner}
+
+	employee := &model.Employee{Name: "山田", LoginID: "yamada", Password: "x", RoleID: int(model.RoleStaff), HourlyPay: 1200}
+	if err := db.Create(employee).Error; err != nil {
+		t.Fatal(err)
+	}
+	hourlyPay := func() int {
+		var current model.Employee
+		if err := db.First(&current, employee.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		return current.HourlyPay
+	}
+	auditLogs := func() int64 {
+		var count int64
+		if err := db.Model(&model.AuditLog{}).Where("employee_id = ?", employee.ID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	// 適用開始日が先の変更は登録時には反映しない
+	today := time.Now().In(time.FixedZone("Asia/Tokyo", 9*60*60))
+	history, err := service.ChangeHourlyPay(owner, employee.ID, 1300, today.AddDate(0, 0, 1), "昇給")
+	if err != nil {
+		t.Fatalf("ChangeHourlyPay() error = %v", err)
+	}
+	if got := hourlyPay(); got != 1200 {
+		t.Fatalf("hourly pay before effective date = %d, want 1200", got)
+	}
+	if applied, err := service.ApplyDueWageChanges(); err != nil || applied != 0 {
+		t.Fatalf("ApplyDueWageChanges() = %d, %v, want 0", applied, err)
+	}
+
+	// 適用開始日を迎えたら反映し、監査ログを残す
+	if err := db.Model(history).Update("effective_from", time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if applied, err := service.ApplyDueWageChanges(); err != nil || applied != 1 {
+		t.Fatalf("ApplyDueWageChanges() = %d, %v, want 1", applied, err)
+	}
+	if got := hourlyPay(); got != 1300 {
+		t.Errorf("hourly pay after effective date = %d, want 1300", got)
+	}
+	if got := auditLogs(); got != 1 {
+		t.Errorf("audit logs = %d, want 1", got)
+	}
+
+	// 反映済みの場合は何もしない
+	if applied, err := service.ApplyDueWageChanges(); err != nil || applied != 0 {
+		t.Errorf("ApplyDueWageChanges() = %d, %v, want 0", applied, err)
+	}
+
+	// 他のサーバが先に反映した時給は、反映前に読み込んだ内容で再度反映しても監査ログを残さない
+	applied, err := repository.NewEmployeeRepository(db).ApplyHourlyPay(employee.ID, 1300, &model.AuditLog{EmployeeID: employee.ID, Entity: model.AuditEntityEmployee, EntityID: employee.ID})
+	if err != nil || applied {
+		t.Errorf("ApplyHourlyPay() = %v, %v, want false", applied, err)
+	}
+	if got := auditLogs(); got != 1 {
+		t.Errorf("audit logs after applying twice = %d, want 1", got)
+	}
+}